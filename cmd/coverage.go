@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackclarke/GoStubGen/internal/generator"
+	"github.com/jackclarke/GoStubGen/stubs"
+	"github.com/spf13/cobra"
+)
+
+var coverageDir string
+var coverageConfigs []string
+var coverageHTMLPath string
+var coverageJSONPath string
+var coverageAllRuns bool
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Combine mock usage reports and list unused mock configuration",
+	Long: `Combines the mock usage reports written by test binaries run with STUBS_COVERAGE set,
+and lists the interface methods no test called and the mock configuration that was never used.
+Only the reports of the latest test run are combined unless --all-runs is given.
+Pass the YAML configs to include interfaces whose mocks were never built.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if coverageDir == "" {
+			log.Fatalf("No report directory: pass --dir or set %s", stubs.CoverageEnv)
+		}
+		report, skipped, err := readCoverageReports(coverageDir, coverageAllRuns)
+		if err != nil {
+			log.Fatalf("Failed to read coverage reports: %v", err)
+		}
+		if skipped > 0 {
+			log.Printf("Ignoring %d reports from earlier test runs, pass --all-runs to include them", skipped)
+		}
+
+		for _, path := range coverageConfigs {
+			config, err := readConfig(path)
+			if err != nil {
+				log.Fatal(err)
+			}
+			includeConfigInterfaces(report, config)
+		}
+
+		if coverageJSONPath != "" {
+			if err := writeReportFile(coverageJSONPath, report.WriteJSON); err != nil {
+				log.Fatalf("Failed to write JSON report: %v", err)
+			}
+		}
+		if coverageHTMLPath != "" {
+			if err := writeReportFile(coverageHTMLPath, report.WriteHTML); err != nil {
+				log.Fatalf("Failed to write HTML report: %v", err)
+			}
+		}
+
+		printCoverage(cmd.OutOrStdout(), report)
+	},
+}
+
+// readCoverageReports merges the JSON reports in dir written by the latest test run, or every report if
+// allRuns is set. The latest run is that of the most recently written report. It returns the number of
+// reports left out.
+func readCoverageReports(dir string, allRuns bool) (*stubs.CoverageReport, int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(paths) == 0 {
+		return nil, 0, fmt.Errorf("no reports found in %s", dir)
+	}
+	reports := make([]*stubs.CoverageReport, 0, len(paths))
+	var latest *stubs.CoverageReport
+	var latestTime time.Time
+	for _, path := range paths {
+		r, err := stubs.ReadCoverageReport(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		if latest == nil || info.ModTime().After(latestTime) {
+			latest, latestTime = r, info.ModTime()
+		}
+		reports = append(reports, r)
+	}
+	if allRuns {
+		return stubs.MergeCoverageReports(reports...), 0, nil
+	}
+	var run []*stubs.CoverageReport
+	for _, r := range reports {
+		if r.Run == latest.Run {
+			run = append(run, r)
+		}
+	}
+	return stubs.MergeCoverageReports(run...), len(reports) - len(run), nil
+}
+
+// includeConfigInterfaces lists every method of the config's interfaces, including embedded ones, in the report
+func includeConfigInterfaces(report *stubs.CoverageReport, config Config) {
+	interfaceMethods, _ := generator.GetMethods(config.Implementers, config.Interfaces)
+	for _, iface := range config.Interfaces {
+		var names []string
+		for _, m := range interfaceMethods.FullSets[iface.Name] {
+			names = append(names, m.Name)
+		}
+		report.Include(config.Package+"."+iface.Name, names...)
+	}
+}
+
+func writeReportFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printCoverage writes a table per interface followed by a summary of the methods never called
+func printCoverage(w io.Writer, report *stubs.CoverageReport) {
+	fmt.Fprintf(w, "Mock coverage from %d test binaries\n", len(report.Binaries))
+	total, never := 0, 0
+	for _, iface := range report.Interfaces {
+		fmt.Fprintf(w, "\n%s\n", iface.Name)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  METHOD\tCALLS\tMOCKED\tNOTES")
+		for _, m := range iface.Methods {
+			total++
+			var notes []string
+			if !m.Exercised() {
+				never++
+				notes = append(notes, "never called")
+			}
+			notes = append(notes, m.Unused()...)
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t%s\n", m.Name, m.Calls, m.MockedCalls, strings.Join(notes, "; "))
+		}
+		tw.Flush()
+	}
+	fmt.Fprintf(w, "\n%d of %d methods never called\n", never, total)
+}
+
+func init() {
+	rootCmd.AddCommand(coverageCmd)
+	coverageCmd.Flags().StringVarP(&coverageDir, "dir", "d", os.Getenv(stubs.CoverageEnv), "Directory of reports written by the tests (defaults to $"+stubs.CoverageEnv+")")
+	coverageCmd.Flags().StringSliceVarP(&coverageConfigs, "config", "c", nil, "YAML configs whose interfaces to list")
+	coverageCmd.Flags().StringVar(&coverageHTMLPath, "html", "", "Write the combined report as HTML to this path")
+	coverageCmd.Flags().StringVar(&coverageJSONPath, "json", "", "Write the combined report as JSON to this path")
+	coverageCmd.Flags().BoolVar(&coverageAllRuns, "all-runs", false, "Combine the reports of every test run in the directory, not just the latest")
+}
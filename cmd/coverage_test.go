@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackclarke/GoStubGen/stubs"
+)
+
+// writeCoverageReport writes a report for run with one call to Honk, modified at the given time
+func writeCoverageReport(t *testing.T, dir, name, run string, modified time.Time) {
+	t.Helper()
+	r := stubs.MergeCoverageReports(&stubs.CoverageReport{Run: run, Binaries: []string{name}})
+	r.Include("vehicle.Vehicle", "Honk")
+	r.Interfaces[0].Methods[0].Calls = 1
+	path := filepath.Join(dir, name+".json")
+	if err := writeReportFile(path, r.WriteJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadCoverageReportsLatestRun(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeCoverageReport(t, dir, "stale.test", "run-1", now.Add(-time.Hour))
+	writeCoverageReport(t, dir, "driver.test", "run-2", now)
+	writeCoverageReport(t, dir, "vehicle.test", "run-2", now.Add(-time.Second))
+
+	report, skipped, err := readCoverageReports(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 || strings.Join(report.Binaries, ",") != "driver.test,vehicle.test" {
+		t.Fatalf("expected only the latest run, got %v with %d skipped", report.Binaries, skipped)
+	}
+	if calls := report.Interfaces[0].Methods[0].Calls; calls != 2 {
+		t.Fatalf("expected the calls of the latest run, got %d", calls)
+	}
+
+	report, skipped, err = readCoverageReports(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 0 || len(report.Binaries) != 3 {
+		t.Fatalf("expected every run with --all-runs, got %v with %d skipped", report.Binaries, skipped)
+	}
+}
@@ -14,12 +14,26 @@ import (
 type Config struct {
 	Package       string                      `yaml:"package"`
 	Importer      string                      `yaml:"importer"`
+	Mocks         generator.MockSpec          `yaml:"mocks"`
 	CustomStructs []generator.StructSpec      `yaml:"custom_structs"`
 	CustomTypes   []generator.CustomTypesSpec `yaml:"custom_types"`
 	Implementers  []generator.StructSpec      `yaml:"implementers"`
 	Interfaces    []generator.InterfaceSpec   `yaml:"interfaces"`
 }
 
+// readConfig reads and parses the YAML config at path
+func readConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("Failed to read config file: %v", err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("Invalid YAML format: %v", err)
+	}
+	return config, nil
+}
+
 var configPath string
 var flattenEmbedsFlag bool
 
@@ -27,20 +41,15 @@ var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate Go code from a YAML config",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(configPath)
-		if err != nil {
-			log.Fatalf("Failed to read config file: %v", err)
-		}
-
-		var config Config
-		err = yaml.Unmarshal(data, &config)
+		config, err := readConfig(configPath)
 		if err != nil {
-			log.Fatalf("Invalid YAML format: %v", err)
+			log.Fatal(err)
 		}
 
 		commonSpec := generator.CommonSpec{
 			Package:  config.Package,
 			Importer: config.Importer,
+			Mocks:    config.Mocks,
 		}
 
 		// get unique methods of each interface and struct (with methods provided by embedded interfaces/structs removed)
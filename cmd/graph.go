@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/jackclarke/GoStubGen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var graphConfigPath string
+var graphFormat string
+var graphFocus string
+var graphDepth int
+var graphOutput string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Draw the interfaces, types and implementers of a config as a DOT or Mermaid diagram",
+	Long: `Draws every interface, implementer, custom struct and custom type as a node, with edges for
+embedding, implemented interfaces and the custom types each method takes or returns.
+Use --focus to draw only the types within --depth edges of one type.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := readConfig(graphConfigPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := validateConfig(config); err != nil {
+			log.Fatalf("Invalid config:\n%v", err)
+		}
+
+		g := buildSpecGraph(config)
+		if graphFocus != "" {
+			if g.node(graphFocus) == nil {
+				log.Fatalf("No type named %s in %s", graphFocus, graphConfigPath)
+			}
+			g = g.neighbourhood(graphFocus, graphDepth)
+		}
+
+		var write func(io.Writer) error
+		switch graphFormat {
+		case "dot":
+			write = g.writeDOT
+		case "mermaid":
+			write = g.writeMermaid
+		default:
+			log.Fatalf("Unknown format %q, use dot or mermaid", graphFormat)
+		}
+
+		if graphOutput == "" {
+			if err := write(cmd.OutOrStdout()); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if err := writeReportFile(graphOutput, write); err != nil {
+			log.Fatalf("Failed to write graph: %v", err)
+		}
+	},
+}
+
+// Node kinds
+const (
+	nodeInterface   = "interface"
+	nodeImplementer = "implementer"
+	nodeStruct      = "struct"
+	nodeType        = "type"
+)
+
+// Edge kinds
+const (
+	edgeEmbeds     = "embeds"
+	edgeImplements = "implements"
+	edgeUses       = "uses"
+)
+
+type graphNode struct {
+	Name string
+	Kind string
+	// Members are the declared methods of interfaces and implementers, the fields of structs, or the definition of a type
+	Members []string
+}
+
+type graphEdge struct {
+	From, To string
+	Kind     string
+	// Label names the methods behind a uses edge
+	Label string
+}
+
+// specGraph is the types of a config and how they depend on each other
+type specGraph struct {
+	nodes []graphNode
+	edges []graphEdge
+}
+
+func (g *specGraph) node(name string) *graphNode {
+	for i := range g.nodes {
+		if g.nodes[i].Name == name {
+			return &g.nodes[i]
+		}
+	}
+	return nil
+}
+
+// buildSpecGraph draws the config using the method sets GetMethods resolves, so interfaces and
+// implementers list the methods they declare or get stubs for, not those they embed
+func buildSpecGraph(config Config) *specGraph {
+	interfaceSets, structSets := generator.GetMethods(config.Implementers, config.Interfaces)
+	g := &specGraph{}
+
+	custom := map[string]bool{}
+	for _, t := range config.CustomTypes {
+		custom[t.Name] = true
+		g.nodes = append(g.nodes, graphNode{Name: t.Name, Kind: nodeType, Members: []string{t.Definition}})
+	}
+	for _, s := range config.CustomStructs {
+		custom[s.Name] = true
+		n := graphNode{Name: s.Name, Kind: nodeStruct}
+		for _, f := range s.Fields {
+			n.Members = append(n.Members, f.Name+" "+f.Type)
+		}
+		g.nodes = append(g.nodes, n)
+	}
+	for _, s := range config.CustomStructs {
+		fieldTypes := map[string]string{}
+		for _, f := range s.Fields {
+			fieldTypes[f.Name] = f.Type
+		}
+		g.edges = append(g.edges, fieldUsesEdges(s.Name, fieldTypes, custom)...)
+	}
+
+	for _, i := range config.Interfaces {
+		methods := sortedMethods(interfaceSets.UniqueSets[i.Name])
+		g.nodes = append(g.nodes, graphNode{Name: i.Name, Kind: nodeInterface, Members: methodSignatures(methods)})
+		for _, e := range i.Embedded {
+			g.edges = append(g.edges, graphEdge{From: i.Name, To: e, Kind: edgeEmbeds})
+		}
+		g.edges = append(g.edges, usesEdges(i.Name, methods, custom)...)
+	}
+	for _, s := range config.Implementers {
+		methods := sortedMethods(structSets.UniqueSets[s.Name])
+		g.nodes = append(g.nodes, graphNode{Name: s.Name, Kind: nodeImplementer, Members: methodSignatures(methods)})
+		for _, e := range s.Embedded {
+			g.edges = append(g.edges, graphEdge{From: s.Name, To: e, Kind: edgeEmbeds})
+		}
+		for _, i := range s.Implements {
+			g.edges = append(g.edges, graphEdge{From: s.Name, To: i, Kind: edgeImplements})
+		}
+		fieldTypes := map[string]string{}
+		for _, f := range s.Fields {
+			fieldTypes[f.Name] = f.Type
+		}
+		g.edges = append(g.edges, usesEdges(s.Name, methods, custom)...)
+		g.edges = append(g.edges, fieldUsesEdges(s.Name, fieldTypes, custom)...)
+	}
+	return g
+}
+
+// fieldUsesEdges links a custom struct or implementer to each custom type its fields have, labelled with the fields
+func fieldUsesEdges(owner string, fieldTypes map[string]string, custom map[string]bool) []graphEdge {
+	names := make([]string, 0, len(fieldTypes))
+	for name := range fieldTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	users := map[string][]string{}
+	for _, field := range names {
+		seen := map[string]bool{}
+		for _, name := range typeNames(fieldTypes[field]) {
+			if custom[name] && name != owner && !seen[name] {
+				seen[name] = true
+				users[name] = append(users[name], field)
+			}
+		}
+	}
+	return sortedUsesEdges(owner, users)
+}
+
+// usesEdges links owner to each custom type its methods take or return, labelled with the methods
+func usesEdges(owner string, methods []generator.Method, custom map[string]bool) []graphEdge {
+	users := map[string][]string{}
+	for _, m := range methods {
+		seen := map[string]bool{}
+		for _, p := range append(append([]generator.Param(nil), m.Inputs...), m.Outputs...) {
+			for _, name := range typeNames(p.Type) {
+				if custom[name] && !seen[name] {
+					seen[name] = true
+					users[name] = append(users[name], m.Name)
+				}
+			}
+		}
+	}
+	return sortedUsesEdges(owner, users)
+}
+
+// sortedUsesEdges turns a map of used type to the members using it into edges ordered by type
+func sortedUsesEdges(owner string, users map[string][]string) []graphEdge {
+	var edges []graphEdge
+	for name, members := range users {
+		edges = append(edges, graphEdge{From: owner, To: name, Kind: edgeUses, Label: strings.Join(members, ", ")})
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].To < edges[j].To })
+	return edges
+}
+
+// typeNames returns the identifiers in a type expression, e.g. map[string][]VehicleStatus gives string and VehicleStatus
+func typeNames(expr string) []string {
+	return strings.FieldsFunc(expr, func(r rune) bool {
+		return !(r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+}
+
+func sortedMethods(methods []generator.Method) []generator.Method {
+	sorted := append([]generator.Method(nil), methods...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// methodSignatures renders methods as Name(types) results
+func methodSignatures(methods []generator.Method) []string {
+	out := make([]string, len(methods))
+	for i, m := range methods {
+		in := make([]string, len(m.Inputs))
+		for j, p := range m.Inputs {
+			in[j] = p.Type
+		}
+		results := make([]string, len(m.Outputs))
+		for j, p := range m.Outputs {
+			results[j] = p.Type
+		}
+		sig := m.Name + "(" + strings.Join(in, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			sig += " " + results[0]
+		default:
+			sig += " (" + strings.Join(results, ", ") + ")"
+		}
+		out[i] = sig
+	}
+	return out
+}
+
+// neighbourhood returns the part of the graph within depth edges of name, following edges either way
+func (g *specGraph) neighbourhood(name string, depth int) *specGraph {
+	keep := map[string]bool{name: true}
+	frontier := []string{name}
+	for d := 0; d < depth && len(frontier) > 0; d++ {
+		var next []string
+		for _, n := range frontier {
+			for _, e := range g.edges {
+				for _, pair := range [][2]string{{e.From, e.To}, {e.To, e.From}} {
+					if pair[0] == n && !keep[pair[1]] {
+						keep[pair[1]] = true
+						next = append(next, pair[1])
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+
+	out := &specGraph{}
+	for _, n := range g.nodes {
+		if keep[n.Name] {
+			out.nodes = append(out.nodes, n)
+		}
+	}
+	for _, e := range g.edges {
+		if keep[e.From] && keep[e.To] {
+			out.edges = append(out.edges, e)
+		}
+	}
+	return out
+}
+
+// writeDOT writes the graph for Graphviz, with arrows pointing from the dependent type to its dependency
+func (g *specGraph) writeDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph spec {\n")
+	b.WriteString("  rankdir=BT;\n")
+	b.WriteString("  node [shape=record, fontname=\"Helvetica\"];\n")
+	for _, n := range g.nodes {
+		label := "«" + n.Kind + "»\\n" + dotEscape(n.Name)
+		if len(n.Members) > 0 {
+			members := make([]string, len(n.Members))
+			for i, m := range n.Members {
+				members[i] = dotEscape(m) + "\\l"
+			}
+			label += "|" + strings.Join(members, "")
+		}
+		fmt.Fprintf(&b, "  %q [label=\"{%s}\"];\n", n.Name, label)
+	}
+	for _, e := range g.edges {
+		var attrs string
+		switch e.Kind {
+		case edgeEmbeds:
+			attrs = `label="embeds", arrowhead=empty`
+		case edgeImplements:
+			attrs = `label="implements", style=dashed, arrowhead=empty`
+		case edgeUses:
+			attrs = fmt.Sprintf(`label=%q, style=dotted, arrowhead=open`, e.Label)
+		}
+		fmt.Fprintf(&b, "  %q -> %q [%s];\n", e.From, e.To, attrs)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotEscape escapes the characters that structure record labels
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`).Replace(s)
+}
+
+// writeMermaid writes the graph as a Mermaid class diagram
+func (g *specGraph) writeMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("classDiagram\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "    class %s {\n", n.Name)
+		fmt.Fprintf(&b, "        <<%s>>\n", n.Kind)
+		for _, m := range n.Members {
+			prefix := "+"
+			if n.Kind == nodeType {
+				prefix = ""
+			}
+			fmt.Fprintf(&b, "        %s%s\n", prefix, mermaidMember(m))
+		}
+		b.WriteString("    }\n")
+	}
+	for _, e := range g.edges {
+		switch e.Kind {
+		case edgeEmbeds:
+			fmt.Fprintf(&b, "    %s --|> %s : embeds\n", e.From, e.To)
+		case edgeImplements:
+			fmt.Fprintf(&b, "    %s ..|> %s : implements\n", e.From, e.To)
+		case edgeUses:
+			fmt.Fprintf(&b, "    %s ..> %s : %s\n", e.From, e.To, e.Label)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidMember replaces the braces that would end a Mermaid class body
+func mermaidMember(s string) string {
+	return strings.NewReplacer("{", "#123;", "}", "#125;").Replace(s)
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVarP(&graphConfigPath, "config", "c", "", "Path to YAML config file")
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "mermaid", "Output format: dot or mermaid")
+	graphCmd.Flags().StringVar(&graphFocus, "focus", "", "Only draw the types around this one")
+	graphCmd.Flags().IntVar(&graphDepth, "depth", 1, "With --focus, how many edges away from the focused type to draw")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "Write the diagram to this path instead of stdout")
+	graphCmd.MarkFlagRequired("config")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildSpecGraph(t *testing.T) {
+	config, err := readConfig("../examples/vehicle-example/vehicle_example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := buildSpecGraph(config)
+	if n := g.node("VehicleStatus"); n == nil || n.Kind != nodeStruct {
+		t.Fatalf("expected VehicleStatus struct node, got %+v", n)
+	}
+
+	var b strings.Builder
+	if err := g.writeMermaid(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"classDiagram\n",
+		"    class Vehicle {\n        <<interface>>\n",
+		"        +GetVehicleStatus() VehicleStatus\n",
+		"    Vehicle ..> VehicleStatus : GetVehicleStatus, UpdateStatus\n",
+		"    SelfDriving --|> Vehicle : embeds\n",
+		"    RoboCar ..|> SelfDriving : implements\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestSpecGraphNeighbourhood(t *testing.T) {
+	config, err := readConfig("../examples/vehicle-example/vehicle_example_embedding.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := buildSpecGraph(config).neighbourhood("RoboCar", 1)
+	var names []string
+	for _, n := range g.nodes {
+		names = append(names, n.Name)
+	}
+	if got := strings.Join(names, ","); got != "SelfDriving,Sedan,RoboCar" {
+		t.Fatalf("unexpected nodes %s", got)
+	}
+
+	var b strings.Builder
+	if err := g.writeDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"RoboCar" [label="{«implementer»\nRoboCar|ActivateAutopilot() string\l}"];`,
+		`"RoboCar" -> "Sedan" [label="embeds", arrowhead=empty];`,
+		`"RoboCar" -> "SelfDriving" [label="implements", style=dashed, arrowhead=empty];`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, b.String())
+		}
+	}
+	if strings.Contains(b.String(), `"Car"`) {
+		t.Errorf("expected Car outside the neighbourhood:\n%s", b.String())
+	}
+}
+
+func TestBuildSpecGraphImplementerUses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fleet.yaml")
+	yaml := `package: fleet
+importer: dispatch
+custom_types:
+  - name: DriverID
+    definition: string
+  - name: Route
+    definition: "[]string"
+interfaces:
+  - name: SelfDriving
+    methods:
+      - name: ActivateAutopilot
+        inputs:
+          - name: route
+            type: Route
+        outputs: []
+implementers:
+  - name: RoboCar
+    implements: [SelfDriving]
+    fields:
+      - name: driver
+        type: DriverID
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := readConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := buildSpecGraph(config).writeMermaid(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"    RoboCar ..> Route : ActivateAutopilot\n",
+		"    RoboCar ..> DriverID : driver\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestFieldUsesEdges(t *testing.T) {
+	custom := map[string]bool{"ID": true, "Status": true}
+	edges := fieldUsesEdges("Status", map[string]string{"Owner": "ID", "Previous": "*Status", "Peers": "map[ID][]ID"}, custom)
+	if len(edges) != 1 || edges[0].To != "ID" || edges[0].Label != "Owner, Peers" {
+		t.Fatalf("unexpected edges %+v", edges)
+	}
+}
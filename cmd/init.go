@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"log"
+	"os"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var initPackage string
+var initImporter string
+var initInterface string
+var initOutput string
+var initForce bool
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented starter YAML config",
+	Long: `Writes a starter config with one interface, a custom struct, a custom type and an implementer,
+ready to edit and pass to generate. An existing file is only replaced with --force.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output := initOutput
+		if output == "" {
+			output = initPackage + ".yaml"
+		}
+		data, err := renderStarterConfig(initPackage, initImporter, initInterface)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeNewFile(output, data, initForce); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s. Run 'GoStubGen generate -c %s' to generate code.\n", output, output)
+	},
+}
+
+// starterConfig is the YAML written by init. It must stay valid for any identifiers it is given.
+var starterConfig = template.Must(template.New("starter").Parse(`# GoStubGen config. Run 'GoStubGen generate -c <this file>' to generate code.
+
+# package holds the generated interfaces, types and implementers.
+package: {{ .Package }}
+# importer is the package that depends on {{ .Package }}. Its tests get the generated mocks.
+importer: {{ .Importer }}
+
+# mocks configures how mocks are emitted. Uncomment to change the defaults.
+# mocks:
+#   package: {{ .Package }}mock     # defaults to the importer package
+#   visibility: exported      # or unexported (default)
+#   backend: native           # or gomock, testify
+
+# custom_types are declared as 'type <name> <definition>'. Use them in struct fields; mocks
+# only import custom_structs from {{ .Package }}, so interface methods take those instead.
+custom_types:
+  - name: {{ .Interface }}ID
+    definition: "string"
+    description: "Identifies a {{ .Interface }} resource"
+
+# custom_structs are plain structs. Interfaces can use them as param types.
+custom_structs:
+  - name: {{ .Interface }}Status
+    description: "Status of a {{ .Interface }} resource"
+    fields:
+      - name: ID
+        type: {{ .Interface }}ID
+        description: "The resource the status describes"
+      - name: Ready
+        type: bool
+        description: "Whether the resource is ready to use"
+
+# interfaces are generated with a mock for each. Add 'embedded: [Other]' to include another
+# interface's methods.
+interfaces:
+  - name: {{ .Interface }}
+    methods:
+      - name: Status
+        description: "Returns the status of a resource"
+        inputs:
+          - name: id
+            type: string
+        outputs:
+          - name: status
+            type: {{ .Interface }}Status
+          - name: err
+            type: error
+
+      - name: Close
+        description: "Releases any resources held"
+        inputs: []
+        outputs:
+          - name: err
+            type: error
+
+# implementers are concrete structs with a stub method for every method of the interfaces
+# they implement. Add 'embedded: [OtherImplementer]' to reuse another implementer's methods.
+implementers:
+  - name: Default{{ .Interface }}
+    implements: ["{{ .Interface }}"]
+    description: "The default {{ .Interface }}"
+    fields:
+      - name: name
+        type: string
+        description: "Name of the {{ .Interface }}"
+`))
+
+// renderStarterConfig returns the starter config for the given names, checked against validateConfig
+func renderStarterConfig(pkg, importer, iface string) ([]byte, error) {
+	if !token.IsIdentifier(iface) || !token.IsExported(iface) {
+		return nil, fmt.Errorf("interface %q must be an exported Go identifier", iface)
+	}
+	var b bytes.Buffer
+	err := starterConfig.Execute(&b, struct{ Package, Importer, Interface string }{pkg, importer, iface})
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.Unmarshal(b.Bytes(), &config); err != nil {
+		return nil, fmt.Errorf("starter config is not valid YAML: %v", err)
+	}
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("Invalid config:\n%v", err)
+	}
+	return b.Bytes(), nil
+}
+
+// writeNewFile writes data to path, refusing to replace an existing file unless force is set
+func writeNewFile(path string, data []byte, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().StringVar(&initPackage, "package", "", "Package for the generated code")
+	initCmd.Flags().StringVar(&initImporter, "importer", "", "Package that imports the generated code")
+	initCmd.Flags().StringVar(&initInterface, "interface", "", "Name of the starter interface")
+	initCmd.Flags().StringVarP(&initOutput, "output", "o", "", "Path to write the config to (defaults to <package>.yaml)")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite an existing file")
+	initCmd.MarkFlagRequired("package")
+	initCmd.MarkFlagRequired("importer")
+	initCmd.MarkFlagRequired("interface")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestRenderStarterConfig(t *testing.T) {
+	data, err := renderStarterConfig("billing", "checkout", "PaymentGateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Interfaces) != 1 || len(config.CustomStructs) != 1 || len(config.CustomTypes) != 1 || len(config.Implementers) != 1 {
+		t.Fatalf("expected one of each kind of type, got %+v", config)
+	}
+	if config.Interfaces[0].Name != "PaymentGateway" || config.Implementers[0].Implements[0] != "PaymentGateway" {
+		t.Fatalf("expected the interface to be named and implemented, got %+v", config)
+	}
+	// mocks only qualify custom structs with the package, so methods must not take custom types
+	for _, m := range config.Interfaces[0].Methods {
+		for _, p := range append(m.Inputs, m.Outputs...) {
+			if p.Type == config.CustomTypes[0].Name {
+				t.Fatalf("expected %s not to use the custom type %s", m.Name, p.Type)
+			}
+		}
+	}
+	if !strings.Contains(string(data), "# interfaces are generated with a mock for each") {
+		t.Fatal("expected the config to be commented")
+	}
+}
+
+func TestRenderStarterConfigRejectsInvalidNames(t *testing.T) {
+	for _, tt := range []struct{ pkg, importer, iface string }{
+		{"billing", "checkout", "paymentGateway"},
+		{"billing", "checkout", "Payment-Gateway"},
+		{"bill-ing", "checkout", "PaymentGateway"},
+	} {
+		if _, err := renderStarterConfig(tt.pkg, tt.importer, tt.iface); err == nil {
+			t.Errorf("expected %+v to be rejected", tt)
+		}
+	}
+}
+
+func TestWriteNewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "billing.yaml")
+	if err := writeNewFile(path, []byte("first"), false); err != nil {
+		t.Fatal(err)
+	}
+	err := writeNewFile(path, []byte("second"), false)
+	if err == nil || !strings.Contains(err.Error(), "use --force") {
+		t.Fatalf("expected an existing file to be kept, got %v", err)
+	}
+	if err := writeNewFile(path, []byte("third"), true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "third" {
+		t.Fatalf("expected --force to overwrite the file, got %q", data)
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jackclarke/GoStubGen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var inspectConfigPath string
+var inspectType string
+var inspectJSON bool
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Explain the resolved method sets of each interface and implementer",
+	Long: `Prints the full, unique and embedded method sets GoStubGen resolves for each interface and
+implementer, and where each method comes from. Implementers get a stub for each method in their
+unique set; methods in the embedded set are provided by an embedded struct.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := readConfig(inspectConfigPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := validateConfig(config); err != nil {
+			log.Fatalf("Invalid config:\n%v", err)
+		}
+
+		types := inspectConfig(config)
+		if inspectType != "" {
+			types = filterInspectedTypes(types, inspectType)
+			if len(types) == 0 {
+				log.Fatalf("No interface or implementer named %s in %s", inspectType, inspectConfigPath)
+			}
+		}
+
+		if inspectJSON {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(types); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		printInspectedTypes(cmd.OutOrStdout(), types)
+	},
+}
+
+// inspectedType is the resolved method sets of an interface or implementer
+type inspectedType struct {
+	Name        string            `json:"name"`
+	Kind        string            `json:"kind"`
+	Implements  []string          `json:"implements,omitempty"`
+	Embeds      []string          `json:"embeds,omitempty"`
+	FullSet     []string          `json:"fullSet"`
+	UniqueSet   []string          `json:"uniqueSet"`
+	EmbeddedSet []string          `json:"embeddedSet"`
+	Methods     []inspectedMethod `json:"methods"`
+}
+
+type inspectedMethod struct {
+	Name string `json:"name"`
+	// Set is unique or embedded
+	Set        string   `json:"set"`
+	Via        []string `json:"via,omitempty"`
+	ProvidedBy string   `json:"providedBy,omitempty"`
+	Origin     string   `json:"origin"`
+}
+
+// inspectConfig resolves the method sets of every interface, then every implementer, in config order
+func inspectConfig(config Config) []inspectedType {
+	interfaceSets, structSets := generator.GetMethods(config.Implementers, config.Interfaces)
+
+	var types []inspectedType
+	for _, i := range config.Interfaces {
+		types = append(types, newInspectedType(i.Name, "interface", nil, i.Embedded, interfaceSets))
+	}
+	for _, s := range config.Implementers {
+		types = append(types, newInspectedType(s.Name, "implementer", s.Implements, s.Embedded, structSets))
+	}
+	return types
+}
+
+func newInspectedType(name, kind string, implements, embeds []string, sets generator.MethodSets) inspectedType {
+	t := inspectedType{
+		Name:        name,
+		Kind:        kind,
+		Implements:  implements,
+		Embeds:      embeds,
+		FullSet:     methodNames(sets.FullSets[name]),
+		UniqueSet:   methodNames(sets.UniqueSets[name]),
+		EmbeddedSet: methodNames(sets.EmbeddedSets[name]),
+	}
+	unique := map[string]bool{}
+	for _, m := range t.UniqueSet {
+		unique[m] = true
+	}
+	for _, m := range t.FullSet {
+		origin := sets.Origins[name][m]
+		set := "embedded"
+		if unique[m] {
+			set = "unique"
+		}
+		t.Methods = append(t.Methods, inspectedMethod{Name: m, Set: set, Via: origin.Via, ProvidedBy: origin.ProvidedBy, Origin: origin.String()})
+	}
+	return t
+}
+
+// methodNames returns the sorted names of methods
+func methodNames(methods []generator.Method) []string {
+	names := make([]string, 0, len(methods))
+	for _, m := range methods {
+		names = append(names, m.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// filterInspectedTypes keeps the type with the given name
+func filterInspectedTypes(types []inspectedType, name string) []inspectedType {
+	var out []inspectedType
+	for _, t := range types {
+		if t.Name == name {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func printInspectedTypes(w io.Writer, types []inspectedType) {
+	for i, t := range types {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s %s\n", t.Kind, t.Name)
+		if len(t.Implements) > 0 {
+			fmt.Fprintf(w, "  implements: %s\n", strings.Join(t.Implements, ", "))
+		}
+		if len(t.Embeds) > 0 {
+			fmt.Fprintf(w, "  embeds:     %s\n", strings.Join(t.Embeds, ", "))
+		}
+		fmt.Fprintf(w, "  full:       %s\n", formatMethodList(t.FullSet))
+		fmt.Fprintf(w, "  unique:     %s\n", formatMethodList(t.UniqueSet))
+		fmt.Fprintf(w, "  embedded:   %s\n", formatMethodList(t.EmbeddedSet))
+		if len(t.Methods) == 0 {
+			continue
+		}
+		fmt.Fprintln(w, "  methods:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, m := range t.Methods {
+			origin := strings.TrimPrefix(m.Origin, m.Name+" ")
+			fmt.Fprintf(tw, "    %s\t%s\t%s\n", m.Name, m.Set, origin)
+		}
+		tw.Flush()
+	}
+}
+
+func formatMethodList(names []string) string {
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+	inspectCmd.Flags().StringVarP(&inspectConfigPath, "config", "c", "", "Path to YAML config file")
+	inspectCmd.Flags().StringVar(&inspectType, "type", "", "Only show this interface or implementer")
+	inspectCmd.Flags().BoolVar(&inspectJSON, "json", false, "Print JSON instead of text")
+	inspectCmd.MarkFlagRequired("config")
+}
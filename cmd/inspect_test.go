@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInspectConfig(t *testing.T) {
+	config, err := readConfig("../examples/vehicle-example/vehicle_example_embedding.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := filterInspectedTypes(inspectConfig(config), "RoboCar")
+	if len(types) != 1 {
+		t.Fatalf("expected RoboCar, got %+v", types)
+	}
+	robo := types[0]
+	if strings.Join(robo.UniqueSet, ",") != "ActivateAutopilot" || strings.Join(robo.EmbeddedSet, ",") != "Drive,OpenTrunk,Stop" {
+		t.Fatalf("unexpected sets %+v", robo)
+	}
+
+	var b strings.Builder
+	printInspectedTypes(&b, types)
+	for _, want := range []string{
+		"implementer RoboCar",
+		"embeds:     Sedan",
+		"Drive              embedded  via SelfDriving → Car → FourWheelVehicle, provided by Sedan",
+		"ActivateAutopilot  unique    via SelfDriving",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, b.String())
+		}
+	}
+}
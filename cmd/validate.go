@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+
+	"github.com/jackclarke/GoStubGen/internal/generator"
+)
+
+// validateConfig checks that the config names valid packages and types, declares each type once and only
+// embeds or implements types it declares. Every problem found is reported.
+func validateConfig(config Config) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for _, p := range []struct{ key, value string }{{"package", config.Package}, {"importer", config.Importer}} {
+		if p.value == "" {
+			fail("%s is required", p.key)
+		} else if !token.IsIdentifier(p.value) {
+			fail("%s %q is not a valid package name", p.key, p.value)
+		}
+	}
+
+	declared := map[string]string{}
+	declare := func(kind, name string) {
+		if !token.IsIdentifier(name) {
+			fail("%s name %q is not a valid Go identifier", kind, name)
+			return
+		}
+		if other, ok := declared[name]; ok {
+			fail("%s %s is already declared as a %s", kind, name, other)
+			return
+		}
+		declared[name] = kind
+	}
+	for _, t := range config.CustomTypes {
+		declare("custom type", t.Name)
+		if t.Definition == "" {
+			fail("custom type %s has no definition", t.Name)
+		}
+	}
+	for _, s := range config.CustomStructs {
+		declare("custom struct", s.Name)
+	}
+	for _, i := range config.Interfaces {
+		declare("interface", i.Name)
+	}
+	for _, s := range config.Implementers {
+		declare("implementer", s.Name)
+	}
+
+	for _, i := range config.Interfaces {
+		for _, e := range i.Embedded {
+			if declared[e] != "interface" {
+				fail("interface %s embeds %s, which is not a declared interface", i.Name, e)
+			}
+		}
+		validateMethods(fail, "interface "+i.Name, i.Methods)
+	}
+	for _, s := range config.Implementers {
+		for _, name := range s.Implements {
+			if declared[name] != "interface" {
+				fail("implementer %s implements %s, which is not a declared interface", s.Name, name)
+			}
+		}
+		for _, e := range s.Embedded {
+			if kind := declared[e]; kind != "implementer" && kind != "custom struct" {
+				fail("implementer %s embeds %s, which is not a declared struct", s.Name, e)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateMethods checks that methods have valid, distinct names and every param has a type
+func validateMethods(fail func(format string, args ...any), owner string, methods []generator.Method) {
+	seen := map[string]bool{}
+	for _, m := range methods {
+		if !token.IsIdentifier(m.Name) {
+			fail("%s has a method named %q, which is not a valid Go identifier", owner, m.Name)
+			continue
+		}
+		if seen[m.Name] {
+			fail("%s declares method %s twice", owner, m.Name)
+		}
+		seen[m.Name] = true
+		for _, p := range append(append([]generator.Param(nil), m.Inputs...), m.Outputs...) {
+			if p.Type == "" {
+				fail("%s.%s has a param with no type", owner, m.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackclarke/GoStubGen/internal/generator"
+)
+
+func TestValidateConfig_Examples(t *testing.T) {
+	paths, _ := filepath.Glob("../examples/vehicle-example/*.yaml")
+	if len(paths) == 0 {
+		t.Fatal("expected example configs")
+	}
+	for _, path := range paths {
+		config, err := readConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validateConfig(config); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	vehicle := generator.InterfaceSpec{Name: "Vehicle", Methods: []generator.Method{{Name: "Drive"}}}
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr []string
+	}{
+		{
+			name:    "missing packages",
+			config:  Config{Interfaces: []generator.InterfaceSpec{vehicle}},
+			wantErr: []string{"package is required", "importer is required"},
+		},
+		{
+			name: "duplicate names",
+			config: Config{Package: "vehicle", Importer: "driver",
+				Interfaces:    []generator.InterfaceSpec{vehicle},
+				CustomStructs: []generator.StructSpec{{Name: "Vehicle"}}},
+			wantErr: []string{"interface Vehicle is already declared as a custom struct"},
+		},
+		{
+			name: "unknown references",
+			config: Config{Package: "vehicle", Importer: "driver",
+				Interfaces:   []generator.InterfaceSpec{{Name: "SelfDriving", Embedded: []string{"Vehicle"}}},
+				Implementers: []generator.StructSpec{{Name: "Car", Implements: []string{"Vehicle"}, Embedded: []string{"Engine"}}}},
+			wantErr: []string{
+				"interface SelfDriving embeds Vehicle, which is not a declared interface",
+				"implementer Car implements Vehicle, which is not a declared interface",
+				"implementer Car embeds Engine, which is not a declared struct",
+			},
+		},
+		{
+			name: "bad methods",
+			config: Config{Package: "vehicle", Importer: "driver",
+				Interfaces: []generator.InterfaceSpec{{Name: "Vehicle", Methods: []generator.Method{
+					{Name: "Drive"}, {Name: "Drive"}, {Name: "Turn", Inputs: []generator.Param{{Name: "dir"}}},
+				}}}},
+			wantErr: []string{"interface Vehicle declares method Drive twice", "interface Vehicle.Turn has a param with no type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(tt.config)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected %q in:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,11 @@ package driver
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,6 +116,67 @@ func TestDriverDriveWithMultipleResponsesAndDelay(t *testing.T) {
 	}
 }
 
+func TestDriverDriveWithDelayAndFakeClock(t *testing.T) {
+	clock := stubs.NewFakeClock(time.Now())
+	mockVeh := newVehicleMockWithClock(vehicle.NewRoboCar(), clock)
+	d := NewDriver(WithVehicle(mockVeh))
+
+	mockVeh.enableLoadCargoMock()
+	mockVeh.enqueueLoadCargoResponseWithDelay(16, nil, 5*time.Second)
+	mockVeh.enqueueLoadCargoResponseWithDelay(18, nil, 5*time.Second)
+
+	done := make(chan int, 1)
+	go func() {
+		resp, err := d.drive()
+		if err != nil {
+			t.Errorf("Did not expect an error. Got %s", err)
+		}
+		done <- resp
+	}()
+
+	// release each delayed response as soon as the driver is waiting on it
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(5 * time.Second)
+	}
+
+	if resp := stubs.WaitForResult(t, done, time.Second); resp != 18 {
+		t.Fatalf("Expected 18. Got %v", resp)
+	}
+}
+
+// Run with -race: the mock is reconfigured while a background goroutine is calling it
+func TestDriverDrive_ConfigureWhileCalling(t *testing.T) {
+	mockVeh := newVehicleMock(vehicle.NewCar())
+	d := NewDriver(WithVehicle(mockVeh))
+	resultCh := mockVeh.captureLoadCargoResult(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			_, _ = d.drive()
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		mockVeh.enableLoadCargoSpy()
+		mockVeh.enableLoadCargoMock()
+		mockVeh.setLoadCargoResponse(i, nil)
+		mockVeh.disableLoadCargoMock()
+		_ = mockVeh.getLoadCargoCalls()
+	}
+
+	// receive captured results while the background calls are still being made, so the capture is raced too
+	for {
+		select {
+		case <-resultCh:
+		case <-done:
+			return
+		}
+	}
+}
+
 func TestDriverDrive_spyLoadCargo(t *testing.T) {
 	realCar := vehicle.NewCar()
 	mock := newVehicleMock(realCar)
@@ -141,6 +207,177 @@ func TestDriverDrive_spyLoadCargo(t *testing.T) {
 	}
 }
 
+func TestDriverDrive_spyLoadCargoTyped(t *testing.T) {
+	mock := newVehicleMock(vehicle.NewCar())
+	mock.enableLoadCargoSpy()
+	mock.enableLoadCargoMock()
+	mock.setLoadCargoResponse(3, nil)
+
+	driver := NewDriver(WithVehicle(mock))
+	if _, err := driver.drive(); err != nil {
+		t.Fatalf("unexpected error from driver.drive(): %v", err)
+	}
+
+	calls := mock.getLoadCargoTypedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls to LoadCargo, got %d", len(calls))
+	}
+	if !reflect.DeepEqual(calls[1].Items, []string{"clothes", "toiletries", "electronics", "more stuff"}) {
+		t.Errorf("unexpected items on second call: %v", calls[1].Items)
+	}
+	for i, call := range calls {
+		if call.Loaded != 3 || call.Err != nil {
+			t.Errorf("call %d: expected results (3, nil), got (%d, %v)", i+1, call.Loaded, call.Err)
+		}
+	}
+}
+
+func TestSpyLoadCargo_ArgsNotAliased(t *testing.T) {
+	mock := newVehicleMock(vehicle.NewCar())
+	mock.enableLoadCargoSpy()
+
+	// code under test often reuses a buffer between calls
+	items := []string{"clothes", "toiletries"}
+	mock.LoadCargo(items)
+	items[0] = "electronics"
+	mock.LoadCargo(items)
+
+	calls := mock.getLoadCargoTypedCalls()
+	if calls[0].Items[0] != "clothes" || calls[1].Items[0] != "electronics" {
+		t.Fatalf("expected each call to keep the items it was given, got %v and %v", calls[0].Items, calls[1].Items)
+	}
+}
+
+func TestDriverDrive_WaitForLoadCargoArgs(t *testing.T) {
+	mock := newVehicleMock(vehicle.NewCar())
+	mock.enableLoadCargoSpy()
+
+	go NewDriver(WithVehicle(mock)).drive()
+
+	call := mock.waitForLoadCargoCallWithArgs(t, time.Second, []string{"clothes", "toiletries", "electronics", "more stuff"})
+	stubs.AssertArgs(t, call, []string{"clothes", "toiletries", "electronics", "more stuff"})
+}
+
+func TestDriverDrive_Scenario(t *testing.T) {
+	mock := newVehicleMock(vehicle.NewCar())
+	if err := mock.loadVehicleScenario("testdata/second_load_fails.yaml"); err != nil {
+		t.Fatalf("failed to load scenario: %v", err)
+	}
+
+	_, err := NewDriver(WithVehicle(mock)).drive()
+	if err == nil || !strings.Contains(err.Error(), "cargo hold full") {
+		t.Fatalf("expected the second load to fail, got %v", err)
+	}
+	if status := mock.GetVehicleStatus(); status.Speed != 30 || !status.EngineOn {
+		t.Fatalf("unexpected status from scenario: %+v", status)
+	}
+}
+
+func TestDriverDrive_Golden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drive.golden.yaml")
+	var recorded int
+
+	t.Run("record", func(t *testing.T) {
+		mock := newVehicleMock(vehicle.NewCar())
+		mock.useVehicleGolden(stubs.NewGolden(t, path, stubs.GoldenRecord))
+		var err error
+		if recorded, err = NewDriver(WithVehicle(mock)).drive(); err != nil {
+			t.Fatalf("Did not expect an error. Got %s", err)
+		}
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "method: Vehicle.LoadCargo") {
+		t.Fatalf("expected LoadCargo calls in the golden file, got %v:\n%s", err, data)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		mock := newVehicleMock(vehicle.NewCar())
+		mock.useVehicleGolden(stubs.NewGolden(t, path, stubs.GoldenReplay))
+		got, err := NewDriver(WithVehicle(mock)).drive()
+		if err != nil || got != recorded {
+			t.Fatalf("expected the recorded result %d, got (%d, %v)", recorded, got, err)
+		}
+	})
+}
+
+func TestDriverDrive_CaptureEveryResult(t *testing.T) {
+	mockVeh := newVehicleMock(vehicle.NewCar())
+	d := NewDriver(WithVehicle(mockVeh))
+
+	mockVeh.enableLoadCargoMock()
+	mockVeh.enqueueLoadCargoResponse(3, nil)
+	mockVeh.enqueueLoadCargoResponse(4, nil)
+
+	// both captures see both calls, and neither blocks the driver
+	results := mockVeh.captureLoadCargoResult(t)
+	events := mockVeh.subscribeLoadCargo(stubs.SubscribeOptions{Buffer: 2})
+	defer events.Unsubscribe()
+
+	if _, err := d.drive(); err != nil {
+		t.Fatalf("Did not expect an error. Got %s", err)
+	}
+
+	for _, want := range []int{3, 4} {
+		if got := stubs.WaitForResult(t, results, time.Second); got.Loaded != want || got.Err != nil {
+			t.Fatalf("expected result %d, got %+v", want, got)
+		}
+	}
+	first := stubs.WaitForResult(t, events.C, time.Second)
+	if len(first.Args.Items) != 3 || first.Result.Loaded != 3 {
+		t.Fatalf("unexpected first event %+v", first)
+	}
+	second := stubs.WaitForResult(t, events.C, time.Second)
+	if len(second.Args.Items) != 4 || second.Result.Loaded != 4 {
+		t.Fatalf("unexpected second event %+v", second)
+	}
+}
+
+func TestCaptureVoidMethod(t *testing.T) {
+	mockVeh := newVehicleMock(vehicle.NewCar())
+	honks := mockVeh.subscribeHonk(stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded})
+	defer honks.Unsubscribe()
+
+	go mockVeh.Honk(3)
+
+	if got := stubs.WaitForResult(t, honks.C, time.Second); got.Args.Times != 3 {
+		t.Fatalf("expected Honk(3), got %+v", got)
+	}
+}
+
+func TestCaptureResultStopsWhenTestFinishes(t *testing.T) {
+	mockVeh := newVehicleMock(vehicle.NewCar())
+	d := NewDriver(WithVehicle(mockVeh))
+	before := runtime.NumGoroutine()
+
+	var results <-chan mockVehicleLoadCargoResult
+	t.Run("capture", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			results = mockVeh.captureLoadCargoResult(t)
+		}
+		if _, err := d.drive(); err != nil {
+			t.Fatalf("Did not expect an error. Got %s", err)
+		}
+		if n := runtime.NumGoroutine(); n < before+10 {
+			t.Fatalf("expected a forwarding goroutine per capture, got %d goroutines from %d", n, before)
+		}
+	})
+
+	for range results {
+		// drained until the capture is closed
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("expected captures to stop with the subtest, %d goroutines left from %d", n, before)
+	}
+	if n := mockVeh.events.LoadCargo.Subscribers(); n != 0 {
+		t.Fatalf("expected no subscriptions left, got %d", n)
+	}
+}
+
 // Future Car embeds car so should inherit methods and therefore work with newVehiclemock
 func TestSelfDriverMethod(t *testing.T) {
 
@@ -168,6 +405,200 @@ func TestSelfDriverMethod(t *testing.T) {
 	}
 }
 
+func TestDriverDrive_Hooks(t *testing.T) {
+	mock := newVehicleMock(vehicle.NewCar())
+	mock.enableLoadCargoMock()
+	mock.enqueueLoadCargoResponse(3, nil)
+	mock.enqueueLoadCargoResponse(4, nil)
+
+	// fill the caller's slice the way a real implementation might, even though the response is queued
+	mock.onLoadCargoBefore(func(args mockVehicleLoadCargoArgs) {
+		args.Items[0] = "packed " + args.Items[0]
+	})
+	var loaded []int
+	mock.onLoadCargoAfter(func(args mockVehicleLoadCargoArgs, result mockVehicleLoadCargoResult) {
+		if !strings.HasPrefix(args.Items[0], "packed ") {
+			t.Errorf("expected the before hook to run first, got %v", args.Items)
+		}
+		loaded = append(loaded, result.Loaded)
+	})
+
+	if _, err := NewDriver(WithVehicle(mock)).drive(); err != nil {
+		t.Fatalf("Did not expect an error. Got %s", err)
+	}
+	if !reflect.DeepEqual(loaded, []int{3, 4}) {
+		t.Fatalf("expected the after hook to see both results, got %v", loaded)
+	}
+}
+
+func TestInstructSelfDriver_NotifyDriveSelfCalled(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	mock.enableDriveSelfMock()
+	release := make(chan struct{})
+	mock.setDriveSelfFunc(func(string) error {
+		<-release
+		return nil
+	})
+	mock.enableParkSelfMock()
+	mock.setParkSelfResponse(nil)
+
+	started := mock.notifyDriveSelfCalled()
+	done := make(chan error, 1)
+	go func() {
+		_, err := (&Driver{vehicle: mock}).instructSelfDriver("garage", "mall")
+		done <- err
+	}()
+
+	stubs.WaitForResult(t, started, time.Second)
+	select {
+	case <-done:
+		t.Fatal("expected the driver to still be waiting on DriveSelf")
+	default:
+	}
+	close(release)
+	if err := stubs.WaitForResult(t, done, time.Second); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+}
+
+func TestLoadCargo_BlockedConcurrentCalls(t *testing.T) {
+	// fails the test if a call is still held at the gate when it finishes
+	mock := newVehicleMockForTest(t, vehicle.NewCar())
+	mock.enableLoadCargoMock()
+	mock.setLoadCargoFunc(func(items []string) (int, error) { return len(items), nil })
+	gate := mock.blockLoadCargo()
+
+	type loadResult struct {
+		n   int
+		err error
+	}
+	results := make(chan loadResult, 2)
+	for _, items := range [][]string{{"a"}, {"b", "c"}} {
+		go func(items []string) {
+			n, err := mock.LoadCargo(items)
+			results <- loadResult{n, err}
+		}(items)
+	}
+
+	// both calls are now in flight at the same time
+	gate.WaitUntilEnteredWithin(t, 2, time.Second)
+	if stats := mock.getLoadCargoConcurrencyStats(); stats.InFlight != 2 {
+		t.Fatalf("expected 2 calls in flight, got %+v", stats)
+	}
+	gate.ReleaseOne()
+	first := stubs.WaitForResult(t, results, time.Second)
+	if first.err != nil {
+		t.Fatalf("expected the released call to succeed, got %v", first.err)
+	}
+
+	errHold := errors.New("cargo hold jammed")
+	gate.Fail(errHold)
+	second := stubs.WaitForResult(t, results, time.Second)
+	if !errors.Is(second.err, errHold) || second.n != 0 {
+		t.Fatalf("expected the held call to fail with %v, got %+v", errHold, second)
+	}
+}
+
+// finishableTB is a stubs.TB whose cleanups run when finish is called, recording its failures
+type finishableTB struct {
+	failures []string
+	cleanups []func()
+}
+
+func (f *finishableTB) TB() stubs.TB {
+	return (&stubs.FuncTB{
+		Fail:    func(msg string) { f.failures = append(f.failures, msg) },
+		Cleanup: func(fn func()) { f.cleanups = append(f.cleanups, fn) },
+	}).TB()
+}
+
+func (f *finishableTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestLoadCargo_LeaksReportedOnOwningTest(t *testing.T) {
+	var quiet, leaking finishableTB
+	quietTB, leakingTB := quiet.TB(), leaking.TB()
+	newVehicleMockForTest(quietTB, vehicle.NewCar())
+	mock := newVehicleMockForTest(leakingTB, vehicle.NewCar())
+
+	gate := mock.blockLoadCargo()
+	go mock.LoadCargo([]string{"a"})
+	gate.WaitUntilEnteredWithin(t, 1, time.Second)
+
+	quiet.finish()
+	if len(quiet.failures) != 0 {
+		t.Fatalf("expected the call held on another test's mock not to be reported, got %q", quiet.failures)
+	}
+	leaking.finish()
+	if len(leaking.failures) != 1 || !strings.Contains(leaking.failures[0], "held at a gate") {
+		t.Fatalf("expected the held call to be reported on its own test, got %q", leaking.failures)
+	}
+}
+
+func TestGetEngineSpecs_FailedGateReportsOnTest(t *testing.T) {
+	var owner finishableTB
+	mock := newVehicleMockForTest(owner.TB(), vehicle.NewCar())
+	gate := mock.blockGetEngineSpecs()
+	gate.Fail(errors.New("engine unavailable"))
+
+	if hp, kind := mock.GetEngineSpecs(); hp != 0 || kind != "" {
+		t.Fatalf("expected zero values from the failed call, got %d %q", hp, kind)
+	}
+	if len(owner.failures) != 1 || !strings.Contains(owner.failures[0], "engine unavailable") {
+		t.Fatalf("expected the gate failure to be reported on the mock's test, got %q", owner.failures)
+	}
+	owner.finish()
+}
+
+func TestInstructSelfDriver_RecordedCalls(t *testing.T) {
+	rec := stubs.NewRecorder()
+	rec.DumpOnFailure(t, "all")
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	mock.attachRecorder(rec)
+	mock.enableDriveSelfMock()
+	mock.setDriveSelfResponse(nil)
+	mock.enableParkSelfMock()
+	mock.setParkSelfResponse(nil)
+
+	if _, err := (&Driver{vehicle: mock}).instructSelfDriver("garage", "mall"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	calls := rec.Calls()
+	if len(calls) < 2 || calls[0].Name() != "SelfDriving.DriveSelf" || !calls[0].Returned {
+		t.Fatalf("expected DriveSelf to be recorded first, got:\n%s", rec.Timeline())
+	}
+	if diagram := rec.Mermaid(); !strings.Contains(diagram, `->>+SelfDriving: #1 DriveSelf("mall")`) {
+		t.Fatalf("expected the diagram to show the DriveSelf call, got:\n%s", diagram)
+	}
+}
+
+func TestDriverDrive_LoadsCargoOneBatchAtATime(t *testing.T) {
+	mock := newVehicleMock(vehicle.NewCar())
+	if _, err := NewDriver(WithVehicle(mock)).drive(); err != nil {
+		t.Fatalf("Did not expect an error. Got %s", err)
+	}
+	mock.assertLoadCargoNeverConcurrent(t)
+	mock.assertLoadCargoMaxConcurrency(t, 1)
+}
+
+// BenchmarkDriverDrive builds a mock per iteration; IterationTB resets it and checks for leaks as each iteration ends
+func BenchmarkDriverDrive(b *testing.B) {
+	it := stubs.NewIterationTB(b)
+	for i := 0; i < b.N; i++ {
+		mock := newVehicleMockForTest(it, vehicle.NewCar())
+		mock.enableLoadCargoMock()
+		mock.setLoadCargoResponse(3, nil)
+		if _, err := NewDriver(WithVehicle(mock)).drive(); err != nil {
+			b.Fatalf("Did not expect an error. Got %s", err)
+		}
+		it.Done()
+	}
+}
+
 func TestInstructSelfDriver_TriggersDeferredPark(t *testing.T) {
 	mock := newSelfDrivingMock(vehicle.NewRoboCar()) // assume realVehicle is a dummy or another mock
 	driver := &Driver{vehicle: mock}
@@ -235,6 +666,181 @@ func TestInstructSelfDriver_TriggersDeferredParkPanic(t *testing.T) {
 	}
 }
 
+func TestInstructSelfDriver_CallOrder(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	driver := &Driver{vehicle: mock}
+
+	seq := stubs.NewSequence()
+	mock.attachSequence(seq)
+
+	if _, err := driver.instructSelfDriver("garage", "mall"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	// ParkSelf is deferred so must come after DriveSelf
+	stubs.InOrder(t, seq, "DriveSelf", "SelfDriving.ParkSelf")
+}
+
+func TestInstructSelfDriverWithCleanup_CallOrder(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	driver := &Driver{vehicle: mock}
+
+	seq := stubs.NewSequence()
+	mock.attachSequence(seq)
+
+	if _, err := driver.instructSelfDriverWithCleanup("garage", "mall"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	// ParkSelf and LockDoors run concurrently, so only their position relative to DriveSelf is fixed
+	stubs.PartialOrder(t, seq, []string{"DriveSelf"}, []string{"ParkSelf", "LockDoors"})
+}
+
+func TestDriverDrive_InjectedFaults(t *testing.T) {
+	mockVeh := newVehicleMock(vehicle.NewCar())
+	d := NewDriver(WithVehicle(mockVeh))
+
+	// every call fails, so the first LoadCargo error is surfaced
+	injected := errors.New("injected failure")
+	mockVeh.injectLoadCargoFaults(stubs.FaultPolicy{Seed: 1, ErrorRate: 1, Err: injected})
+	if _, err := d.drive(); !errors.Is(err, injected) {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+
+	// clearing the policy restores the real behaviour
+	mockVeh.clearLoadCargoFaults()
+	if _, err := d.drive(); err != nil {
+		t.Fatalf("did not expect an error after clearing faults. Got %s", err)
+	}
+}
+
+func TestDriverDrive_QueuedPanic(t *testing.T) {
+	mockVeh := newVehicleMock(vehicle.NewCar())
+	d := NewDriver(WithVehicle(mockVeh))
+
+	// first call succeeds, second panics
+	mockVeh.enableLoadCargoMock()
+	mockVeh.enqueueLoadCargoResponse(3, nil)
+	mockVeh.enqueueLoadCargoPanic("cargo hold jammed")
+
+	stubs.MustPanic(t, func() {
+		_, _ = d.drive()
+	})
+}
+
+func TestInstructSelfDriverWithCleanup_BackgroundTasks(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	driver := &Driver{vehicle: mock}
+	mock.enableCloseWindowsSpy()
+	mock.enableTurnOffACSpy()
+
+	if _, err := driver.instructSelfDriverWithCleanup("garage", "mall"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	// fire-and-forget tasks may still be running after the method returns
+	mock.waitForCloseWindowsCalls(t, 1, time.Second)
+	mock.eventuallyTurnOffACCalls(t, func(calls []stubs.MethodCall) bool { return len(calls) == 1 }, time.Second)
+}
+
+func TestInstructSelfDriver_DoesNotLockDoors(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	driver := &Driver{vehicle: mock}
+	mock.enableLockDoorsSpy()
+
+	if _, err := driver.instructSelfDriver("garage", "mall"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	mock.consistentlyLockDoorsNotCalled(t, 50*time.Millisecond)
+}
+
+func TestSharedMockScopedSubtests(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+	driver := &Driver{vehicle: mock}
+
+	// configuration shared by every subtest
+	mock.enableDriveSelfSpy()
+
+	t.Run("group", func(t *testing.T) {
+		t.Run("DriveSelf fails", func(t *testing.T) {
+			t.Parallel()
+			mock.scope(t)
+			mock.enableDriveSelfMock()
+			mock.setDriveSelfResponse(errors.New("no route"))
+
+			if _, err := driver.instructSelfDriver("garage", "mall"); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+
+		t.Run("ParkSelf fails", func(t *testing.T) {
+			t.Parallel()
+			mock.scope(t)
+			mock.enableParkSelfMock()
+			mock.setParkSelfResponse(errors.New("no space"))
+
+			_ = mock.ParkSelf()
+		})
+	})
+
+	// both subtests have finished and rolled back their own configuration
+	if _, err := driver.instructSelfDriver("garage", "mall"); err != nil {
+		t.Fatalf("expected DriveSelf mock to be rolled back, got %s", err)
+	}
+	if err := mock.ParkSelf(); err != nil {
+		t.Fatalf("expected ParkSelf mock to be rolled back, got %s", err)
+	}
+	// calls made in the subtest were rolled back with its configuration, but the spy stays enabled
+	if n := len(mock.getDriveSelfCalls()); n != 1 {
+		t.Fatalf("expected 1 DriveSelf call since the subtests, got %d", n)
+	}
+
+	mock.reset()
+	if len(mock.getDriveSelfCalls()) != 0 {
+		t.Fatal("expected reset to clear spy calls")
+	}
+}
+
+func TestScopedSubscriptionsEndWithSubtest(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+
+	var events *stubs.Subscription[mockSelfDrivingParkSelfEvent]
+	t.Run("subscribes", func(t *testing.T) {
+		mock.scope(t)
+		events = mock.subscribeParkSelf(stubs.SubscribeOptions{Buffer: 10})
+		_ = mock.ParkSelf()
+		if got := stubs.WaitForResult(t, events.C, time.Second); got.Result != nil {
+			t.Fatalf("expected ParkSelf to succeed, got %v", got.Result)
+		}
+	})
+
+	_ = mock.ParkSelf()
+	if e, ok := <-events.C; ok {
+		t.Fatalf("expected no events after the subtest ended, got %+v", e)
+	}
+	if n := mock.events.ParkSelf.Subscribers(); n != 0 {
+		t.Fatalf("expected the subscription to be removed, got %d", n)
+	}
+}
+
+func TestScopedHooksEndWithSubtest(t *testing.T) {
+	mock := newSelfDrivingMock(vehicle.NewRoboCar())
+
+	var before, after int
+	t.Run("adds hooks", func(t *testing.T) {
+		mock.scope(t)
+		mock.onParkSelfBefore(func(mockSelfDrivingParkSelfArgs) { before++ })
+		mock.onParkSelfAfter(func(mockSelfDrivingParkSelfArgs, error) { after++ })
+		_ = mock.ParkSelf()
+	})
+
+	_ = mock.ParkSelf()
+	if before != 1 || after != 1 {
+		t.Fatalf("expected hooks to run only during the subtest, ran %d before and %d after", before, after)
+	}
+}
+
 func (m *mockSelfDriving) captureParkSelfCallFunc(parkSelfFunc func() error) <-chan error {
 	ch := make(chan error, 1)
 	m.setParkSelfFunc(func() error {
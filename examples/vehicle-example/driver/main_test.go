@@ -0,0 +1,19 @@
+package driver
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jackclarke/GoStubGen/stubs"
+)
+
+// TestMain writes the mock coverage report once every test has run, when STUBS_COVERAGE is set
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if err := stubs.WriteCoverage(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write mock coverage: %v\n", err)
+		code = 1
+	}
+	os.Exit(code)
+}
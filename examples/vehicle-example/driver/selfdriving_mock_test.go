@@ -1,7 +1,6 @@
 package driver
 
 import (
-	"testing"
 	"time"
 
 	"github.com/jackclarke/GoStubGen/examples/vehicle-example/vehicle"
@@ -34,25 +33,819 @@ type mockSelfDrivingConfig struct {
 
 // mockSelfDriving embeds a concrete SelfDriving and its mocks
 type mockSelfDriving struct {
-	real          vehicle.SelfDriving
-	mocked        mockSelfDrivingConfig
-	responseChans map[string]any
+	real   vehicle.SelfDriving
+	mocked mockSelfDrivingConfig
+	events mockSelfDrivingEvents
+	hooks  mockSelfDrivingHooks
+}
+
+// mockSelfDrivingEvents fans out an event for every call to each method
+type mockSelfDrivingEvents struct {
+	UpdateStatus     stubs.Broadcaster[mockSelfDrivingUpdateStatusEvent]
+	LockDoors        stubs.Broadcaster[mockSelfDrivingLockDoorsEvent]
+	GetEngineSpecs   stubs.Broadcaster[mockSelfDrivingGetEngineSpecsEvent]
+	ApplyBrakes      stubs.Broadcaster[mockSelfDrivingApplyBrakesEvent]
+	GetTopSpeed      stubs.Broadcaster[mockSelfDrivingGetTopSpeedEvent]
+	ParkSelf         stubs.Broadcaster[mockSelfDrivingParkSelfEvent]
+	Honk             stubs.Broadcaster[mockSelfDrivingHonkEvent]
+	LoadCargo        stubs.Broadcaster[mockSelfDrivingLoadCargoEvent]
+	GetVehicleStatus stubs.Broadcaster[mockSelfDrivingGetVehicleStatusEvent]
+	TurnOffAC        stubs.Broadcaster[mockSelfDrivingTurnOffACEvent]
+	TurnOffMusic     stubs.Broadcaster[mockSelfDrivingTurnOffMusicEvent]
+	CloseWindows     stubs.Broadcaster[mockSelfDrivingCloseWindowsEvent]
+	Reverse          stubs.Broadcaster[mockSelfDrivingReverseEvent]
+	IsMoving         stubs.Broadcaster[mockSelfDrivingIsMovingEvent]
+	ChangeGears      stubs.Broadcaster[mockSelfDrivingChangeGearsEvent]
+	Telemetry        stubs.Broadcaster[mockSelfDrivingTelemetryEvent]
+	Accelerate       stubs.Broadcaster[mockSelfDrivingAccelerateEvent]
+	DriveSelf        stubs.Broadcaster[mockSelfDrivingDriveSelfEvent]
+	Turn             stubs.Broadcaster[mockSelfDrivingTurnEvent]
+	GetPassengers    stubs.Broadcaster[mockSelfDrivingGetPassengersEvent]
+}
+
+// mockSelfDrivingHooks holds the before and after hooks of each method
+type mockSelfDrivingHooks struct {
+	UpdateStatus     stubs.Hooks[mockSelfDrivingUpdateStatusArgs, error]
+	LockDoors        stubs.Hooks[mockSelfDrivingLockDoorsArgs, error]
+	GetEngineSpecs   stubs.Hooks[mockSelfDrivingGetEngineSpecsArgs, mockSelfDrivingGetEngineSpecsResult]
+	ApplyBrakes      stubs.Hooks[mockSelfDrivingApplyBrakesArgs, bool]
+	GetTopSpeed      stubs.Hooks[mockSelfDrivingGetTopSpeedArgs, int]
+	ParkSelf         stubs.Hooks[mockSelfDrivingParkSelfArgs, error]
+	Honk             stubs.Hooks[mockSelfDrivingHonkArgs, struct{}]
+	LoadCargo        stubs.Hooks[mockSelfDrivingLoadCargoArgs, mockSelfDrivingLoadCargoResult]
+	GetVehicleStatus stubs.Hooks[mockSelfDrivingGetVehicleStatusArgs, vehicle.VehicleStatus]
+	TurnOffAC        stubs.Hooks[mockSelfDrivingTurnOffACArgs, error]
+	TurnOffMusic     stubs.Hooks[mockSelfDrivingTurnOffMusicArgs, error]
+	CloseWindows     stubs.Hooks[mockSelfDrivingCloseWindowsArgs, error]
+	Reverse          stubs.Hooks[mockSelfDrivingReverseArgs, mockSelfDrivingReverseResult]
+	IsMoving         stubs.Hooks[mockSelfDrivingIsMovingArgs, bool]
+	ChangeGears      stubs.Hooks[mockSelfDrivingChangeGearsArgs, mockSelfDrivingChangeGearsResult]
+	Telemetry        stubs.Hooks[mockSelfDrivingTelemetryArgs, map[string]float64]
+	Accelerate       stubs.Hooks[mockSelfDrivingAccelerateArgs, mockSelfDrivingAccelerateResult]
+	DriveSelf        stubs.Hooks[mockSelfDrivingDriveSelfArgs, error]
+	Turn             stubs.Hooks[mockSelfDrivingTurnArgs, string]
+	GetPassengers    stubs.Hooks[mockSelfDrivingGetPassengersArgs, []string]
+}
+
+// newSelfDrivingMock returns a new mock. Its usage is counted in the stubs coverage report when STUBS_COVERAGE is set.
+func newSelfDrivingMock(v vehicle.SelfDriving) *mockSelfDriving {
+	m := &mockSelfDriving{
+		real: v,
+	}
+	m.mocked.UpdateStatus.TrackCoverage("vehicle.SelfDriving", "UpdateStatus")
+	m.mocked.LockDoors.TrackCoverage("vehicle.SelfDriving", "LockDoors")
+	m.mocked.GetEngineSpecs.TrackCoverage("vehicle.SelfDriving", "GetEngineSpecs")
+	m.mocked.ApplyBrakes.TrackCoverage("vehicle.SelfDriving", "ApplyBrakes")
+	m.mocked.GetTopSpeed.TrackCoverage("vehicle.SelfDriving", "GetTopSpeed")
+	m.mocked.ParkSelf.TrackCoverage("vehicle.SelfDriving", "ParkSelf")
+	m.mocked.Honk.TrackCoverage("vehicle.SelfDriving", "Honk")
+	m.mocked.LoadCargo.TrackCoverage("vehicle.SelfDriving", "LoadCargo")
+	m.mocked.GetVehicleStatus.TrackCoverage("vehicle.SelfDriving", "GetVehicleStatus")
+	m.mocked.TurnOffAC.TrackCoverage("vehicle.SelfDriving", "TurnOffAC")
+	m.mocked.TurnOffMusic.TrackCoverage("vehicle.SelfDriving", "TurnOffMusic")
+	m.mocked.CloseWindows.TrackCoverage("vehicle.SelfDriving", "CloseWindows")
+	m.mocked.Reverse.TrackCoverage("vehicle.SelfDriving", "Reverse")
+	m.mocked.IsMoving.TrackCoverage("vehicle.SelfDriving", "IsMoving")
+	m.mocked.ChangeGears.TrackCoverage("vehicle.SelfDriving", "ChangeGears")
+	m.mocked.Telemetry.TrackCoverage("vehicle.SelfDriving", "Telemetry")
+	m.mocked.Accelerate.TrackCoverage("vehicle.SelfDriving", "Accelerate")
+	m.mocked.DriveSelf.TrackCoverage("vehicle.SelfDriving", "DriveSelf")
+	m.mocked.Turn.TrackCoverage("vehicle.SelfDriving", "Turn")
+	m.mocked.GetPassengers.TrackCoverage("vehicle.SelfDriving", "GetPassengers")
+	return m
+}
+
+// newSelfDrivingMockWithClock returns a new mock whose delays, call timestamps and wait helpers use clock
+func newSelfDrivingMockWithClock(v vehicle.SelfDriving, clock stubs.Clock) *mockSelfDriving {
+	m := newSelfDrivingMock(v)
+	m.mocked.UpdateStatus.SetClock(clock)
+	m.mocked.LockDoors.SetClock(clock)
+	m.mocked.GetEngineSpecs.SetClock(clock)
+	m.mocked.ApplyBrakes.SetClock(clock)
+	m.mocked.GetTopSpeed.SetClock(clock)
+	m.mocked.ParkSelf.SetClock(clock)
+	m.mocked.Honk.SetClock(clock)
+	m.mocked.LoadCargo.SetClock(clock)
+	m.mocked.GetVehicleStatus.SetClock(clock)
+	m.mocked.TurnOffAC.SetClock(clock)
+	m.mocked.TurnOffMusic.SetClock(clock)
+	m.mocked.CloseWindows.SetClock(clock)
+	m.mocked.Reverse.SetClock(clock)
+	m.mocked.IsMoving.SetClock(clock)
+	m.mocked.ChangeGears.SetClock(clock)
+	m.mocked.Telemetry.SetClock(clock)
+	m.mocked.Accelerate.SetClock(clock)
+	m.mocked.DriveSelf.SetClock(clock)
+	m.mocked.Turn.SetClock(clock)
+	m.mocked.GetPassengers.SetClock(clock)
+	return m
+}
+
+// newSelfDrivingMockForTest returns a new mock that is reset when t finishes, after checking with
+// stubs.VerifyNoLeaks that no call to it is left held at a gate or blocked on a subscription.
+// Calls without an error output report gate failures on t, and the mock stops counting towards the
+// coverage report when t finishes. When STUBS_RECORD is set, calls are recorded with every other mock
+// built for t and printed if t fails.
+func newSelfDrivingMockForTest(t stubs.TB, v vehicle.SelfDriving) *mockSelfDriving {
+	t.Helper()
+	m := newSelfDrivingMock(v)
+	t.Cleanup(m.reset)
+	if stubs.RecordingEnabled() {
+		m.attachRecorder(stubs.RecorderFor(t))
+	}
+	stubs.VerifyNoLeaks(t)
+	m.mocked.UpdateStatus.TrackLeaks(t)
+	m.mocked.UpdateStatus.ReportTo(t)
+	m.events.UpdateStatus.TrackLeaks(t)
+	t.Cleanup(m.mocked.UpdateStatus.FinishCoverage)
+	m.mocked.LockDoors.TrackLeaks(t)
+	m.mocked.LockDoors.ReportTo(t)
+	m.events.LockDoors.TrackLeaks(t)
+	t.Cleanup(m.mocked.LockDoors.FinishCoverage)
+	m.mocked.GetEngineSpecs.TrackLeaks(t)
+	m.mocked.GetEngineSpecs.ReportTo(t)
+	m.events.GetEngineSpecs.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetEngineSpecs.FinishCoverage)
+	m.mocked.ApplyBrakes.TrackLeaks(t)
+	m.mocked.ApplyBrakes.ReportTo(t)
+	m.events.ApplyBrakes.TrackLeaks(t)
+	t.Cleanup(m.mocked.ApplyBrakes.FinishCoverage)
+	m.mocked.GetTopSpeed.TrackLeaks(t)
+	m.mocked.GetTopSpeed.ReportTo(t)
+	m.events.GetTopSpeed.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetTopSpeed.FinishCoverage)
+	m.mocked.ParkSelf.TrackLeaks(t)
+	m.mocked.ParkSelf.ReportTo(t)
+	m.events.ParkSelf.TrackLeaks(t)
+	t.Cleanup(m.mocked.ParkSelf.FinishCoverage)
+	m.mocked.Honk.TrackLeaks(t)
+	m.mocked.Honk.ReportTo(t)
+	m.events.Honk.TrackLeaks(t)
+	t.Cleanup(m.mocked.Honk.FinishCoverage)
+	m.mocked.LoadCargo.TrackLeaks(t)
+	m.mocked.LoadCargo.ReportTo(t)
+	m.events.LoadCargo.TrackLeaks(t)
+	t.Cleanup(m.mocked.LoadCargo.FinishCoverage)
+	m.mocked.GetVehicleStatus.TrackLeaks(t)
+	m.mocked.GetVehicleStatus.ReportTo(t)
+	m.events.GetVehicleStatus.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetVehicleStatus.FinishCoverage)
+	m.mocked.TurnOffAC.TrackLeaks(t)
+	m.mocked.TurnOffAC.ReportTo(t)
+	m.events.TurnOffAC.TrackLeaks(t)
+	t.Cleanup(m.mocked.TurnOffAC.FinishCoverage)
+	m.mocked.TurnOffMusic.TrackLeaks(t)
+	m.mocked.TurnOffMusic.ReportTo(t)
+	m.events.TurnOffMusic.TrackLeaks(t)
+	t.Cleanup(m.mocked.TurnOffMusic.FinishCoverage)
+	m.mocked.CloseWindows.TrackLeaks(t)
+	m.mocked.CloseWindows.ReportTo(t)
+	m.events.CloseWindows.TrackLeaks(t)
+	t.Cleanup(m.mocked.CloseWindows.FinishCoverage)
+	m.mocked.Reverse.TrackLeaks(t)
+	m.mocked.Reverse.ReportTo(t)
+	m.events.Reverse.TrackLeaks(t)
+	t.Cleanup(m.mocked.Reverse.FinishCoverage)
+	m.mocked.IsMoving.TrackLeaks(t)
+	m.mocked.IsMoving.ReportTo(t)
+	m.events.IsMoving.TrackLeaks(t)
+	t.Cleanup(m.mocked.IsMoving.FinishCoverage)
+	m.mocked.ChangeGears.TrackLeaks(t)
+	m.mocked.ChangeGears.ReportTo(t)
+	m.events.ChangeGears.TrackLeaks(t)
+	t.Cleanup(m.mocked.ChangeGears.FinishCoverage)
+	m.mocked.Telemetry.TrackLeaks(t)
+	m.mocked.Telemetry.ReportTo(t)
+	m.events.Telemetry.TrackLeaks(t)
+	t.Cleanup(m.mocked.Telemetry.FinishCoverage)
+	m.mocked.Accelerate.TrackLeaks(t)
+	m.mocked.Accelerate.ReportTo(t)
+	m.events.Accelerate.TrackLeaks(t)
+	t.Cleanup(m.mocked.Accelerate.FinishCoverage)
+	m.mocked.DriveSelf.TrackLeaks(t)
+	m.mocked.DriveSelf.ReportTo(t)
+	m.events.DriveSelf.TrackLeaks(t)
+	t.Cleanup(m.mocked.DriveSelf.FinishCoverage)
+	m.mocked.Turn.TrackLeaks(t)
+	m.mocked.Turn.ReportTo(t)
+	m.events.Turn.TrackLeaks(t)
+	t.Cleanup(m.mocked.Turn.FinishCoverage)
+	m.mocked.GetPassengers.TrackLeaks(t)
+	m.mocked.GetPassengers.ReportTo(t)
+	m.events.GetPassengers.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetPassengers.FinishCoverage)
+	return m
+}
+
+// attachSequence records every call on the mock into seq so call order can be asserted across mocks
+func (m *mockSelfDriving) attachSequence(seq *stubs.Sequence) {
+	m.mocked.UpdateStatus.AttachSequence(seq, "SelfDriving", "UpdateStatus")
+	m.mocked.LockDoors.AttachSequence(seq, "SelfDriving", "LockDoors")
+	m.mocked.GetEngineSpecs.AttachSequence(seq, "SelfDriving", "GetEngineSpecs")
+	m.mocked.ApplyBrakes.AttachSequence(seq, "SelfDriving", "ApplyBrakes")
+	m.mocked.GetTopSpeed.AttachSequence(seq, "SelfDriving", "GetTopSpeed")
+	m.mocked.ParkSelf.AttachSequence(seq, "SelfDriving", "ParkSelf")
+	m.mocked.Honk.AttachSequence(seq, "SelfDriving", "Honk")
+	m.mocked.LoadCargo.AttachSequence(seq, "SelfDriving", "LoadCargo")
+	m.mocked.GetVehicleStatus.AttachSequence(seq, "SelfDriving", "GetVehicleStatus")
+	m.mocked.TurnOffAC.AttachSequence(seq, "SelfDriving", "TurnOffAC")
+	m.mocked.TurnOffMusic.AttachSequence(seq, "SelfDriving", "TurnOffMusic")
+	m.mocked.CloseWindows.AttachSequence(seq, "SelfDriving", "CloseWindows")
+	m.mocked.Reverse.AttachSequence(seq, "SelfDriving", "Reverse")
+	m.mocked.IsMoving.AttachSequence(seq, "SelfDriving", "IsMoving")
+	m.mocked.ChangeGears.AttachSequence(seq, "SelfDriving", "ChangeGears")
+	m.mocked.Telemetry.AttachSequence(seq, "SelfDriving", "Telemetry")
+	m.mocked.Accelerate.AttachSequence(seq, "SelfDriving", "Accelerate")
+	m.mocked.DriveSelf.AttachSequence(seq, "SelfDriving", "DriveSelf")
+	m.mocked.Turn.AttachSequence(seq, "SelfDriving", "Turn")
+	m.mocked.GetPassengers.AttachSequence(seq, "SelfDriving", "GetPassengers")
+}
+
+// attachRecorder records every call on the mock into rec with its arguments, results, timing and goroutine
+func (m *mockSelfDriving) attachRecorder(rec *stubs.Recorder) {
+	m.mocked.UpdateStatus.AttachRecorder(rec, "SelfDriving", "UpdateStatus")
+	m.mocked.LockDoors.AttachRecorder(rec, "SelfDriving", "LockDoors")
+	m.mocked.GetEngineSpecs.AttachRecorder(rec, "SelfDriving", "GetEngineSpecs")
+	m.mocked.ApplyBrakes.AttachRecorder(rec, "SelfDriving", "ApplyBrakes")
+	m.mocked.GetTopSpeed.AttachRecorder(rec, "SelfDriving", "GetTopSpeed")
+	m.mocked.ParkSelf.AttachRecorder(rec, "SelfDriving", "ParkSelf")
+	m.mocked.Honk.AttachRecorder(rec, "SelfDriving", "Honk")
+	m.mocked.LoadCargo.AttachRecorder(rec, "SelfDriving", "LoadCargo")
+	m.mocked.GetVehicleStatus.AttachRecorder(rec, "SelfDriving", "GetVehicleStatus")
+	m.mocked.TurnOffAC.AttachRecorder(rec, "SelfDriving", "TurnOffAC")
+	m.mocked.TurnOffMusic.AttachRecorder(rec, "SelfDriving", "TurnOffMusic")
+	m.mocked.CloseWindows.AttachRecorder(rec, "SelfDriving", "CloseWindows")
+	m.mocked.Reverse.AttachRecorder(rec, "SelfDriving", "Reverse")
+	m.mocked.IsMoving.AttachRecorder(rec, "SelfDriving", "IsMoving")
+	m.mocked.ChangeGears.AttachRecorder(rec, "SelfDriving", "ChangeGears")
+	m.mocked.Telemetry.AttachRecorder(rec, "SelfDriving", "Telemetry")
+	m.mocked.Accelerate.AttachRecorder(rec, "SelfDriving", "Accelerate")
+	m.mocked.DriveSelf.AttachRecorder(rec, "SelfDriving", "DriveSelf")
+	m.mocked.Turn.AttachRecorder(rec, "SelfDriving", "Turn")
+	m.mocked.GetPassengers.AttachRecorder(rec, "SelfDriving", "GetPassengers")
+}
+
+// mockSelfDrivingSnapshot is a point-in-time copy of a mockSelfDriving's configuration, spy calls, subscriptions and hooks
+type mockSelfDrivingSnapshot struct {
+	methods struct {
+		UpdateStatus     stubs.MethodSnapshot[func(vehicle.VehicleStatus) error]
+		LockDoors        stubs.MethodSnapshot[func() error]
+		GetEngineSpecs   stubs.MethodSnapshot[func() (int, string)]
+		ApplyBrakes      stubs.MethodSnapshot[func(float64) bool]
+		GetTopSpeed      stubs.MethodSnapshot[func() int]
+		ParkSelf         stubs.MethodSnapshot[func() error]
+		Honk             stubs.MethodSnapshot[func(int)]
+		LoadCargo        stubs.MethodSnapshot[func([]string) (int, error)]
+		GetVehicleStatus stubs.MethodSnapshot[func() vehicle.VehicleStatus]
+		TurnOffAC        stubs.MethodSnapshot[func() error]
+		TurnOffMusic     stubs.MethodSnapshot[func() error]
+		CloseWindows     stubs.MethodSnapshot[func() error]
+		Reverse          stubs.MethodSnapshot[func() (string, error)]
+		IsMoving         stubs.MethodSnapshot[func() bool]
+		ChangeGears      stubs.MethodSnapshot[func(int) (int, int)]
+		Telemetry        stubs.MethodSnapshot[func() map[string]float64]
+		Accelerate       stubs.MethodSnapshot[func(int, string) (int, error)]
+		DriveSelf        stubs.MethodSnapshot[func(string) error]
+		Turn             stubs.MethodSnapshot[func(string) string]
+		GetPassengers    stubs.MethodSnapshot[func() []string]
+	}
+	events struct {
+		UpdateStatus     uint64
+		LockDoors        uint64
+		GetEngineSpecs   uint64
+		ApplyBrakes      uint64
+		GetTopSpeed      uint64
+		ParkSelf         uint64
+		Honk             uint64
+		LoadCargo        uint64
+		GetVehicleStatus uint64
+		TurnOffAC        uint64
+		TurnOffMusic     uint64
+		CloseWindows     uint64
+		Reverse          uint64
+		IsMoving         uint64
+		ChangeGears      uint64
+		Telemetry        uint64
+		Accelerate       uint64
+		DriveSelf        uint64
+		Turn             uint64
+		GetPassengers    uint64
+	}
+	hooks struct {
+		UpdateStatus     uint64
+		LockDoors        uint64
+		GetEngineSpecs   uint64
+		ApplyBrakes      uint64
+		GetTopSpeed      uint64
+		ParkSelf         uint64
+		Honk             uint64
+		LoadCargo        uint64
+		GetVehicleStatus uint64
+		TurnOffAC        uint64
+		TurnOffMusic     uint64
+		CloseWindows     uint64
+		Reverse          uint64
+		IsMoving         uint64
+		ChangeGears      uint64
+		Telemetry        uint64
+		Accelerate       uint64
+		DriveSelf        uint64
+		Turn             uint64
+		GetPassengers    uint64
+	}
 }
 
-// newSelfDrivingMock returns a new mock
-func newSelfDrivingMock(v vehicle.SelfDriving) *mockSelfDriving {
-	return &mockSelfDriving{
-		real:          v,
-		mocked:        mockSelfDrivingConfig{},
-		responseChans: make(map[string]any),
+// reset clears the configuration, spy calls, subscriptions and hooks of every method
+func (m *mockSelfDriving) reset() {
+	m.mocked.UpdateStatus.Reset()
+	m.events.UpdateStatus.UnsubscribeAll()
+	m.hooks.UpdateStatus.RemoveAll()
+	m.mocked.LockDoors.Reset()
+	m.events.LockDoors.UnsubscribeAll()
+	m.hooks.LockDoors.RemoveAll()
+	m.mocked.GetEngineSpecs.Reset()
+	m.events.GetEngineSpecs.UnsubscribeAll()
+	m.hooks.GetEngineSpecs.RemoveAll()
+	m.mocked.ApplyBrakes.Reset()
+	m.events.ApplyBrakes.UnsubscribeAll()
+	m.hooks.ApplyBrakes.RemoveAll()
+	m.mocked.GetTopSpeed.Reset()
+	m.events.GetTopSpeed.UnsubscribeAll()
+	m.hooks.GetTopSpeed.RemoveAll()
+	m.mocked.ParkSelf.Reset()
+	m.events.ParkSelf.UnsubscribeAll()
+	m.hooks.ParkSelf.RemoveAll()
+	m.mocked.Honk.Reset()
+	m.events.Honk.UnsubscribeAll()
+	m.hooks.Honk.RemoveAll()
+	m.mocked.LoadCargo.Reset()
+	m.events.LoadCargo.UnsubscribeAll()
+	m.hooks.LoadCargo.RemoveAll()
+	m.mocked.GetVehicleStatus.Reset()
+	m.events.GetVehicleStatus.UnsubscribeAll()
+	m.hooks.GetVehicleStatus.RemoveAll()
+	m.mocked.TurnOffAC.Reset()
+	m.events.TurnOffAC.UnsubscribeAll()
+	m.hooks.TurnOffAC.RemoveAll()
+	m.mocked.TurnOffMusic.Reset()
+	m.events.TurnOffMusic.UnsubscribeAll()
+	m.hooks.TurnOffMusic.RemoveAll()
+	m.mocked.CloseWindows.Reset()
+	m.events.CloseWindows.UnsubscribeAll()
+	m.hooks.CloseWindows.RemoveAll()
+	m.mocked.Reverse.Reset()
+	m.events.Reverse.UnsubscribeAll()
+	m.hooks.Reverse.RemoveAll()
+	m.mocked.IsMoving.Reset()
+	m.events.IsMoving.UnsubscribeAll()
+	m.hooks.IsMoving.RemoveAll()
+	m.mocked.ChangeGears.Reset()
+	m.events.ChangeGears.UnsubscribeAll()
+	m.hooks.ChangeGears.RemoveAll()
+	m.mocked.Telemetry.Reset()
+	m.events.Telemetry.UnsubscribeAll()
+	m.hooks.Telemetry.RemoveAll()
+	m.mocked.Accelerate.Reset()
+	m.events.Accelerate.UnsubscribeAll()
+	m.hooks.Accelerate.RemoveAll()
+	m.mocked.DriveSelf.Reset()
+	m.events.DriveSelf.UnsubscribeAll()
+	m.hooks.DriveSelf.RemoveAll()
+	m.mocked.Turn.Reset()
+	m.events.Turn.UnsubscribeAll()
+	m.hooks.Turn.RemoveAll()
+	m.mocked.GetPassengers.Reset()
+	m.events.GetPassengers.UnsubscribeAll()
+	m.hooks.GetPassengers.RemoveAll()
+}
+
+// snapshot captures the configuration, spy calls, subscriptions and hooks of every method
+func (m *mockSelfDriving) snapshot() mockSelfDrivingSnapshot {
+	var snap mockSelfDrivingSnapshot
+	snap.methods.UpdateStatus = m.mocked.UpdateStatus.Snapshot()
+	snap.events.UpdateStatus = m.events.UpdateStatus.Mark()
+	snap.hooks.UpdateStatus = m.hooks.UpdateStatus.Mark()
+	snap.methods.LockDoors = m.mocked.LockDoors.Snapshot()
+	snap.events.LockDoors = m.events.LockDoors.Mark()
+	snap.hooks.LockDoors = m.hooks.LockDoors.Mark()
+	snap.methods.GetEngineSpecs = m.mocked.GetEngineSpecs.Snapshot()
+	snap.events.GetEngineSpecs = m.events.GetEngineSpecs.Mark()
+	snap.hooks.GetEngineSpecs = m.hooks.GetEngineSpecs.Mark()
+	snap.methods.ApplyBrakes = m.mocked.ApplyBrakes.Snapshot()
+	snap.events.ApplyBrakes = m.events.ApplyBrakes.Mark()
+	snap.hooks.ApplyBrakes = m.hooks.ApplyBrakes.Mark()
+	snap.methods.GetTopSpeed = m.mocked.GetTopSpeed.Snapshot()
+	snap.events.GetTopSpeed = m.events.GetTopSpeed.Mark()
+	snap.hooks.GetTopSpeed = m.hooks.GetTopSpeed.Mark()
+	snap.methods.ParkSelf = m.mocked.ParkSelf.Snapshot()
+	snap.events.ParkSelf = m.events.ParkSelf.Mark()
+	snap.hooks.ParkSelf = m.hooks.ParkSelf.Mark()
+	snap.methods.Honk = m.mocked.Honk.Snapshot()
+	snap.events.Honk = m.events.Honk.Mark()
+	snap.hooks.Honk = m.hooks.Honk.Mark()
+	snap.methods.LoadCargo = m.mocked.LoadCargo.Snapshot()
+	snap.events.LoadCargo = m.events.LoadCargo.Mark()
+	snap.hooks.LoadCargo = m.hooks.LoadCargo.Mark()
+	snap.methods.GetVehicleStatus = m.mocked.GetVehicleStatus.Snapshot()
+	snap.events.GetVehicleStatus = m.events.GetVehicleStatus.Mark()
+	snap.hooks.GetVehicleStatus = m.hooks.GetVehicleStatus.Mark()
+	snap.methods.TurnOffAC = m.mocked.TurnOffAC.Snapshot()
+	snap.events.TurnOffAC = m.events.TurnOffAC.Mark()
+	snap.hooks.TurnOffAC = m.hooks.TurnOffAC.Mark()
+	snap.methods.TurnOffMusic = m.mocked.TurnOffMusic.Snapshot()
+	snap.events.TurnOffMusic = m.events.TurnOffMusic.Mark()
+	snap.hooks.TurnOffMusic = m.hooks.TurnOffMusic.Mark()
+	snap.methods.CloseWindows = m.mocked.CloseWindows.Snapshot()
+	snap.events.CloseWindows = m.events.CloseWindows.Mark()
+	snap.hooks.CloseWindows = m.hooks.CloseWindows.Mark()
+	snap.methods.Reverse = m.mocked.Reverse.Snapshot()
+	snap.events.Reverse = m.events.Reverse.Mark()
+	snap.hooks.Reverse = m.hooks.Reverse.Mark()
+	snap.methods.IsMoving = m.mocked.IsMoving.Snapshot()
+	snap.events.IsMoving = m.events.IsMoving.Mark()
+	snap.hooks.IsMoving = m.hooks.IsMoving.Mark()
+	snap.methods.ChangeGears = m.mocked.ChangeGears.Snapshot()
+	snap.events.ChangeGears = m.events.ChangeGears.Mark()
+	snap.hooks.ChangeGears = m.hooks.ChangeGears.Mark()
+	snap.methods.Telemetry = m.mocked.Telemetry.Snapshot()
+	snap.events.Telemetry = m.events.Telemetry.Mark()
+	snap.hooks.Telemetry = m.hooks.Telemetry.Mark()
+	snap.methods.Accelerate = m.mocked.Accelerate.Snapshot()
+	snap.events.Accelerate = m.events.Accelerate.Mark()
+	snap.hooks.Accelerate = m.hooks.Accelerate.Mark()
+	snap.methods.DriveSelf = m.mocked.DriveSelf.Snapshot()
+	snap.events.DriveSelf = m.events.DriveSelf.Mark()
+	snap.hooks.DriveSelf = m.hooks.DriveSelf.Mark()
+	snap.methods.Turn = m.mocked.Turn.Snapshot()
+	snap.events.Turn = m.events.Turn.Mark()
+	snap.hooks.Turn = m.hooks.Turn.Mark()
+	snap.methods.GetPassengers = m.mocked.GetPassengers.Snapshot()
+	snap.events.GetPassengers = m.events.GetPassengers.Mark()
+	snap.hooks.GetPassengers = m.hooks.GetPassengers.Mark()
+	return snap
+}
+
+// restore returns every method to the state captured by snap and removes subscriptions and hooks added since
+func (m *mockSelfDriving) restore(snap mockSelfDrivingSnapshot) {
+	m.mocked.UpdateStatus.Restore(snap.methods.UpdateStatus)
+	m.events.UpdateStatus.UnsubscribeAfter(snap.events.UpdateStatus)
+	m.hooks.UpdateStatus.RemoveAfter(snap.hooks.UpdateStatus)
+	m.mocked.LockDoors.Restore(snap.methods.LockDoors)
+	m.events.LockDoors.UnsubscribeAfter(snap.events.LockDoors)
+	m.hooks.LockDoors.RemoveAfter(snap.hooks.LockDoors)
+	m.mocked.GetEngineSpecs.Restore(snap.methods.GetEngineSpecs)
+	m.events.GetEngineSpecs.UnsubscribeAfter(snap.events.GetEngineSpecs)
+	m.hooks.GetEngineSpecs.RemoveAfter(snap.hooks.GetEngineSpecs)
+	m.mocked.ApplyBrakes.Restore(snap.methods.ApplyBrakes)
+	m.events.ApplyBrakes.UnsubscribeAfter(snap.events.ApplyBrakes)
+	m.hooks.ApplyBrakes.RemoveAfter(snap.hooks.ApplyBrakes)
+	m.mocked.GetTopSpeed.Restore(snap.methods.GetTopSpeed)
+	m.events.GetTopSpeed.UnsubscribeAfter(snap.events.GetTopSpeed)
+	m.hooks.GetTopSpeed.RemoveAfter(snap.hooks.GetTopSpeed)
+	m.mocked.ParkSelf.Restore(snap.methods.ParkSelf)
+	m.events.ParkSelf.UnsubscribeAfter(snap.events.ParkSelf)
+	m.hooks.ParkSelf.RemoveAfter(snap.hooks.ParkSelf)
+	m.mocked.Honk.Restore(snap.methods.Honk)
+	m.events.Honk.UnsubscribeAfter(snap.events.Honk)
+	m.hooks.Honk.RemoveAfter(snap.hooks.Honk)
+	m.mocked.LoadCargo.Restore(snap.methods.LoadCargo)
+	m.events.LoadCargo.UnsubscribeAfter(snap.events.LoadCargo)
+	m.hooks.LoadCargo.RemoveAfter(snap.hooks.LoadCargo)
+	m.mocked.GetVehicleStatus.Restore(snap.methods.GetVehicleStatus)
+	m.events.GetVehicleStatus.UnsubscribeAfter(snap.events.GetVehicleStatus)
+	m.hooks.GetVehicleStatus.RemoveAfter(snap.hooks.GetVehicleStatus)
+	m.mocked.TurnOffAC.Restore(snap.methods.TurnOffAC)
+	m.events.TurnOffAC.UnsubscribeAfter(snap.events.TurnOffAC)
+	m.hooks.TurnOffAC.RemoveAfter(snap.hooks.TurnOffAC)
+	m.mocked.TurnOffMusic.Restore(snap.methods.TurnOffMusic)
+	m.events.TurnOffMusic.UnsubscribeAfter(snap.events.TurnOffMusic)
+	m.hooks.TurnOffMusic.RemoveAfter(snap.hooks.TurnOffMusic)
+	m.mocked.CloseWindows.Restore(snap.methods.CloseWindows)
+	m.events.CloseWindows.UnsubscribeAfter(snap.events.CloseWindows)
+	m.hooks.CloseWindows.RemoveAfter(snap.hooks.CloseWindows)
+	m.mocked.Reverse.Restore(snap.methods.Reverse)
+	m.events.Reverse.UnsubscribeAfter(snap.events.Reverse)
+	m.hooks.Reverse.RemoveAfter(snap.hooks.Reverse)
+	m.mocked.IsMoving.Restore(snap.methods.IsMoving)
+	m.events.IsMoving.UnsubscribeAfter(snap.events.IsMoving)
+	m.hooks.IsMoving.RemoveAfter(snap.hooks.IsMoving)
+	m.mocked.ChangeGears.Restore(snap.methods.ChangeGears)
+	m.events.ChangeGears.UnsubscribeAfter(snap.events.ChangeGears)
+	m.hooks.ChangeGears.RemoveAfter(snap.hooks.ChangeGears)
+	m.mocked.Telemetry.Restore(snap.methods.Telemetry)
+	m.events.Telemetry.UnsubscribeAfter(snap.events.Telemetry)
+	m.hooks.Telemetry.RemoveAfter(snap.hooks.Telemetry)
+	m.mocked.Accelerate.Restore(snap.methods.Accelerate)
+	m.events.Accelerate.UnsubscribeAfter(snap.events.Accelerate)
+	m.hooks.Accelerate.RemoveAfter(snap.hooks.Accelerate)
+	m.mocked.DriveSelf.Restore(snap.methods.DriveSelf)
+	m.events.DriveSelf.UnsubscribeAfter(snap.events.DriveSelf)
+	m.hooks.DriveSelf.RemoveAfter(snap.hooks.DriveSelf)
+	m.mocked.Turn.Restore(snap.methods.Turn)
+	m.events.Turn.UnsubscribeAfter(snap.events.Turn)
+	m.hooks.Turn.RemoveAfter(snap.hooks.Turn)
+	m.mocked.GetPassengers.Restore(snap.methods.GetPassengers)
+	m.events.GetPassengers.UnsubscribeAfter(snap.events.GetPassengers)
+	m.hooks.GetPassengers.RemoveAfter(snap.hooks.GetPassengers)
+}
+
+// scope rolls back configuration made during t when t and its subtests finish.
+// Only methods configured since scope was called are restored, so parallel subtests
+// that configure different methods of a shared mock do not undo each other.
+// Subscriptions and hooks added since scope was called are removed.
+func (m *mockSelfDriving) scope(t stubs.TB) {
+	snap := m.snapshot()
+	t.Cleanup(func() {
+		if m.mocked.UpdateStatus.ModifiedSince(snap.methods.UpdateStatus) {
+			m.mocked.UpdateStatus.Restore(snap.methods.UpdateStatus)
+		}
+		m.events.UpdateStatus.UnsubscribeAfter(snap.events.UpdateStatus)
+		m.hooks.UpdateStatus.RemoveAfter(snap.hooks.UpdateStatus)
+		if m.mocked.LockDoors.ModifiedSince(snap.methods.LockDoors) {
+			m.mocked.LockDoors.Restore(snap.methods.LockDoors)
+		}
+		m.events.LockDoors.UnsubscribeAfter(snap.events.LockDoors)
+		m.hooks.LockDoors.RemoveAfter(snap.hooks.LockDoors)
+		if m.mocked.GetEngineSpecs.ModifiedSince(snap.methods.GetEngineSpecs) {
+			m.mocked.GetEngineSpecs.Restore(snap.methods.GetEngineSpecs)
+		}
+		m.events.GetEngineSpecs.UnsubscribeAfter(snap.events.GetEngineSpecs)
+		m.hooks.GetEngineSpecs.RemoveAfter(snap.hooks.GetEngineSpecs)
+		if m.mocked.ApplyBrakes.ModifiedSince(snap.methods.ApplyBrakes) {
+			m.mocked.ApplyBrakes.Restore(snap.methods.ApplyBrakes)
+		}
+		m.events.ApplyBrakes.UnsubscribeAfter(snap.events.ApplyBrakes)
+		m.hooks.ApplyBrakes.RemoveAfter(snap.hooks.ApplyBrakes)
+		if m.mocked.GetTopSpeed.ModifiedSince(snap.methods.GetTopSpeed) {
+			m.mocked.GetTopSpeed.Restore(snap.methods.GetTopSpeed)
+		}
+		m.events.GetTopSpeed.UnsubscribeAfter(snap.events.GetTopSpeed)
+		m.hooks.GetTopSpeed.RemoveAfter(snap.hooks.GetTopSpeed)
+		if m.mocked.ParkSelf.ModifiedSince(snap.methods.ParkSelf) {
+			m.mocked.ParkSelf.Restore(snap.methods.ParkSelf)
+		}
+		m.events.ParkSelf.UnsubscribeAfter(snap.events.ParkSelf)
+		m.hooks.ParkSelf.RemoveAfter(snap.hooks.ParkSelf)
+		if m.mocked.Honk.ModifiedSince(snap.methods.Honk) {
+			m.mocked.Honk.Restore(snap.methods.Honk)
+		}
+		m.events.Honk.UnsubscribeAfter(snap.events.Honk)
+		m.hooks.Honk.RemoveAfter(snap.hooks.Honk)
+		if m.mocked.LoadCargo.ModifiedSince(snap.methods.LoadCargo) {
+			m.mocked.LoadCargo.Restore(snap.methods.LoadCargo)
+		}
+		m.events.LoadCargo.UnsubscribeAfter(snap.events.LoadCargo)
+		m.hooks.LoadCargo.RemoveAfter(snap.hooks.LoadCargo)
+		if m.mocked.GetVehicleStatus.ModifiedSince(snap.methods.GetVehicleStatus) {
+			m.mocked.GetVehicleStatus.Restore(snap.methods.GetVehicleStatus)
+		}
+		m.events.GetVehicleStatus.UnsubscribeAfter(snap.events.GetVehicleStatus)
+		m.hooks.GetVehicleStatus.RemoveAfter(snap.hooks.GetVehicleStatus)
+		if m.mocked.TurnOffAC.ModifiedSince(snap.methods.TurnOffAC) {
+			m.mocked.TurnOffAC.Restore(snap.methods.TurnOffAC)
+		}
+		m.events.TurnOffAC.UnsubscribeAfter(snap.events.TurnOffAC)
+		m.hooks.TurnOffAC.RemoveAfter(snap.hooks.TurnOffAC)
+		if m.mocked.TurnOffMusic.ModifiedSince(snap.methods.TurnOffMusic) {
+			m.mocked.TurnOffMusic.Restore(snap.methods.TurnOffMusic)
+		}
+		m.events.TurnOffMusic.UnsubscribeAfter(snap.events.TurnOffMusic)
+		m.hooks.TurnOffMusic.RemoveAfter(snap.hooks.TurnOffMusic)
+		if m.mocked.CloseWindows.ModifiedSince(snap.methods.CloseWindows) {
+			m.mocked.CloseWindows.Restore(snap.methods.CloseWindows)
+		}
+		m.events.CloseWindows.UnsubscribeAfter(snap.events.CloseWindows)
+		m.hooks.CloseWindows.RemoveAfter(snap.hooks.CloseWindows)
+		if m.mocked.Reverse.ModifiedSince(snap.methods.Reverse) {
+			m.mocked.Reverse.Restore(snap.methods.Reverse)
+		}
+		m.events.Reverse.UnsubscribeAfter(snap.events.Reverse)
+		m.hooks.Reverse.RemoveAfter(snap.hooks.Reverse)
+		if m.mocked.IsMoving.ModifiedSince(snap.methods.IsMoving) {
+			m.mocked.IsMoving.Restore(snap.methods.IsMoving)
+		}
+		m.events.IsMoving.UnsubscribeAfter(snap.events.IsMoving)
+		m.hooks.IsMoving.RemoveAfter(snap.hooks.IsMoving)
+		if m.mocked.ChangeGears.ModifiedSince(snap.methods.ChangeGears) {
+			m.mocked.ChangeGears.Restore(snap.methods.ChangeGears)
+		}
+		m.events.ChangeGears.UnsubscribeAfter(snap.events.ChangeGears)
+		m.hooks.ChangeGears.RemoveAfter(snap.hooks.ChangeGears)
+		if m.mocked.Telemetry.ModifiedSince(snap.methods.Telemetry) {
+			m.mocked.Telemetry.Restore(snap.methods.Telemetry)
+		}
+		m.events.Telemetry.UnsubscribeAfter(snap.events.Telemetry)
+		m.hooks.Telemetry.RemoveAfter(snap.hooks.Telemetry)
+		if m.mocked.Accelerate.ModifiedSince(snap.methods.Accelerate) {
+			m.mocked.Accelerate.Restore(snap.methods.Accelerate)
+		}
+		m.events.Accelerate.UnsubscribeAfter(snap.events.Accelerate)
+		m.hooks.Accelerate.RemoveAfter(snap.hooks.Accelerate)
+		if m.mocked.DriveSelf.ModifiedSince(snap.methods.DriveSelf) {
+			m.mocked.DriveSelf.Restore(snap.methods.DriveSelf)
+		}
+		m.events.DriveSelf.UnsubscribeAfter(snap.events.DriveSelf)
+		m.hooks.DriveSelf.RemoveAfter(snap.hooks.DriveSelf)
+		if m.mocked.Turn.ModifiedSince(snap.methods.Turn) {
+			m.mocked.Turn.Restore(snap.methods.Turn)
+		}
+		m.events.Turn.UnsubscribeAfter(snap.events.Turn)
+		m.hooks.Turn.RemoveAfter(snap.hooks.Turn)
+		if m.mocked.GetPassengers.ModifiedSince(snap.methods.GetPassengers) {
+			m.mocked.GetPassengers.Restore(snap.methods.GetPassengers)
+		}
+		m.events.GetPassengers.UnsubscribeAfter(snap.events.GetPassengers)
+		m.hooks.GetPassengers.RemoveAfter(snap.hooks.GetPassengers)
+	})
+}
+
+// loadSelfDrivingScenario configures the mock from a YAML or JSON scenario file, see stubs.Scenario
+func (m *mockSelfDriving) loadSelfDrivingScenario(path string) error {
+	sc, err := stubs.LoadScenario(path)
+	if err != nil {
+		return err
 	}
+	return sc.Apply(map[string]func(stubs.ScenarioMethod) error{
+		"UpdateStatus": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.UpdateStatus.ApplyScenario("UpdateStatus", sm,
+				[]string{"status"},
+				[]string{"err"})
+		},
+		"LockDoors": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.LockDoors.ApplyScenario("LockDoors", sm,
+				[]string{},
+				[]string{"err"})
+		},
+		"GetEngineSpecs": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetEngineSpecs.ApplyScenario("GetEngineSpecs", sm,
+				[]string{},
+				[]string{"power", "fuelType"})
+		},
+		"ApplyBrakes": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.ApplyBrakes.ApplyScenario("ApplyBrakes", sm,
+				[]string{"force"},
+				[]string{"applied"})
+		},
+		"GetTopSpeed": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetTopSpeed.ApplyScenario("GetTopSpeed", sm,
+				[]string{},
+				[]string{""})
+		},
+		"ParkSelf": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.ParkSelf.ApplyScenario("ParkSelf", sm,
+				[]string{},
+				[]string{"err"})
+		},
+		"Honk": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Honk.ApplyScenario("Honk", sm,
+				[]string{"times"},
+				[]string{})
+		},
+		"LoadCargo": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.LoadCargo.ApplyScenario("LoadCargo", sm,
+				[]string{"items"},
+				[]string{"loaded", "err"})
+		},
+		"GetVehicleStatus": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetVehicleStatus.ApplyScenario("GetVehicleStatus", sm,
+				[]string{},
+				[]string{"vehicleStatus"})
+		},
+		"TurnOffAC": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.TurnOffAC.ApplyScenario("TurnOffAC", sm,
+				[]string{},
+				[]string{"err"})
+		},
+		"TurnOffMusic": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.TurnOffMusic.ApplyScenario("TurnOffMusic", sm,
+				[]string{},
+				[]string{"err"})
+		},
+		"CloseWindows": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.CloseWindows.ApplyScenario("CloseWindows", sm,
+				[]string{},
+				[]string{"err"})
+		},
+		"Reverse": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Reverse.ApplyScenario("Reverse", sm,
+				[]string{},
+				[]string{"location", "err"})
+		},
+		"IsMoving": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.IsMoving.ApplyScenario("IsMoving", sm,
+				[]string{},
+				[]string{"isMoving"})
+		},
+		"ChangeGears": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.ChangeGears.ApplyScenario("ChangeGears", sm,
+				[]string{"gear"},
+				[]string{"before", "after"})
+		},
+		"Telemetry": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Telemetry.ApplyScenario("Telemetry", sm,
+				[]string{},
+				[]string{"telemetryData"})
+		},
+		"Accelerate": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Accelerate.ApplyScenario("Accelerate", sm,
+				[]string{"speed", "unit"},
+				[]string{"newSpeed", "err"})
+		},
+		"DriveSelf": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.DriveSelf.ApplyScenario("DriveSelf", sm,
+				[]string{"endLocation"},
+				[]string{"err"})
+		},
+		"Turn": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Turn.ApplyScenario("Turn", sm,
+				[]string{"dir"},
+				[]string{""})
+		},
+		"GetPassengers": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetPassengers.ApplyScenario("GetPassengers", sm,
+				[]string{},
+				[]string{"passengers"})
+		},
+	})
+}
+
+// useSelfDrivingGolden records every method to g or replays them from it, see stubs.Golden
+func (m *mockSelfDriving) useSelfDrivingGolden(g *stubs.Golden) {
+	m.mocked.UpdateStatus.UseGolden(g, "SelfDriving.UpdateStatus",
+		[]string{"status"},
+		[]string{"err"})
+	m.mocked.LockDoors.UseGolden(g, "SelfDriving.LockDoors",
+		[]string{},
+		[]string{"err"})
+	m.mocked.GetEngineSpecs.UseGolden(g, "SelfDriving.GetEngineSpecs",
+		[]string{},
+		[]string{"power", "fuelType"})
+	m.mocked.ApplyBrakes.UseGolden(g, "SelfDriving.ApplyBrakes",
+		[]string{"force"},
+		[]string{"applied"})
+	m.mocked.GetTopSpeed.UseGolden(g, "SelfDriving.GetTopSpeed",
+		[]string{},
+		[]string{""})
+	m.mocked.ParkSelf.UseGolden(g, "SelfDriving.ParkSelf",
+		[]string{},
+		[]string{"err"})
+	m.mocked.Honk.UseGolden(g, "SelfDriving.Honk",
+		[]string{"times"},
+		[]string{})
+	m.mocked.LoadCargo.UseGolden(g, "SelfDriving.LoadCargo",
+		[]string{"items"},
+		[]string{"loaded", "err"})
+	m.mocked.GetVehicleStatus.UseGolden(g, "SelfDriving.GetVehicleStatus",
+		[]string{},
+		[]string{"vehicleStatus"})
+	m.mocked.TurnOffAC.UseGolden(g, "SelfDriving.TurnOffAC",
+		[]string{},
+		[]string{"err"})
+	m.mocked.TurnOffMusic.UseGolden(g, "SelfDriving.TurnOffMusic",
+		[]string{},
+		[]string{"err"})
+	m.mocked.CloseWindows.UseGolden(g, "SelfDriving.CloseWindows",
+		[]string{},
+		[]string{"err"})
+	m.mocked.Reverse.UseGolden(g, "SelfDriving.Reverse",
+		[]string{},
+		[]string{"location", "err"})
+	m.mocked.IsMoving.UseGolden(g, "SelfDriving.IsMoving",
+		[]string{},
+		[]string{"isMoving"})
+	m.mocked.ChangeGears.UseGolden(g, "SelfDriving.ChangeGears",
+		[]string{"gear"},
+		[]string{"before", "after"})
+	m.mocked.Telemetry.UseGolden(g, "SelfDriving.Telemetry",
+		[]string{},
+		[]string{"telemetryData"})
+	m.mocked.Accelerate.UseGolden(g, "SelfDriving.Accelerate",
+		[]string{"speed", "unit"},
+		[]string{"newSpeed", "err"})
+	m.mocked.DriveSelf.UseGolden(g, "SelfDriving.DriveSelf",
+		[]string{"endLocation"},
+		[]string{"err"})
+	m.mocked.Turn.UseGolden(g, "SelfDriving.Turn",
+		[]string{"dir"},
+		[]string{""})
+	m.mocked.GetPassengers.UseGolden(g, "SelfDriving.GetPassengers",
+		[]string{},
+		[]string{"passengers"})
+}
+
+// useSelfDrivingGoldenFile records to or replays from path in the mode given by stubs.GoldenModeFromFlags
+func (m *mockSelfDriving) useSelfDrivingGoldenFile(t stubs.TB, path string) *stubs.Golden {
+	t.Helper()
+	g := stubs.NewGolden(t, path, stubs.GoldenModeFromFlags())
+	m.useSelfDrivingGolden(g)
+	return g
 }
 
 /* -------------------------- UpdateStatus Mock Helpers --------------------------- */
 
-// enableUpdateStatusMock turns the spy on
+// enableUpdateStatusSpy turns the spy on
 func (m *mockSelfDriving) enableUpdateStatusSpy() {
-	m.mocked.UpdateStatus.SpyEnabled = true
+	m.mocked.UpdateStatus.EnableSpy()
 }
 
 // getUpdateStatusCalls returns recorded calls to UpdateStatus
@@ -60,49 +853,112 @@ func (m *mockSelfDriving) getUpdateStatusCalls() []stubs.MethodCall {
 	return m.mocked.UpdateStatus.Calls()
 }
 
-// enableUpdateStatusMock turns the spy off
+// enableUpdateStatusSpy turns the spy off
 func (m *mockSelfDriving) disableUpdateStatusSpy() {
-	m.mocked.UpdateStatus.SpyEnabled = false
+	m.mocked.UpdateStatus.DisableSpy()
+}
+
+// disableUpdateStatusArgCloning records arguments and results of UpdateStatus by reference instead of deep copying them
+func (m *mockSelfDriving) disableUpdateStatusArgCloning() {
+	m.mocked.UpdateStatus.DisableArgCloning()
+}
+
+// enableUpdateStatusArgCloning deep copies arguments and results of UpdateStatus as they are recorded
+func (m *mockSelfDriving) enableUpdateStatusArgCloning() {
+	m.mocked.UpdateStatus.EnableArgCloning()
 }
 
 // UpdateStatus overrides the method to return the mock response
 func (m *mockSelfDriving) UpdateStatus(status vehicle.VehicleStatus) error {
-	m.mocked.UpdateStatus.RecordCall(status)
+	callID := m.mocked.UpdateStatus.RecordCall(status)
+	defer m.mocked.UpdateStatus.EndCall(callID)
+	callArgs := mockSelfDrivingUpdateStatusArgs{Status: status}
+	m.hooks.UpdateStatus.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.UpdateStatus.Enabled {
+	if gateErr := m.mocked.UpdateStatus.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.UpdateStatus.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.UpdateStatus.IsEnabled() {
 		out0 = m.mocked.UpdateStatus.NextResponse(func(status vehicle.VehicleStatus) error {
 			return m.real.UpdateStatus(status)
 		})(status)
-
 	} else {
 		out0 = m.real.UpdateStatus(status)
-
 	}
 
-	if ch, ok := m.responseChans["UpdateStatus"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.UpdateStatus.RecordResults(callID, out0)
+	m.hooks.UpdateStatus.RunAfter(callArgs, out0)
+	m.events.UpdateStatus.Publish(mockSelfDrivingUpdateStatusEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onUpdateStatusBefore runs fn on every call to UpdateStatus before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onUpdateStatusBefore(fn func(args mockSelfDrivingUpdateStatusArgs)) func() {
+	return m.hooks.UpdateStatus.Before(fn)
+}
+
+// onUpdateStatusAfter runs fn once each call to UpdateStatus has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onUpdateStatusAfter(fn func(args mockSelfDrivingUpdateStatusArgs, result error)) func() {
+	return m.hooks.UpdateStatus.After(fn)
+}
+
+// notifyUpdateStatusCalled returns a channel that is closed when UpdateStatus is next called, before it responds
+func (m *mockSelfDriving) notifyUpdateStatusCalled() <-chan struct{} {
+	return m.hooks.UpdateStatus.Notify()
+}
+
+// blockUpdateStatus holds every later call to UpdateStatus mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockUpdateStatus() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.UpdateStatus.SetGate(g)
+	return g
+}
+
+// unblockUpdateStatus removes the gate set by blockUpdateStatus, releasing any held calls
+func (m *mockSelfDriving) unblockUpdateStatus() {
+	m.mocked.UpdateStatus.ClearGate()
+}
 
+// assertUpdateStatusMaxConcurrency fails the test if more than n calls to UpdateStatus were ever in flight at once
+func (m *mockSelfDriving) assertUpdateStatusMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.UpdateStatus, n)
+}
+
+// assertUpdateStatusNeverConcurrent fails the test if any two calls to UpdateStatus were in flight at the same time
+func (m *mockSelfDriving) assertUpdateStatusNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.UpdateStatus)
+}
+
+// getUpdateStatusConcurrencyStats returns the in-flight counts and overlapping calls to UpdateStatus
+func (m *mockSelfDriving) getUpdateStatusConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.UpdateStatus.ConcurrencyStats()
 }
 
 // setUpdateStatusFunc sets the function for UpdateStatus
 func (m *mockSelfDriving) setUpdateStatusFunc(f func(vehicle.VehicleStatus) error) {
-	m.mocked.UpdateStatus.Fallback = f
+	m.mocked.UpdateStatus.SetResponseFunc(f)
 }
 
 // enableUpdateStatusMock turns the mock on
 func (m *mockSelfDriving) enableUpdateStatusMock() {
-	m.mocked.UpdateStatus.Enabled = true
+	m.mocked.UpdateStatus.Enable()
 }
 
 // disableUpdateStatusMock turns the mock off
 func (m *mockSelfDriving) disableUpdateStatusMock() {
-	m.mocked.UpdateStatus.Enabled = false
+	m.mocked.UpdateStatus.Disable()
 }
 
 // enqueueUpdateStatusResponseFunc enqueues a function response for UpdateStatus
@@ -115,25 +971,108 @@ func (m *mockSelfDriving) enqueueUpdateStatusResponseFuncWithDelay(f func(vehicl
 	m.mocked.UpdateStatus.EnqueueWithDelay(f, d)
 }
 
-// captureUpdateStatusResult sets up a channel to capture UpdateStatus results.
-func (m *mockSelfDriving) captureUpdateStatusResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["UpdateStatus"] = ch
-	return ch
+// subscribeUpdateStatus returns a subscription that receives an event for every call to UpdateStatus. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeUpdateStatus(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingUpdateStatusEvent] {
+	return m.events.UpdateStatus.Subscribe(opts)
+}
+
+// captureUpdateStatusResult returns a channel that receives the result of every call to UpdateStatus.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureUpdateStatusResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.UpdateStatus, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingUpdateStatusEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureUpdateStatusCallSpy starts watching for UpdateStatus spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureUpdateStatusCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getUpdateStatusCalls, timeout)
-		ch <- m.getUpdateStatusCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureUpdateStatusCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.UpdateStatus, "UpdateStatus spy call", timeout)
+}
+
+// waitForUpdateStatusCalls waits until at least n UpdateStatus spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForUpdateStatusCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.UpdateStatus, n, timeout)
+}
+
+// waitForUpdateStatusCallWithin waits until a UpdateStatus spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForUpdateStatusCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.UpdateStatus, match, timeout)
+}
+
+// eventuallyUpdateStatusCalls waits until cond holds for the recorded UpdateStatus spy calls
+func (m *mockSelfDriving) eventuallyUpdateStatusCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.UpdateStatus, cond, timeout)
+}
+
+// waitForUpdateStatusCallWithArgs waits until UpdateStatus is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForUpdateStatusCallWithArgs(t stubs.TB, timeout time.Duration, wantStatus vehicle.VehicleStatus) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.UpdateStatus, timeout, wantStatus)
+}
+
+// consistentlyUpdateStatusNotCalled fails the test if UpdateStatus is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyUpdateStatusNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.UpdateStatus, window)
+}
+
+// setUpdateStatusPanic makes every mocked call to UpdateStatus panic with v
+func (m *mockSelfDriving) setUpdateStatusPanic(v any) {
+	m.mocked.UpdateStatus.SetPanicResponse(v)
+}
+
+// enqueueUpdateStatusPanic enqueues a response for UpdateStatus that panics with v
+func (m *mockSelfDriving) enqueueUpdateStatusPanic(v any) {
+	m.mocked.UpdateStatus.EnqueuePanic(v)
+}
+
+// injectUpdateStatusFaults applies a seeded fault policy to UpdateStatus. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectUpdateStatusFaults(policy stubs.FaultPolicy) {
+	m.mocked.UpdateStatus.SetFaults(policy)
+}
+
+// clearUpdateStatusFaults removes the fault policy from UpdateStatus
+func (m *mockSelfDriving) clearUpdateStatusFaults() {
+	m.mocked.UpdateStatus.ClearFaults()
+}
+
+// mockSelfDrivingUpdateStatusArgs holds the arguments of a call to UpdateStatus
+type mockSelfDrivingUpdateStatusArgs struct {
+	Status vehicle.VehicleStatus
+}
+
+// mockSelfDrivingUpdateStatusEvent is published to subscribers on every call to UpdateStatus
+type mockSelfDrivingUpdateStatusEvent struct {
+	Args   mockSelfDrivingUpdateStatusArgs
+	Result error
+}
+
+// mockSelfDrivingUpdateStatusCall is a typed record of a call to UpdateStatus. Outputs are zero until the call returns.
+type mockSelfDrivingUpdateStatusCall struct {
+	Status vehicle.VehicleStatus
+	Err    error
 }
 
-type mockSelfDrivingUpdateStatusResult struct {
-	Output0 error
+// getUpdateStatusTypedCalls returns the spied calls to UpdateStatus with typed arguments and results
+func (m *mockSelfDriving) getUpdateStatusTypedCalls() []mockSelfDrivingUpdateStatusCall {
+	calls := m.mocked.UpdateStatus.Calls()
+	typed := make([]mockSelfDrivingUpdateStatusCall, len(calls))
+	for i, call := range calls {
+		typed[i].Status, _ = call.Args[0].(vehicle.VehicleStatus)
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setUpdateStatusResponse sets the response for UpdateStatus
@@ -153,16 +1092,15 @@ func (m *mockSelfDriving) enqueueUpdateStatusResponse(output0 error) {
 // enqueueUpdateStatusResponseWithDelay enqueues a static response with delay for UpdateStatus
 func (m *mockSelfDriving) enqueueUpdateStatusResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.UpdateStatus.EnqueueWithDelay(func(vehicle.VehicleStatus) error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- LockDoors Mock Helpers --------------------------- */
 
-// enableLockDoorsMock turns the spy on
+// enableLockDoorsSpy turns the spy on
 func (m *mockSelfDriving) enableLockDoorsSpy() {
-	m.mocked.LockDoors.SpyEnabled = true
+	m.mocked.LockDoors.EnableSpy()
 }
 
 // getLockDoorsCalls returns recorded calls to LockDoors
@@ -170,49 +1108,112 @@ func (m *mockSelfDriving) getLockDoorsCalls() []stubs.MethodCall {
 	return m.mocked.LockDoors.Calls()
 }
 
-// enableLockDoorsMock turns the spy off
+// enableLockDoorsSpy turns the spy off
 func (m *mockSelfDriving) disableLockDoorsSpy() {
-	m.mocked.LockDoors.SpyEnabled = false
+	m.mocked.LockDoors.DisableSpy()
+}
+
+// disableLockDoorsArgCloning records arguments and results of LockDoors by reference instead of deep copying them
+func (m *mockSelfDriving) disableLockDoorsArgCloning() {
+	m.mocked.LockDoors.DisableArgCloning()
+}
+
+// enableLockDoorsArgCloning deep copies arguments and results of LockDoors as they are recorded
+func (m *mockSelfDriving) enableLockDoorsArgCloning() {
+	m.mocked.LockDoors.EnableArgCloning()
 }
 
 // LockDoors overrides the method to return the mock response
 func (m *mockSelfDriving) LockDoors() error {
-	m.mocked.LockDoors.RecordCall()
+	callID := m.mocked.LockDoors.RecordCall()
+	defer m.mocked.LockDoors.EndCall(callID)
+	callArgs := mockSelfDrivingLockDoorsArgs{}
+	m.hooks.LockDoors.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.LockDoors.Enabled {
+	if gateErr := m.mocked.LockDoors.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.LockDoors.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.LockDoors.IsEnabled() {
 		out0 = m.mocked.LockDoors.NextResponse(func() error {
 			return m.real.LockDoors()
 		})()
-
 	} else {
 		out0 = m.real.LockDoors()
-
 	}
 
-	if ch, ok := m.responseChans["LockDoors"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.LockDoors.RecordResults(callID, out0)
+	m.hooks.LockDoors.RunAfter(callArgs, out0)
+	m.events.LockDoors.Publish(mockSelfDrivingLockDoorsEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onLockDoorsBefore runs fn on every call to LockDoors before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onLockDoorsBefore(fn func(args mockSelfDrivingLockDoorsArgs)) func() {
+	return m.hooks.LockDoors.Before(fn)
+}
+
+// onLockDoorsAfter runs fn once each call to LockDoors has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onLockDoorsAfter(fn func(args mockSelfDrivingLockDoorsArgs, result error)) func() {
+	return m.hooks.LockDoors.After(fn)
+}
+
+// notifyLockDoorsCalled returns a channel that is closed when LockDoors is next called, before it responds
+func (m *mockSelfDriving) notifyLockDoorsCalled() <-chan struct{} {
+	return m.hooks.LockDoors.Notify()
+}
+
+// blockLockDoors holds every later call to LockDoors mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockLockDoors() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.LockDoors.SetGate(g)
+	return g
+}
+
+// unblockLockDoors removes the gate set by blockLockDoors, releasing any held calls
+func (m *mockSelfDriving) unblockLockDoors() {
+	m.mocked.LockDoors.ClearGate()
+}
+
+// assertLockDoorsMaxConcurrency fails the test if more than n calls to LockDoors were ever in flight at once
+func (m *mockSelfDriving) assertLockDoorsMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.LockDoors, n)
+}
+
+// assertLockDoorsNeverConcurrent fails the test if any two calls to LockDoors were in flight at the same time
+func (m *mockSelfDriving) assertLockDoorsNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.LockDoors)
+}
 
+// getLockDoorsConcurrencyStats returns the in-flight counts and overlapping calls to LockDoors
+func (m *mockSelfDriving) getLockDoorsConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.LockDoors.ConcurrencyStats()
 }
 
 // setLockDoorsFunc sets the function for LockDoors
 func (m *mockSelfDriving) setLockDoorsFunc(f func() error) {
-	m.mocked.LockDoors.Fallback = f
+	m.mocked.LockDoors.SetResponseFunc(f)
 }
 
 // enableLockDoorsMock turns the mock on
 func (m *mockSelfDriving) enableLockDoorsMock() {
-	m.mocked.LockDoors.Enabled = true
+	m.mocked.LockDoors.Enable()
 }
 
 // disableLockDoorsMock turns the mock off
 func (m *mockSelfDriving) disableLockDoorsMock() {
-	m.mocked.LockDoors.Enabled = false
+	m.mocked.LockDoors.Disable()
 }
 
 // enqueueLockDoorsResponseFunc enqueues a function response for LockDoors
@@ -225,25 +1226,98 @@ func (m *mockSelfDriving) enqueueLockDoorsResponseFuncWithDelay(f func() error,
 	m.mocked.LockDoors.EnqueueWithDelay(f, d)
 }
 
-// captureLockDoorsResult sets up a channel to capture LockDoors results.
-func (m *mockSelfDriving) captureLockDoorsResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["LockDoors"] = ch
-	return ch
+// subscribeLockDoors returns a subscription that receives an event for every call to LockDoors. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeLockDoors(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingLockDoorsEvent] {
+	return m.events.LockDoors.Subscribe(opts)
+}
+
+// captureLockDoorsResult returns a channel that receives the result of every call to LockDoors.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureLockDoorsResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.LockDoors, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingLockDoorsEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureLockDoorsCallSpy starts watching for LockDoors spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureLockDoorsCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getLockDoorsCalls, timeout)
-		ch <- m.getLockDoorsCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureLockDoorsCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.LockDoors, "LockDoors spy call", timeout)
+}
+
+// waitForLockDoorsCalls waits until at least n LockDoors spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForLockDoorsCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.LockDoors, n, timeout)
+}
+
+// waitForLockDoorsCallWithin waits until a LockDoors spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForLockDoorsCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.LockDoors, match, timeout)
+}
+
+// eventuallyLockDoorsCalls waits until cond holds for the recorded LockDoors spy calls
+func (m *mockSelfDriving) eventuallyLockDoorsCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.LockDoors, cond, timeout)
 }
 
-type mockSelfDrivingLockDoorsResult struct {
-	Output0 error
+// consistentlyLockDoorsNotCalled fails the test if LockDoors is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyLockDoorsNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.LockDoors, window)
+}
+
+// setLockDoorsPanic makes every mocked call to LockDoors panic with v
+func (m *mockSelfDriving) setLockDoorsPanic(v any) {
+	m.mocked.LockDoors.SetPanicResponse(v)
+}
+
+// enqueueLockDoorsPanic enqueues a response for LockDoors that panics with v
+func (m *mockSelfDriving) enqueueLockDoorsPanic(v any) {
+	m.mocked.LockDoors.EnqueuePanic(v)
+}
+
+// injectLockDoorsFaults applies a seeded fault policy to LockDoors. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectLockDoorsFaults(policy stubs.FaultPolicy) {
+	m.mocked.LockDoors.SetFaults(policy)
+}
+
+// clearLockDoorsFaults removes the fault policy from LockDoors
+func (m *mockSelfDriving) clearLockDoorsFaults() {
+	m.mocked.LockDoors.ClearFaults()
+}
+
+// mockSelfDrivingLockDoorsArgs holds the arguments of a call to LockDoors
+type mockSelfDrivingLockDoorsArgs struct {
+}
+
+// mockSelfDrivingLockDoorsEvent is published to subscribers on every call to LockDoors
+type mockSelfDrivingLockDoorsEvent struct {
+	Args   mockSelfDrivingLockDoorsArgs
+	Result error
+}
+
+// mockSelfDrivingLockDoorsCall is a typed record of a call to LockDoors. Outputs are zero until the call returns.
+type mockSelfDrivingLockDoorsCall struct {
+	Err error
+}
+
+// getLockDoorsTypedCalls returns the spied calls to LockDoors with typed arguments and results
+func (m *mockSelfDriving) getLockDoorsTypedCalls() []mockSelfDrivingLockDoorsCall {
+	calls := m.mocked.LockDoors.Calls()
+	typed := make([]mockSelfDrivingLockDoorsCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setLockDoorsResponse sets the response for LockDoors
@@ -263,16 +1337,15 @@ func (m *mockSelfDriving) enqueueLockDoorsResponse(output0 error) {
 // enqueueLockDoorsResponseWithDelay enqueues a static response with delay for LockDoors
 func (m *mockSelfDriving) enqueueLockDoorsResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.LockDoors.EnqueueWithDelay(func() error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- GetEngineSpecs Mock Helpers --------------------------- */
 
-// enableGetEngineSpecsMock turns the spy on
+// enableGetEngineSpecsSpy turns the spy on
 func (m *mockSelfDriving) enableGetEngineSpecsSpy() {
-	m.mocked.GetEngineSpecs.SpyEnabled = true
+	m.mocked.GetEngineSpecs.EnableSpy()
 }
 
 // getGetEngineSpecsCalls returns recorded calls to GetEngineSpecs
@@ -280,55 +1353,117 @@ func (m *mockSelfDriving) getGetEngineSpecsCalls() []stubs.MethodCall {
 	return m.mocked.GetEngineSpecs.Calls()
 }
 
-// enableGetEngineSpecsMock turns the spy off
+// enableGetEngineSpecsSpy turns the spy off
 func (m *mockSelfDriving) disableGetEngineSpecsSpy() {
-	m.mocked.GetEngineSpecs.SpyEnabled = false
+	m.mocked.GetEngineSpecs.DisableSpy()
+}
+
+// disableGetEngineSpecsArgCloning records arguments and results of GetEngineSpecs by reference instead of deep copying them
+func (m *mockSelfDriving) disableGetEngineSpecsArgCloning() {
+	m.mocked.GetEngineSpecs.DisableArgCloning()
+}
+
+// enableGetEngineSpecsArgCloning deep copies arguments and results of GetEngineSpecs as they are recorded
+func (m *mockSelfDriving) enableGetEngineSpecsArgCloning() {
+	m.mocked.GetEngineSpecs.EnableArgCloning()
 }
 
 // GetEngineSpecs overrides the method to return the mock response
 func (m *mockSelfDriving) GetEngineSpecs() (int, string) {
-	m.mocked.GetEngineSpecs.RecordCall()
+	callID := m.mocked.GetEngineSpecs.RecordCall()
+	defer m.mocked.GetEngineSpecs.EndCall(callID)
+	callArgs := mockSelfDrivingGetEngineSpecsArgs{}
+	m.hooks.GetEngineSpecs.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 string
-
-		result mockSelfDrivingGetEngineSpecsResult
 	)
 
-	if m.mocked.GetEngineSpecs.Enabled {
+	gatePassed := m.mocked.GetEngineSpecs.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetEngineSpecs.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetEngineSpecs.IsEnabled() {
 		out0, out1 = m.mocked.GetEngineSpecs.NextResponse(func() (int, string) {
 			return m.real.GetEngineSpecs()
 		})()
-
 	} else {
 		out0, out1 = m.real.GetEngineSpecs()
-
 	}
 
-	result = mockSelfDrivingGetEngineSpecsResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["GetEngineSpecs"]; ok {
-		chTyped := ch.(chan mockSelfDrivingGetEngineSpecsResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.GetEngineSpecs.RecordResults(callID, out0, out1)
+	callResult := mockSelfDrivingGetEngineSpecsResult{Power: out0, FuelType: out1}
+	m.hooks.GetEngineSpecs.RunAfter(callArgs, callResult)
+	m.events.GetEngineSpecs.Publish(mockSelfDrivingGetEngineSpecsEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onGetEngineSpecsBefore runs fn on every call to GetEngineSpecs before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onGetEngineSpecsBefore(fn func(args mockSelfDrivingGetEngineSpecsArgs)) func() {
+	return m.hooks.GetEngineSpecs.Before(fn)
+}
 
+// onGetEngineSpecsAfter runs fn once each call to GetEngineSpecs has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onGetEngineSpecsAfter(fn func(args mockSelfDrivingGetEngineSpecsArgs, result mockSelfDrivingGetEngineSpecsResult)) func() {
+	return m.hooks.GetEngineSpecs.After(fn)
+}
+
+// notifyGetEngineSpecsCalled returns a channel that is closed when GetEngineSpecs is next called, before it responds
+func (m *mockSelfDriving) notifyGetEngineSpecsCalled() <-chan struct{} {
+	return m.hooks.GetEngineSpecs.Notify()
+}
+
+// blockGetEngineSpecs holds every later call to GetEngineSpecs mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockGetEngineSpecs() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetEngineSpecs.SetGate(g)
+	return g
+}
+
+// unblockGetEngineSpecs removes the gate set by blockGetEngineSpecs, releasing any held calls
+func (m *mockSelfDriving) unblockGetEngineSpecs() {
+	m.mocked.GetEngineSpecs.ClearGate()
+}
+
+// assertGetEngineSpecsMaxConcurrency fails the test if more than n calls to GetEngineSpecs were ever in flight at once
+func (m *mockSelfDriving) assertGetEngineSpecsMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetEngineSpecs, n)
+}
+
+// assertGetEngineSpecsNeverConcurrent fails the test if any two calls to GetEngineSpecs were in flight at the same time
+func (m *mockSelfDriving) assertGetEngineSpecsNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetEngineSpecs)
+}
+
+// getGetEngineSpecsConcurrencyStats returns the in-flight counts and overlapping calls to GetEngineSpecs
+func (m *mockSelfDriving) getGetEngineSpecsConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetEngineSpecs.ConcurrencyStats()
 }
 
 // setGetEngineSpecsFunc sets the function for GetEngineSpecs
 func (m *mockSelfDriving) setGetEngineSpecsFunc(f func() (int, string)) {
-	m.mocked.GetEngineSpecs.Fallback = f
+	m.mocked.GetEngineSpecs.SetResponseFunc(f)
 }
 
 // enableGetEngineSpecsMock turns the mock on
 func (m *mockSelfDriving) enableGetEngineSpecsMock() {
-	m.mocked.GetEngineSpecs.Enabled = true
+	m.mocked.GetEngineSpecs.Enable()
 }
 
 // disableGetEngineSpecsMock turns the mock off
 func (m *mockSelfDriving) disableGetEngineSpecsMock() {
-	m.mocked.GetEngineSpecs.Enabled = false
+	m.mocked.GetEngineSpecs.Disable()
 }
 
 // enqueueGetEngineSpecsResponseFunc enqueues a function response for GetEngineSpecs
@@ -341,26 +1476,114 @@ func (m *mockSelfDriving) enqueueGetEngineSpecsResponseFuncWithDelay(f func() (i
 	m.mocked.GetEngineSpecs.EnqueueWithDelay(f, d)
 }
 
-// captureGetEngineSpecsResult sets up a channel to capture GetEngineSpecs results.
-func (m *mockSelfDriving) captureGetEngineSpecsResult() <-chan mockSelfDrivingGetEngineSpecsResult {
-	ch := make(chan mockSelfDrivingGetEngineSpecsResult, 1)
-	m.responseChans["GetEngineSpecs"] = ch
-	return ch
+// subscribeGetEngineSpecs returns a subscription that receives an event for every call to GetEngineSpecs. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeGetEngineSpecs(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingGetEngineSpecsEvent] {
+	return m.events.GetEngineSpecs.Subscribe(opts)
+}
+
+// captureGetEngineSpecsResult returns a channel that receives the result of every call to GetEngineSpecs.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureGetEngineSpecsResult(t stubs.TB) <-chan mockSelfDrivingGetEngineSpecsResult {
+	sub := stubs.SubscribeMapped(&m.events.GetEngineSpecs, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingGetEngineSpecsEvent) mockSelfDrivingGetEngineSpecsResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetEngineSpecsCallSpy starts watching for GetEngineSpecs spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureGetEngineSpecsCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetEngineSpecsCalls, timeout)
-		ch <- m.getGetEngineSpecsCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureGetEngineSpecsCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetEngineSpecs, "GetEngineSpecs spy call", timeout)
+}
+
+// waitForGetEngineSpecsCalls waits until at least n GetEngineSpecs spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForGetEngineSpecsCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetEngineSpecs, n, timeout)
+}
+
+// waitForGetEngineSpecsCallWithin waits until a GetEngineSpecs spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForGetEngineSpecsCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetEngineSpecs, match, timeout)
+}
+
+// eventuallyGetEngineSpecsCalls waits until cond holds for the recorded GetEngineSpecs spy calls
+func (m *mockSelfDriving) eventuallyGetEngineSpecsCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetEngineSpecs, cond, timeout)
+}
+
+// consistentlyGetEngineSpecsNotCalled fails the test if GetEngineSpecs is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyGetEngineSpecsNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetEngineSpecs, window)
+}
+
+// setGetEngineSpecsPanic makes every mocked call to GetEngineSpecs panic with v
+func (m *mockSelfDriving) setGetEngineSpecsPanic(v any) {
+	m.mocked.GetEngineSpecs.SetPanicResponse(v)
+}
+
+// enqueueGetEngineSpecsPanic enqueues a response for GetEngineSpecs that panics with v
+func (m *mockSelfDriving) enqueueGetEngineSpecsPanic(v any) {
+	m.mocked.GetEngineSpecs.EnqueuePanic(v)
+}
+
+// injectGetEngineSpecsFaults applies a seeded fault policy to GetEngineSpecs. GetEngineSpecs has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectGetEngineSpecsFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetEngineSpecsFaults: GetEngineSpecs has no error output to inject errors into")
+	}
+	m.mocked.GetEngineSpecs.SetFaults(policy)
+}
+
+// clearGetEngineSpecsFaults removes the fault policy from GetEngineSpecs
+func (m *mockSelfDriving) clearGetEngineSpecsFaults() {
+	m.mocked.GetEngineSpecs.ClearFaults()
 }
 
+// mockSelfDrivingGetEngineSpecsResult holds the outputs of a call to GetEngineSpecs
 type mockSelfDrivingGetEngineSpecsResult struct {
-	Output0 int
-	Output1 string
+	Power    int
+	FuelType string
+}
+
+// Values returns the outputs in the order GetEngineSpecs returns them
+func (r mockSelfDrivingGetEngineSpecsResult) Values() (int, string) {
+	return r.Power, r.FuelType
+}
+
+// mockSelfDrivingGetEngineSpecsArgs holds the arguments of a call to GetEngineSpecs
+type mockSelfDrivingGetEngineSpecsArgs struct {
+}
+
+// mockSelfDrivingGetEngineSpecsEvent is published to subscribers on every call to GetEngineSpecs
+type mockSelfDrivingGetEngineSpecsEvent struct {
+	Args   mockSelfDrivingGetEngineSpecsArgs
+	Result mockSelfDrivingGetEngineSpecsResult
+}
+
+// mockSelfDrivingGetEngineSpecsCall is a typed record of a call to GetEngineSpecs. Outputs are zero until the call returns.
+type mockSelfDrivingGetEngineSpecsCall struct {
+	Power    int
+	FuelType string
+}
+
+// getGetEngineSpecsTypedCalls returns the spied calls to GetEngineSpecs with typed arguments and results
+func (m *mockSelfDriving) getGetEngineSpecsTypedCalls() []mockSelfDrivingGetEngineSpecsCall {
+	calls := m.mocked.GetEngineSpecs.Calls()
+	typed := make([]mockSelfDrivingGetEngineSpecsCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Power, _ = call.Results[0].(int)
+			typed[i].FuelType, _ = call.Results[1].(string)
+		}
+	}
+	return typed
 }
 
 // setGetEngineSpecsResponse sets the response for GetEngineSpecs
@@ -380,16 +1603,15 @@ func (m *mockSelfDriving) enqueueGetEngineSpecsResponse(output0 int, output1 str
 // enqueueGetEngineSpecsResponseWithDelay enqueues a static response with delay for GetEngineSpecs
 func (m *mockSelfDriving) enqueueGetEngineSpecsResponseWithDelay(output0 int, output1 string, d time.Duration) {
 	m.mocked.GetEngineSpecs.EnqueueWithDelay(func() (int, string) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- ApplyBrakes Mock Helpers --------------------------- */
 
-// enableApplyBrakesMock turns the spy on
+// enableApplyBrakesSpy turns the spy on
 func (m *mockSelfDriving) enableApplyBrakesSpy() {
-	m.mocked.ApplyBrakes.SpyEnabled = true
+	m.mocked.ApplyBrakes.EnableSpy()
 }
 
 // getApplyBrakesCalls returns recorded calls to ApplyBrakes
@@ -397,49 +1619,115 @@ func (m *mockSelfDriving) getApplyBrakesCalls() []stubs.MethodCall {
 	return m.mocked.ApplyBrakes.Calls()
 }
 
-// enableApplyBrakesMock turns the spy off
+// enableApplyBrakesSpy turns the spy off
 func (m *mockSelfDriving) disableApplyBrakesSpy() {
-	m.mocked.ApplyBrakes.SpyEnabled = false
+	m.mocked.ApplyBrakes.DisableSpy()
+}
+
+// disableApplyBrakesArgCloning records arguments and results of ApplyBrakes by reference instead of deep copying them
+func (m *mockSelfDriving) disableApplyBrakesArgCloning() {
+	m.mocked.ApplyBrakes.DisableArgCloning()
+}
+
+// enableApplyBrakesArgCloning deep copies arguments and results of ApplyBrakes as they are recorded
+func (m *mockSelfDriving) enableApplyBrakesArgCloning() {
+	m.mocked.ApplyBrakes.EnableArgCloning()
 }
 
 // ApplyBrakes overrides the method to return the mock response
 func (m *mockSelfDriving) ApplyBrakes(force float64) bool {
-	m.mocked.ApplyBrakes.RecordCall(force)
+	callID := m.mocked.ApplyBrakes.RecordCall(force)
+	defer m.mocked.ApplyBrakes.EndCall(callID)
+	callArgs := mockSelfDrivingApplyBrakesArgs{Force: force}
+	m.hooks.ApplyBrakes.RunBefore(callArgs)
 	var (
 		out0 bool
 	)
 
-	if m.mocked.ApplyBrakes.Enabled {
+	gatePassed := m.mocked.ApplyBrakes.PassGateOrReport()
+	if gatePassed {
+		m.mocked.ApplyBrakes.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.ApplyBrakes.IsEnabled() {
 		out0 = m.mocked.ApplyBrakes.NextResponse(func(force float64) bool {
 			return m.real.ApplyBrakes(force)
 		})(force)
-
 	} else {
 		out0 = m.real.ApplyBrakes(force)
-
 	}
 
-	if ch, ok := m.responseChans["ApplyBrakes"]; ok {
-		chTyped := ch.(chan bool)
-		chTyped <- out0
-	}
+	m.mocked.ApplyBrakes.RecordResults(callID, out0)
+	m.hooks.ApplyBrakes.RunAfter(callArgs, out0)
+	m.events.ApplyBrakes.Publish(mockSelfDrivingApplyBrakesEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onApplyBrakesBefore runs fn on every call to ApplyBrakes before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onApplyBrakesBefore(fn func(args mockSelfDrivingApplyBrakesArgs)) func() {
+	return m.hooks.ApplyBrakes.Before(fn)
+}
+
+// onApplyBrakesAfter runs fn once each call to ApplyBrakes has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onApplyBrakesAfter(fn func(args mockSelfDrivingApplyBrakesArgs, result bool)) func() {
+	return m.hooks.ApplyBrakes.After(fn)
+}
+
+// notifyApplyBrakesCalled returns a channel that is closed when ApplyBrakes is next called, before it responds
+func (m *mockSelfDriving) notifyApplyBrakesCalled() <-chan struct{} {
+	return m.hooks.ApplyBrakes.Notify()
+}
+
+// blockApplyBrakes holds every later call to ApplyBrakes mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockApplyBrakes() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.ApplyBrakes.SetGate(g)
+	return g
+}
+
+// unblockApplyBrakes removes the gate set by blockApplyBrakes, releasing any held calls
+func (m *mockSelfDriving) unblockApplyBrakes() {
+	m.mocked.ApplyBrakes.ClearGate()
+}
+
+// assertApplyBrakesMaxConcurrency fails the test if more than n calls to ApplyBrakes were ever in flight at once
+func (m *mockSelfDriving) assertApplyBrakesMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.ApplyBrakes, n)
+}
+
+// assertApplyBrakesNeverConcurrent fails the test if any two calls to ApplyBrakes were in flight at the same time
+func (m *mockSelfDriving) assertApplyBrakesNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.ApplyBrakes)
+}
 
+// getApplyBrakesConcurrencyStats returns the in-flight counts and overlapping calls to ApplyBrakes
+func (m *mockSelfDriving) getApplyBrakesConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.ApplyBrakes.ConcurrencyStats()
 }
 
 // setApplyBrakesFunc sets the function for ApplyBrakes
 func (m *mockSelfDriving) setApplyBrakesFunc(f func(float64) bool) {
-	m.mocked.ApplyBrakes.Fallback = f
+	m.mocked.ApplyBrakes.SetResponseFunc(f)
 }
 
 // enableApplyBrakesMock turns the mock on
 func (m *mockSelfDriving) enableApplyBrakesMock() {
-	m.mocked.ApplyBrakes.Enabled = true
+	m.mocked.ApplyBrakes.Enable()
 }
 
 // disableApplyBrakesMock turns the mock off
 func (m *mockSelfDriving) disableApplyBrakesMock() {
-	m.mocked.ApplyBrakes.Enabled = false
+	m.mocked.ApplyBrakes.Disable()
 }
 
 // enqueueApplyBrakesResponseFunc enqueues a function response for ApplyBrakes
@@ -452,25 +1740,111 @@ func (m *mockSelfDriving) enqueueApplyBrakesResponseFuncWithDelay(f func(float64
 	m.mocked.ApplyBrakes.EnqueueWithDelay(f, d)
 }
 
-// captureApplyBrakesResult sets up a channel to capture ApplyBrakes results.
-func (m *mockSelfDriving) captureApplyBrakesResult() <-chan bool {
-	ch := make(chan bool, 1)
-	m.responseChans["ApplyBrakes"] = ch
-	return ch
+// subscribeApplyBrakes returns a subscription that receives an event for every call to ApplyBrakes. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeApplyBrakes(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingApplyBrakesEvent] {
+	return m.events.ApplyBrakes.Subscribe(opts)
+}
+
+// captureApplyBrakesResult returns a channel that receives the result of every call to ApplyBrakes.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureApplyBrakesResult(t stubs.TB) <-chan bool {
+	sub := stubs.SubscribeMapped(&m.events.ApplyBrakes, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingApplyBrakesEvent) bool {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureApplyBrakesCallSpy starts watching for ApplyBrakes spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureApplyBrakesCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getApplyBrakesCalls, timeout)
-		ch <- m.getApplyBrakesCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureApplyBrakesCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.ApplyBrakes, "ApplyBrakes spy call", timeout)
+}
+
+// waitForApplyBrakesCalls waits until at least n ApplyBrakes spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForApplyBrakesCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.ApplyBrakes, n, timeout)
+}
+
+// waitForApplyBrakesCallWithin waits until a ApplyBrakes spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForApplyBrakesCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.ApplyBrakes, match, timeout)
+}
+
+// eventuallyApplyBrakesCalls waits until cond holds for the recorded ApplyBrakes spy calls
+func (m *mockSelfDriving) eventuallyApplyBrakesCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.ApplyBrakes, cond, timeout)
+}
+
+// waitForApplyBrakesCallWithArgs waits until ApplyBrakes is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForApplyBrakesCallWithArgs(t stubs.TB, timeout time.Duration, wantForce float64) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.ApplyBrakes, timeout, wantForce)
+}
+
+// consistentlyApplyBrakesNotCalled fails the test if ApplyBrakes is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyApplyBrakesNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.ApplyBrakes, window)
+}
+
+// setApplyBrakesPanic makes every mocked call to ApplyBrakes panic with v
+func (m *mockSelfDriving) setApplyBrakesPanic(v any) {
+	m.mocked.ApplyBrakes.SetPanicResponse(v)
+}
+
+// enqueueApplyBrakesPanic enqueues a response for ApplyBrakes that panics with v
+func (m *mockSelfDriving) enqueueApplyBrakesPanic(v any) {
+	m.mocked.ApplyBrakes.EnqueuePanic(v)
+}
+
+// injectApplyBrakesFaults applies a seeded fault policy to ApplyBrakes. ApplyBrakes has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectApplyBrakesFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectApplyBrakesFaults: ApplyBrakes has no error output to inject errors into")
+	}
+	m.mocked.ApplyBrakes.SetFaults(policy)
+}
+
+// clearApplyBrakesFaults removes the fault policy from ApplyBrakes
+func (m *mockSelfDriving) clearApplyBrakesFaults() {
+	m.mocked.ApplyBrakes.ClearFaults()
+}
+
+// mockSelfDrivingApplyBrakesArgs holds the arguments of a call to ApplyBrakes
+type mockSelfDrivingApplyBrakesArgs struct {
+	Force float64
+}
+
+// mockSelfDrivingApplyBrakesEvent is published to subscribers on every call to ApplyBrakes
+type mockSelfDrivingApplyBrakesEvent struct {
+	Args   mockSelfDrivingApplyBrakesArgs
+	Result bool
+}
+
+// mockSelfDrivingApplyBrakesCall is a typed record of a call to ApplyBrakes. Outputs are zero until the call returns.
+type mockSelfDrivingApplyBrakesCall struct {
+	Force   float64
+	Applied bool
 }
 
-type mockSelfDrivingApplyBrakesResult struct {
-	Output0 bool
+// getApplyBrakesTypedCalls returns the spied calls to ApplyBrakes with typed arguments and results
+func (m *mockSelfDriving) getApplyBrakesTypedCalls() []mockSelfDrivingApplyBrakesCall {
+	calls := m.mocked.ApplyBrakes.Calls()
+	typed := make([]mockSelfDrivingApplyBrakesCall, len(calls))
+	for i, call := range calls {
+		typed[i].Force, _ = call.Args[0].(float64)
+		if call.Returned {
+			typed[i].Applied, _ = call.Results[0].(bool)
+		}
+	}
+	return typed
 }
 
 // setApplyBrakesResponse sets the response for ApplyBrakes
@@ -490,16 +1864,15 @@ func (m *mockSelfDriving) enqueueApplyBrakesResponse(output0 bool) {
 // enqueueApplyBrakesResponseWithDelay enqueues a static response with delay for ApplyBrakes
 func (m *mockSelfDriving) enqueueApplyBrakesResponseWithDelay(output0 bool, d time.Duration) {
 	m.mocked.ApplyBrakes.EnqueueWithDelay(func(float64) bool {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- GetTopSpeed Mock Helpers --------------------------- */
 
-// enableGetTopSpeedMock turns the spy on
+// enableGetTopSpeedSpy turns the spy on
 func (m *mockSelfDriving) enableGetTopSpeedSpy() {
-	m.mocked.GetTopSpeed.SpyEnabled = true
+	m.mocked.GetTopSpeed.EnableSpy()
 }
 
 // getGetTopSpeedCalls returns recorded calls to GetTopSpeed
@@ -507,49 +1880,115 @@ func (m *mockSelfDriving) getGetTopSpeedCalls() []stubs.MethodCall {
 	return m.mocked.GetTopSpeed.Calls()
 }
 
-// enableGetTopSpeedMock turns the spy off
+// enableGetTopSpeedSpy turns the spy off
 func (m *mockSelfDriving) disableGetTopSpeedSpy() {
-	m.mocked.GetTopSpeed.SpyEnabled = false
+	m.mocked.GetTopSpeed.DisableSpy()
+}
+
+// disableGetTopSpeedArgCloning records arguments and results of GetTopSpeed by reference instead of deep copying them
+func (m *mockSelfDriving) disableGetTopSpeedArgCloning() {
+	m.mocked.GetTopSpeed.DisableArgCloning()
+}
+
+// enableGetTopSpeedArgCloning deep copies arguments and results of GetTopSpeed as they are recorded
+func (m *mockSelfDriving) enableGetTopSpeedArgCloning() {
+	m.mocked.GetTopSpeed.EnableArgCloning()
 }
 
 // GetTopSpeed overrides the method to return the mock response
 func (m *mockSelfDriving) GetTopSpeed() int {
-	m.mocked.GetTopSpeed.RecordCall()
+	callID := m.mocked.GetTopSpeed.RecordCall()
+	defer m.mocked.GetTopSpeed.EndCall(callID)
+	callArgs := mockSelfDrivingGetTopSpeedArgs{}
+	m.hooks.GetTopSpeed.RunBefore(callArgs)
 	var (
 		out0 int
 	)
 
-	if m.mocked.GetTopSpeed.Enabled {
+	gatePassed := m.mocked.GetTopSpeed.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetTopSpeed.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetTopSpeed.IsEnabled() {
 		out0 = m.mocked.GetTopSpeed.NextResponse(func() int {
 			return m.real.GetTopSpeed()
 		})()
-
 	} else {
 		out0 = m.real.GetTopSpeed()
-
 	}
 
-	if ch, ok := m.responseChans["GetTopSpeed"]; ok {
-		chTyped := ch.(chan int)
-		chTyped <- out0
-	}
+	m.mocked.GetTopSpeed.RecordResults(callID, out0)
+	m.hooks.GetTopSpeed.RunAfter(callArgs, out0)
+	m.events.GetTopSpeed.Publish(mockSelfDrivingGetTopSpeedEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onGetTopSpeedBefore runs fn on every call to GetTopSpeed before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onGetTopSpeedBefore(fn func(args mockSelfDrivingGetTopSpeedArgs)) func() {
+	return m.hooks.GetTopSpeed.Before(fn)
+}
+
+// onGetTopSpeedAfter runs fn once each call to GetTopSpeed has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onGetTopSpeedAfter(fn func(args mockSelfDrivingGetTopSpeedArgs, result int)) func() {
+	return m.hooks.GetTopSpeed.After(fn)
+}
+
+// notifyGetTopSpeedCalled returns a channel that is closed when GetTopSpeed is next called, before it responds
+func (m *mockSelfDriving) notifyGetTopSpeedCalled() <-chan struct{} {
+	return m.hooks.GetTopSpeed.Notify()
+}
+
+// blockGetTopSpeed holds every later call to GetTopSpeed mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockGetTopSpeed() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetTopSpeed.SetGate(g)
+	return g
+}
+
+// unblockGetTopSpeed removes the gate set by blockGetTopSpeed, releasing any held calls
+func (m *mockSelfDriving) unblockGetTopSpeed() {
+	m.mocked.GetTopSpeed.ClearGate()
+}
+
+// assertGetTopSpeedMaxConcurrency fails the test if more than n calls to GetTopSpeed were ever in flight at once
+func (m *mockSelfDriving) assertGetTopSpeedMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetTopSpeed, n)
+}
+
+// assertGetTopSpeedNeverConcurrent fails the test if any two calls to GetTopSpeed were in flight at the same time
+func (m *mockSelfDriving) assertGetTopSpeedNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetTopSpeed)
+}
 
+// getGetTopSpeedConcurrencyStats returns the in-flight counts and overlapping calls to GetTopSpeed
+func (m *mockSelfDriving) getGetTopSpeedConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetTopSpeed.ConcurrencyStats()
 }
 
 // setGetTopSpeedFunc sets the function for GetTopSpeed
 func (m *mockSelfDriving) setGetTopSpeedFunc(f func() int) {
-	m.mocked.GetTopSpeed.Fallback = f
+	m.mocked.GetTopSpeed.SetResponseFunc(f)
 }
 
 // enableGetTopSpeedMock turns the mock on
 func (m *mockSelfDriving) enableGetTopSpeedMock() {
-	m.mocked.GetTopSpeed.Enabled = true
+	m.mocked.GetTopSpeed.Enable()
 }
 
 // disableGetTopSpeedMock turns the mock off
 func (m *mockSelfDriving) disableGetTopSpeedMock() {
-	m.mocked.GetTopSpeed.Enabled = false
+	m.mocked.GetTopSpeed.Disable()
 }
 
 // enqueueGetTopSpeedResponseFunc enqueues a function response for GetTopSpeed
@@ -562,28 +2001,104 @@ func (m *mockSelfDriving) enqueueGetTopSpeedResponseFuncWithDelay(f func() int,
 	m.mocked.GetTopSpeed.EnqueueWithDelay(f, d)
 }
 
-// captureGetTopSpeedResult sets up a channel to capture GetTopSpeed results.
-func (m *mockSelfDriving) captureGetTopSpeedResult() <-chan int {
-	ch := make(chan int, 1)
-	m.responseChans["GetTopSpeed"] = ch
-	return ch
+// subscribeGetTopSpeed returns a subscription that receives an event for every call to GetTopSpeed. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeGetTopSpeed(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingGetTopSpeedEvent] {
+	return m.events.GetTopSpeed.Subscribe(opts)
+}
+
+// captureGetTopSpeedResult returns a channel that receives the result of every call to GetTopSpeed.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureGetTopSpeedResult(t stubs.TB) <-chan int {
+	sub := stubs.SubscribeMapped(&m.events.GetTopSpeed, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingGetTopSpeedEvent) int {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetTopSpeedCallSpy starts watching for GetTopSpeed spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureGetTopSpeedCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetTopSpeedCalls, timeout)
-		ch <- m.getGetTopSpeedCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureGetTopSpeedCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetTopSpeed, "GetTopSpeed spy call", timeout)
 }
 
-type mockSelfDrivingGetTopSpeedResult struct {
-	Output0 int
+// waitForGetTopSpeedCalls waits until at least n GetTopSpeed spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForGetTopSpeedCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetTopSpeed, n, timeout)
 }
 
-// setGetTopSpeedResponse sets the response for GetTopSpeed
+// waitForGetTopSpeedCallWithin waits until a GetTopSpeed spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForGetTopSpeedCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetTopSpeed, match, timeout)
+}
+
+// eventuallyGetTopSpeedCalls waits until cond holds for the recorded GetTopSpeed spy calls
+func (m *mockSelfDriving) eventuallyGetTopSpeedCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetTopSpeed, cond, timeout)
+}
+
+// consistentlyGetTopSpeedNotCalled fails the test if GetTopSpeed is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyGetTopSpeedNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetTopSpeed, window)
+}
+
+// setGetTopSpeedPanic makes every mocked call to GetTopSpeed panic with v
+func (m *mockSelfDriving) setGetTopSpeedPanic(v any) {
+	m.mocked.GetTopSpeed.SetPanicResponse(v)
+}
+
+// enqueueGetTopSpeedPanic enqueues a response for GetTopSpeed that panics with v
+func (m *mockSelfDriving) enqueueGetTopSpeedPanic(v any) {
+	m.mocked.GetTopSpeed.EnqueuePanic(v)
+}
+
+// injectGetTopSpeedFaults applies a seeded fault policy to GetTopSpeed. GetTopSpeed has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectGetTopSpeedFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetTopSpeedFaults: GetTopSpeed has no error output to inject errors into")
+	}
+	m.mocked.GetTopSpeed.SetFaults(policy)
+}
+
+// clearGetTopSpeedFaults removes the fault policy from GetTopSpeed
+func (m *mockSelfDriving) clearGetTopSpeedFaults() {
+	m.mocked.GetTopSpeed.ClearFaults()
+}
+
+// mockSelfDrivingGetTopSpeedArgs holds the arguments of a call to GetTopSpeed
+type mockSelfDrivingGetTopSpeedArgs struct {
+}
+
+// mockSelfDrivingGetTopSpeedEvent is published to subscribers on every call to GetTopSpeed
+type mockSelfDrivingGetTopSpeedEvent struct {
+	Args   mockSelfDrivingGetTopSpeedArgs
+	Result int
+}
+
+// mockSelfDrivingGetTopSpeedCall is a typed record of a call to GetTopSpeed. Outputs are zero until the call returns.
+type mockSelfDrivingGetTopSpeedCall struct {
+	Output0 int
+}
+
+// getGetTopSpeedTypedCalls returns the spied calls to GetTopSpeed with typed arguments and results
+func (m *mockSelfDriving) getGetTopSpeedTypedCalls() []mockSelfDrivingGetTopSpeedCall {
+	calls := m.mocked.GetTopSpeed.Calls()
+	typed := make([]mockSelfDrivingGetTopSpeedCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Output0, _ = call.Results[0].(int)
+		}
+	}
+	return typed
+}
+
+// setGetTopSpeedResponse sets the response for GetTopSpeed
 func (m *mockSelfDriving) setGetTopSpeedResponse(output0 int) {
 	m.setGetTopSpeedFunc(func() int {
 		return output0
@@ -600,16 +2115,15 @@ func (m *mockSelfDriving) enqueueGetTopSpeedResponse(output0 int) {
 // enqueueGetTopSpeedResponseWithDelay enqueues a static response with delay for GetTopSpeed
 func (m *mockSelfDriving) enqueueGetTopSpeedResponseWithDelay(output0 int, d time.Duration) {
 	m.mocked.GetTopSpeed.EnqueueWithDelay(func() int {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- ParkSelf Mock Helpers --------------------------- */
 
-// enableParkSelfMock turns the spy on
+// enableParkSelfSpy turns the spy on
 func (m *mockSelfDriving) enableParkSelfSpy() {
-	m.mocked.ParkSelf.SpyEnabled = true
+	m.mocked.ParkSelf.EnableSpy()
 }
 
 // getParkSelfCalls returns recorded calls to ParkSelf
@@ -617,49 +2131,112 @@ func (m *mockSelfDriving) getParkSelfCalls() []stubs.MethodCall {
 	return m.mocked.ParkSelf.Calls()
 }
 
-// enableParkSelfMock turns the spy off
+// enableParkSelfSpy turns the spy off
 func (m *mockSelfDriving) disableParkSelfSpy() {
-	m.mocked.ParkSelf.SpyEnabled = false
+	m.mocked.ParkSelf.DisableSpy()
+}
+
+// disableParkSelfArgCloning records arguments and results of ParkSelf by reference instead of deep copying them
+func (m *mockSelfDriving) disableParkSelfArgCloning() {
+	m.mocked.ParkSelf.DisableArgCloning()
+}
+
+// enableParkSelfArgCloning deep copies arguments and results of ParkSelf as they are recorded
+func (m *mockSelfDriving) enableParkSelfArgCloning() {
+	m.mocked.ParkSelf.EnableArgCloning()
 }
 
 // ParkSelf overrides the method to return the mock response
 func (m *mockSelfDriving) ParkSelf() error {
-	m.mocked.ParkSelf.RecordCall()
+	callID := m.mocked.ParkSelf.RecordCall()
+	defer m.mocked.ParkSelf.EndCall(callID)
+	callArgs := mockSelfDrivingParkSelfArgs{}
+	m.hooks.ParkSelf.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.ParkSelf.Enabled {
+	if gateErr := m.mocked.ParkSelf.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.ParkSelf.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.ParkSelf.IsEnabled() {
 		out0 = m.mocked.ParkSelf.NextResponse(func() error {
 			return m.real.ParkSelf()
 		})()
-
 	} else {
 		out0 = m.real.ParkSelf()
-
 	}
 
-	if ch, ok := m.responseChans["ParkSelf"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.ParkSelf.RecordResults(callID, out0)
+	m.hooks.ParkSelf.RunAfter(callArgs, out0)
+	m.events.ParkSelf.Publish(mockSelfDrivingParkSelfEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onParkSelfBefore runs fn on every call to ParkSelf before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onParkSelfBefore(fn func(args mockSelfDrivingParkSelfArgs)) func() {
+	return m.hooks.ParkSelf.Before(fn)
+}
+
+// onParkSelfAfter runs fn once each call to ParkSelf has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onParkSelfAfter(fn func(args mockSelfDrivingParkSelfArgs, result error)) func() {
+	return m.hooks.ParkSelf.After(fn)
+}
 
+// notifyParkSelfCalled returns a channel that is closed when ParkSelf is next called, before it responds
+func (m *mockSelfDriving) notifyParkSelfCalled() <-chan struct{} {
+	return m.hooks.ParkSelf.Notify()
+}
+
+// blockParkSelf holds every later call to ParkSelf mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockParkSelf() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.ParkSelf.SetGate(g)
+	return g
+}
+
+// unblockParkSelf removes the gate set by blockParkSelf, releasing any held calls
+func (m *mockSelfDriving) unblockParkSelf() {
+	m.mocked.ParkSelf.ClearGate()
+}
+
+// assertParkSelfMaxConcurrency fails the test if more than n calls to ParkSelf were ever in flight at once
+func (m *mockSelfDriving) assertParkSelfMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.ParkSelf, n)
+}
+
+// assertParkSelfNeverConcurrent fails the test if any two calls to ParkSelf were in flight at the same time
+func (m *mockSelfDriving) assertParkSelfNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.ParkSelf)
+}
+
+// getParkSelfConcurrencyStats returns the in-flight counts and overlapping calls to ParkSelf
+func (m *mockSelfDriving) getParkSelfConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.ParkSelf.ConcurrencyStats()
 }
 
 // setParkSelfFunc sets the function for ParkSelf
 func (m *mockSelfDriving) setParkSelfFunc(f func() error) {
-	m.mocked.ParkSelf.Fallback = f
+	m.mocked.ParkSelf.SetResponseFunc(f)
 }
 
 // enableParkSelfMock turns the mock on
 func (m *mockSelfDriving) enableParkSelfMock() {
-	m.mocked.ParkSelf.Enabled = true
+	m.mocked.ParkSelf.Enable()
 }
 
 // disableParkSelfMock turns the mock off
 func (m *mockSelfDriving) disableParkSelfMock() {
-	m.mocked.ParkSelf.Enabled = false
+	m.mocked.ParkSelf.Disable()
 }
 
 // enqueueParkSelfResponseFunc enqueues a function response for ParkSelf
@@ -672,25 +2249,98 @@ func (m *mockSelfDriving) enqueueParkSelfResponseFuncWithDelay(f func() error, d
 	m.mocked.ParkSelf.EnqueueWithDelay(f, d)
 }
 
-// captureParkSelfResult sets up a channel to capture ParkSelf results.
-func (m *mockSelfDriving) captureParkSelfResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["ParkSelf"] = ch
-	return ch
+// subscribeParkSelf returns a subscription that receives an event for every call to ParkSelf. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeParkSelf(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingParkSelfEvent] {
+	return m.events.ParkSelf.Subscribe(opts)
+}
+
+// captureParkSelfResult returns a channel that receives the result of every call to ParkSelf.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureParkSelfResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.ParkSelf, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingParkSelfEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureParkSelfCallSpy starts watching for ParkSelf spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureParkSelfCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getParkSelfCalls, timeout)
-		ch <- m.getParkSelfCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureParkSelfCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.ParkSelf, "ParkSelf spy call", timeout)
+}
+
+// waitForParkSelfCalls waits until at least n ParkSelf spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForParkSelfCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.ParkSelf, n, timeout)
+}
+
+// waitForParkSelfCallWithin waits until a ParkSelf spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForParkSelfCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.ParkSelf, match, timeout)
+}
+
+// eventuallyParkSelfCalls waits until cond holds for the recorded ParkSelf spy calls
+func (m *mockSelfDriving) eventuallyParkSelfCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.ParkSelf, cond, timeout)
+}
+
+// consistentlyParkSelfNotCalled fails the test if ParkSelf is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyParkSelfNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.ParkSelf, window)
+}
+
+// setParkSelfPanic makes every mocked call to ParkSelf panic with v
+func (m *mockSelfDriving) setParkSelfPanic(v any) {
+	m.mocked.ParkSelf.SetPanicResponse(v)
+}
+
+// enqueueParkSelfPanic enqueues a response for ParkSelf that panics with v
+func (m *mockSelfDriving) enqueueParkSelfPanic(v any) {
+	m.mocked.ParkSelf.EnqueuePanic(v)
+}
+
+// injectParkSelfFaults applies a seeded fault policy to ParkSelf. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectParkSelfFaults(policy stubs.FaultPolicy) {
+	m.mocked.ParkSelf.SetFaults(policy)
 }
 
-type mockSelfDrivingParkSelfResult struct {
-	Output0 error
+// clearParkSelfFaults removes the fault policy from ParkSelf
+func (m *mockSelfDriving) clearParkSelfFaults() {
+	m.mocked.ParkSelf.ClearFaults()
+}
+
+// mockSelfDrivingParkSelfArgs holds the arguments of a call to ParkSelf
+type mockSelfDrivingParkSelfArgs struct {
+}
+
+// mockSelfDrivingParkSelfEvent is published to subscribers on every call to ParkSelf
+type mockSelfDrivingParkSelfEvent struct {
+	Args   mockSelfDrivingParkSelfArgs
+	Result error
+}
+
+// mockSelfDrivingParkSelfCall is a typed record of a call to ParkSelf. Outputs are zero until the call returns.
+type mockSelfDrivingParkSelfCall struct {
+	Err error
+}
+
+// getParkSelfTypedCalls returns the spied calls to ParkSelf with typed arguments and results
+func (m *mockSelfDriving) getParkSelfTypedCalls() []mockSelfDrivingParkSelfCall {
+	calls := m.mocked.ParkSelf.Calls()
+	typed := make([]mockSelfDrivingParkSelfCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setParkSelfResponse sets the response for ParkSelf
@@ -710,16 +2360,15 @@ func (m *mockSelfDriving) enqueueParkSelfResponse(output0 error) {
 // enqueueParkSelfResponseWithDelay enqueues a static response with delay for ParkSelf
 func (m *mockSelfDriving) enqueueParkSelfResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.ParkSelf.EnqueueWithDelay(func() error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Honk Mock Helpers --------------------------- */
 
-// enableHonkMock turns the spy on
+// enableHonkSpy turns the spy on
 func (m *mockSelfDriving) enableHonkSpy() {
-	m.mocked.Honk.SpyEnabled = true
+	m.mocked.Honk.EnableSpy()
 }
 
 // getHonkCalls returns recorded calls to Honk
@@ -727,39 +2376,110 @@ func (m *mockSelfDriving) getHonkCalls() []stubs.MethodCall {
 	return m.mocked.Honk.Calls()
 }
 
-// enableHonkMock turns the spy off
+// enableHonkSpy turns the spy off
 func (m *mockSelfDriving) disableHonkSpy() {
-	m.mocked.Honk.SpyEnabled = false
+	m.mocked.Honk.DisableSpy()
+}
+
+// disableHonkArgCloning records arguments and results of Honk by reference instead of deep copying them
+func (m *mockSelfDriving) disableHonkArgCloning() {
+	m.mocked.Honk.DisableArgCloning()
+}
+
+// enableHonkArgCloning deep copies arguments and results of Honk as they are recorded
+func (m *mockSelfDriving) enableHonkArgCloning() {
+	m.mocked.Honk.EnableArgCloning()
 }
 
 // Honk overrides the method to return the mock response
 func (m *mockSelfDriving) Honk(times int) {
-	m.mocked.Honk.RecordCall(times)
-	var ()
-
-	if m.mocked.Honk.Enabled {
+	callID := m.mocked.Honk.RecordCall(times)
+	defer m.mocked.Honk.EndCall(callID)
+	callArgs := mockSelfDrivingHonkArgs{Times: times}
+	m.hooks.Honk.RunBefore(callArgs)
+
+	gatePassed := m.mocked.Honk.PassGateOrReport()
+	if gatePassed {
+		m.mocked.Honk.ApplyFault()
+	}
 
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.Honk.IsEnabled() {
+		m.mocked.Honk.NextResponse(func(times int) {
+			m.real.Honk(times)
+		})(times)
 	} else {
-
+		m.real.Honk(times)
 	}
 
-	return
+	m.mocked.Honk.RecordResults(callID)
+	m.hooks.Honk.RunAfter(callArgs, struct{}{})
+	m.events.Honk.Publish(mockSelfDrivingHonkEvent{
+		Args: callArgs,
+	})
+}
+
+// onHonkBefore runs fn on every call to Honk before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onHonkBefore(fn func(args mockSelfDrivingHonkArgs)) func() {
+	return m.hooks.Honk.Before(fn)
+}
+
+// onHonkAfter runs fn once each call to Honk has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onHonkAfter(fn func(args mockSelfDrivingHonkArgs)) func() {
+	return m.hooks.Honk.After(func(args mockSelfDrivingHonkArgs, _ struct{}) { fn(args) })
+}
+
+// notifyHonkCalled returns a channel that is closed when Honk is next called, before it responds
+func (m *mockSelfDriving) notifyHonkCalled() <-chan struct{} {
+	return m.hooks.Honk.Notify()
+}
+
+// blockHonk holds every later call to Honk mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockHonk() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Honk.SetGate(g)
+	return g
+}
+
+// unblockHonk removes the gate set by blockHonk, releasing any held calls
+func (m *mockSelfDriving) unblockHonk() {
+	m.mocked.Honk.ClearGate()
+}
+
+// assertHonkMaxConcurrency fails the test if more than n calls to Honk were ever in flight at once
+func (m *mockSelfDriving) assertHonkMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Honk, n)
+}
+
+// assertHonkNeverConcurrent fails the test if any two calls to Honk were in flight at the same time
+func (m *mockSelfDriving) assertHonkNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Honk)
+}
 
+// getHonkConcurrencyStats returns the in-flight counts and overlapping calls to Honk
+func (m *mockSelfDriving) getHonkConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Honk.ConcurrencyStats()
 }
 
 // setHonkFunc sets the function for Honk
 func (m *mockSelfDriving) setHonkFunc(f func(int)) {
-	m.mocked.Honk.Fallback = f
+	m.mocked.Honk.SetResponseFunc(f)
 }
 
 // enableHonkMock turns the mock on
 func (m *mockSelfDriving) enableHonkMock() {
-	m.mocked.Honk.Enabled = true
+	m.mocked.Honk.Enable()
 }
 
 // disableHonkMock turns the mock off
 func (m *mockSelfDriving) disableHonkMock() {
-	m.mocked.Honk.Enabled = false
+	m.mocked.Honk.Disable()
 }
 
 // enqueueHonkResponseFunc enqueues a function response for Honk
@@ -772,31 +2492,113 @@ func (m *mockSelfDriving) enqueueHonkResponseFuncWithDelay(f func(int), d time.D
 	m.mocked.Honk.EnqueueWithDelay(f, d)
 }
 
-// captureHonkResult sets up a channel to capture Honk results.
-func (m *mockSelfDriving) captureHonkResult() <-chan struct{} {
-	ch := make(chan struct{}, 1)
-	m.responseChans["Honk"] = ch
-	return ch
+// subscribeHonk returns a subscription that receives an event for every call to Honk. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeHonk(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingHonkEvent] {
+	return m.events.Honk.Subscribe(opts)
+}
+
+// captureHonkResult returns a channel that receives the result of every call to Honk.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureHonkResult(t stubs.TB) <-chan struct{} {
+	sub := stubs.SubscribeMapped(&m.events.Honk, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingHonkEvent) struct{} {
+		return struct{}{}
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureHonkCallSpy starts watching for Honk spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureHonkCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getHonkCalls, timeout)
-		ch <- m.getHonkCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureHonkCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Honk, "Honk spy call", timeout)
+}
+
+// waitForHonkCalls waits until at least n Honk spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForHonkCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Honk, n, timeout)
+}
+
+// waitForHonkCallWithin waits until a Honk spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForHonkCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Honk, match, timeout)
+}
+
+// eventuallyHonkCalls waits until cond holds for the recorded Honk spy calls
+func (m *mockSelfDriving) eventuallyHonkCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Honk, cond, timeout)
+}
+
+// waitForHonkCallWithArgs waits until Honk is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForHonkCallWithArgs(t stubs.TB, timeout time.Duration, wantTimes int) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.Honk, timeout, wantTimes)
 }
 
-type mockSelfDrivingHonkResult struct {
+// consistentlyHonkNotCalled fails the test if Honk is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyHonkNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Honk, window)
+}
+
+// setHonkPanic makes every mocked call to Honk panic with v
+func (m *mockSelfDriving) setHonkPanic(v any) {
+	m.mocked.Honk.SetPanicResponse(v)
+}
+
+// enqueueHonkPanic enqueues a response for Honk that panics with v
+func (m *mockSelfDriving) enqueueHonkPanic(v any) {
+	m.mocked.Honk.EnqueuePanic(v)
+}
+
+// injectHonkFaults applies a seeded fault policy to Honk. Honk has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectHonkFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectHonkFaults: Honk has no error output to inject errors into")
+	}
+	m.mocked.Honk.SetFaults(policy)
+}
+
+// clearHonkFaults removes the fault policy from Honk
+func (m *mockSelfDriving) clearHonkFaults() {
+	m.mocked.Honk.ClearFaults()
+}
+
+// mockSelfDrivingHonkArgs holds the arguments of a call to Honk
+type mockSelfDrivingHonkArgs struct {
+	Times int
+}
+
+// mockSelfDrivingHonkEvent is published to subscribers on every call to Honk
+type mockSelfDrivingHonkEvent struct {
+	Args mockSelfDrivingHonkArgs
+}
+
+// mockSelfDrivingHonkCall is a typed record of a call to Honk. Outputs are zero until the call returns.
+type mockSelfDrivingHonkCall struct {
+	Times int
+}
+
+// getHonkTypedCalls returns the spied calls to Honk with typed arguments and results
+func (m *mockSelfDriving) getHonkTypedCalls() []mockSelfDrivingHonkCall {
+	calls := m.mocked.Honk.Calls()
+	typed := make([]mockSelfDrivingHonkCall, len(calls))
+	for i, call := range calls {
+		typed[i].Times, _ = call.Args[0].(int)
+	}
+	return typed
 }
 
 /* -------------------------- LoadCargo Mock Helpers --------------------------- */
 
-// enableLoadCargoMock turns the spy on
+// enableLoadCargoSpy turns the spy on
 func (m *mockSelfDriving) enableLoadCargoSpy() {
-	m.mocked.LoadCargo.SpyEnabled = true
+	m.mocked.LoadCargo.EnableSpy()
 }
 
 // getLoadCargoCalls returns recorded calls to LoadCargo
@@ -804,55 +2606,114 @@ func (m *mockSelfDriving) getLoadCargoCalls() []stubs.MethodCall {
 	return m.mocked.LoadCargo.Calls()
 }
 
-// enableLoadCargoMock turns the spy off
+// enableLoadCargoSpy turns the spy off
 func (m *mockSelfDriving) disableLoadCargoSpy() {
-	m.mocked.LoadCargo.SpyEnabled = false
+	m.mocked.LoadCargo.DisableSpy()
+}
+
+// disableLoadCargoArgCloning records arguments and results of LoadCargo by reference instead of deep copying them
+func (m *mockSelfDriving) disableLoadCargoArgCloning() {
+	m.mocked.LoadCargo.DisableArgCloning()
+}
+
+// enableLoadCargoArgCloning deep copies arguments and results of LoadCargo as they are recorded
+func (m *mockSelfDriving) enableLoadCargoArgCloning() {
+	m.mocked.LoadCargo.EnableArgCloning()
 }
 
 // LoadCargo overrides the method to return the mock response
 func (m *mockSelfDriving) LoadCargo(items []string) (int, error) {
-	m.mocked.LoadCargo.RecordCall(items)
+	callID := m.mocked.LoadCargo.RecordCall(items)
+	defer m.mocked.LoadCargo.EndCall(callID)
+	callArgs := mockSelfDrivingLoadCargoArgs{Items: items}
+	m.hooks.LoadCargo.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 error
-
-		result mockSelfDrivingLoadCargoResult
 	)
 
-	if m.mocked.LoadCargo.Enabled {
+	if gateErr := m.mocked.LoadCargo.PassGate(); gateErr != nil {
+		out1 = gateErr
+	} else if faultErr := m.mocked.LoadCargo.ApplyFault(); faultErr != nil {
+		out1 = faultErr
+	} else if m.mocked.LoadCargo.IsEnabled() {
 		out0, out1 = m.mocked.LoadCargo.NextResponse(func(items []string) (int, error) {
 			return m.real.LoadCargo(items)
 		})(items)
-
 	} else {
 		out0, out1 = m.real.LoadCargo(items)
-
 	}
 
-	result = mockSelfDrivingLoadCargoResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["LoadCargo"]; ok {
-		chTyped := ch.(chan mockSelfDrivingLoadCargoResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.LoadCargo.RecordResults(callID, out0, out1)
+	callResult := mockSelfDrivingLoadCargoResult{Loaded: out0, Err: out1}
+	m.hooks.LoadCargo.RunAfter(callArgs, callResult)
+	m.events.LoadCargo.Publish(mockSelfDrivingLoadCargoEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onLoadCargoBefore runs fn on every call to LoadCargo before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onLoadCargoBefore(fn func(args mockSelfDrivingLoadCargoArgs)) func() {
+	return m.hooks.LoadCargo.Before(fn)
+}
+
+// onLoadCargoAfter runs fn once each call to LoadCargo has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onLoadCargoAfter(fn func(args mockSelfDrivingLoadCargoArgs, result mockSelfDrivingLoadCargoResult)) func() {
+	return m.hooks.LoadCargo.After(fn)
+}
 
+// notifyLoadCargoCalled returns a channel that is closed when LoadCargo is next called, before it responds
+func (m *mockSelfDriving) notifyLoadCargoCalled() <-chan struct{} {
+	return m.hooks.LoadCargo.Notify()
+}
+
+// blockLoadCargo holds every later call to LoadCargo mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockLoadCargo() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.LoadCargo.SetGate(g)
+	return g
+}
+
+// unblockLoadCargo removes the gate set by blockLoadCargo, releasing any held calls
+func (m *mockSelfDriving) unblockLoadCargo() {
+	m.mocked.LoadCargo.ClearGate()
+}
+
+// assertLoadCargoMaxConcurrency fails the test if more than n calls to LoadCargo were ever in flight at once
+func (m *mockSelfDriving) assertLoadCargoMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.LoadCargo, n)
+}
+
+// assertLoadCargoNeverConcurrent fails the test if any two calls to LoadCargo were in flight at the same time
+func (m *mockSelfDriving) assertLoadCargoNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.LoadCargo)
+}
+
+// getLoadCargoConcurrencyStats returns the in-flight counts and overlapping calls to LoadCargo
+func (m *mockSelfDriving) getLoadCargoConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.LoadCargo.ConcurrencyStats()
 }
 
 // setLoadCargoFunc sets the function for LoadCargo
 func (m *mockSelfDriving) setLoadCargoFunc(f func([]string) (int, error)) {
-	m.mocked.LoadCargo.Fallback = f
+	m.mocked.LoadCargo.SetResponseFunc(f)
 }
 
 // enableLoadCargoMock turns the mock on
 func (m *mockSelfDriving) enableLoadCargoMock() {
-	m.mocked.LoadCargo.Enabled = true
+	m.mocked.LoadCargo.Enable()
 }
 
 // disableLoadCargoMock turns the mock off
 func (m *mockSelfDriving) disableLoadCargoMock() {
-	m.mocked.LoadCargo.Enabled = false
+	m.mocked.LoadCargo.Disable()
 }
 
 // enqueueLoadCargoResponseFunc enqueues a function response for LoadCargo
@@ -865,26 +2726,126 @@ func (m *mockSelfDriving) enqueueLoadCargoResponseFuncWithDelay(f func([]string)
 	m.mocked.LoadCargo.EnqueueWithDelay(f, d)
 }
 
-// captureLoadCargoResult sets up a channel to capture LoadCargo results.
-func (m *mockSelfDriving) captureLoadCargoResult() <-chan mockSelfDrivingLoadCargoResult {
-	ch := make(chan mockSelfDrivingLoadCargoResult, 1)
-	m.responseChans["LoadCargo"] = ch
-	return ch
+// subscribeLoadCargo returns a subscription that receives an event for every call to LoadCargo. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeLoadCargo(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingLoadCargoEvent] {
+	return m.events.LoadCargo.Subscribe(opts)
+}
+
+// captureLoadCargoResult returns a channel that receives the result of every call to LoadCargo.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureLoadCargoResult(t stubs.TB) <-chan mockSelfDrivingLoadCargoResult {
+	sub := stubs.SubscribeMapped(&m.events.LoadCargo, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingLoadCargoEvent) mockSelfDrivingLoadCargoResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureLoadCargoCallSpy starts watching for LoadCargo spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureLoadCargoCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getLoadCargoCalls, timeout)
-		ch <- m.getLoadCargoCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureLoadCargoCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.LoadCargo, "LoadCargo spy call", timeout)
+}
+
+// waitForLoadCargoCalls waits until at least n LoadCargo spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForLoadCargoCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.LoadCargo, n, timeout)
+}
+
+// waitForLoadCargoCallWithin waits until a LoadCargo spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForLoadCargoCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.LoadCargo, match, timeout)
 }
 
+// eventuallyLoadCargoCalls waits until cond holds for the recorded LoadCargo spy calls
+func (m *mockSelfDriving) eventuallyLoadCargoCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.LoadCargo, cond, timeout)
+}
+
+// waitForLoadCargoCallWithArgs waits until LoadCargo is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForLoadCargoCallWithArgs(t stubs.TB, timeout time.Duration, wantItems []string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.LoadCargo, timeout, wantItems)
+}
+
+// consistentlyLoadCargoNotCalled fails the test if LoadCargo is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyLoadCargoNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.LoadCargo, window)
+}
+
+// setLoadCargoPanic makes every mocked call to LoadCargo panic with v
+func (m *mockSelfDriving) setLoadCargoPanic(v any) {
+	m.mocked.LoadCargo.SetPanicResponse(v)
+}
+
+// enqueueLoadCargoPanic enqueues a response for LoadCargo that panics with v
+func (m *mockSelfDriving) enqueueLoadCargoPanic(v any) {
+	m.mocked.LoadCargo.EnqueuePanic(v)
+}
+
+// injectLoadCargoFaults applies a seeded fault policy to LoadCargo. Injected errors are returned in output 1.
+func (m *mockSelfDriving) injectLoadCargoFaults(policy stubs.FaultPolicy) {
+	m.mocked.LoadCargo.SetFaults(policy)
+}
+
+// clearLoadCargoFaults removes the fault policy from LoadCargo
+func (m *mockSelfDriving) clearLoadCargoFaults() {
+	m.mocked.LoadCargo.ClearFaults()
+}
+
+// mockSelfDrivingLoadCargoResult holds the outputs of a call to LoadCargo
 type mockSelfDrivingLoadCargoResult struct {
-	Output0 int
-	Output1 error
+	Loaded int
+	Err    error
+}
+
+// Values returns the outputs in the order LoadCargo returns them
+func (r mockSelfDrivingLoadCargoResult) Values() (int, error) {
+	return r.Loaded, r.Err
+}
+
+// ReturnedErr returns the error output
+func (r mockSelfDrivingLoadCargoResult) ReturnedErr() error {
+	return r.Err
+}
+
+// mockSelfDrivingLoadCargoArgs holds the arguments of a call to LoadCargo
+type mockSelfDrivingLoadCargoArgs struct {
+	Items []string
+}
+
+// mockSelfDrivingLoadCargoEvent is published to subscribers on every call to LoadCargo
+type mockSelfDrivingLoadCargoEvent struct {
+	Args   mockSelfDrivingLoadCargoArgs
+	Result mockSelfDrivingLoadCargoResult
+}
+
+// mockSelfDrivingLoadCargoCall is a typed record of a call to LoadCargo. Outputs are zero until the call returns.
+type mockSelfDrivingLoadCargoCall struct {
+	Items  []string
+	Loaded int
+	Err    error
+}
+
+// getLoadCargoTypedCalls returns the spied calls to LoadCargo with typed arguments and results
+func (m *mockSelfDriving) getLoadCargoTypedCalls() []mockSelfDrivingLoadCargoCall {
+	calls := m.mocked.LoadCargo.Calls()
+	typed := make([]mockSelfDrivingLoadCargoCall, len(calls))
+	for i, call := range calls {
+		typed[i].Items, _ = call.Args[0].([]string)
+		if call.Returned {
+			typed[i].Loaded, _ = call.Results[0].(int)
+			typed[i].Err, _ = call.Results[1].(error)
+		}
+	}
+	return typed
 }
 
 // setLoadCargoResponse sets the response for LoadCargo
@@ -904,16 +2865,15 @@ func (m *mockSelfDriving) enqueueLoadCargoResponse(output0 int, output1 error) {
 // enqueueLoadCargoResponseWithDelay enqueues a static response with delay for LoadCargo
 func (m *mockSelfDriving) enqueueLoadCargoResponseWithDelay(output0 int, output1 error, d time.Duration) {
 	m.mocked.LoadCargo.EnqueueWithDelay(func([]string) (int, error) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- GetVehicleStatus Mock Helpers --------------------------- */
 
-// enableGetVehicleStatusMock turns the spy on
+// enableGetVehicleStatusSpy turns the spy on
 func (m *mockSelfDriving) enableGetVehicleStatusSpy() {
-	m.mocked.GetVehicleStatus.SpyEnabled = true
+	m.mocked.GetVehicleStatus.EnableSpy()
 }
 
 // getGetVehicleStatusCalls returns recorded calls to GetVehicleStatus
@@ -921,49 +2881,115 @@ func (m *mockSelfDriving) getGetVehicleStatusCalls() []stubs.MethodCall {
 	return m.mocked.GetVehicleStatus.Calls()
 }
 
-// enableGetVehicleStatusMock turns the spy off
+// enableGetVehicleStatusSpy turns the spy off
 func (m *mockSelfDriving) disableGetVehicleStatusSpy() {
-	m.mocked.GetVehicleStatus.SpyEnabled = false
+	m.mocked.GetVehicleStatus.DisableSpy()
+}
+
+// disableGetVehicleStatusArgCloning records arguments and results of GetVehicleStatus by reference instead of deep copying them
+func (m *mockSelfDriving) disableGetVehicleStatusArgCloning() {
+	m.mocked.GetVehicleStatus.DisableArgCloning()
+}
+
+// enableGetVehicleStatusArgCloning deep copies arguments and results of GetVehicleStatus as they are recorded
+func (m *mockSelfDriving) enableGetVehicleStatusArgCloning() {
+	m.mocked.GetVehicleStatus.EnableArgCloning()
 }
 
 // GetVehicleStatus overrides the method to return the mock response
 func (m *mockSelfDriving) GetVehicleStatus() vehicle.VehicleStatus {
-	m.mocked.GetVehicleStatus.RecordCall()
+	callID := m.mocked.GetVehicleStatus.RecordCall()
+	defer m.mocked.GetVehicleStatus.EndCall(callID)
+	callArgs := mockSelfDrivingGetVehicleStatusArgs{}
+	m.hooks.GetVehicleStatus.RunBefore(callArgs)
 	var (
 		out0 vehicle.VehicleStatus
 	)
 
-	if m.mocked.GetVehicleStatus.Enabled {
+	gatePassed := m.mocked.GetVehicleStatus.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetVehicleStatus.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetVehicleStatus.IsEnabled() {
 		out0 = m.mocked.GetVehicleStatus.NextResponse(func() vehicle.VehicleStatus {
 			return m.real.GetVehicleStatus()
 		})()
-
 	} else {
 		out0 = m.real.GetVehicleStatus()
-
 	}
 
-	if ch, ok := m.responseChans["GetVehicleStatus"]; ok {
-		chTyped := ch.(chan vehicle.VehicleStatus)
-		chTyped <- out0
-	}
+	m.mocked.GetVehicleStatus.RecordResults(callID, out0)
+	m.hooks.GetVehicleStatus.RunAfter(callArgs, out0)
+	m.events.GetVehicleStatus.Publish(mockSelfDrivingGetVehicleStatusEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onGetVehicleStatusBefore runs fn on every call to GetVehicleStatus before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onGetVehicleStatusBefore(fn func(args mockSelfDrivingGetVehicleStatusArgs)) func() {
+	return m.hooks.GetVehicleStatus.Before(fn)
+}
+
+// onGetVehicleStatusAfter runs fn once each call to GetVehicleStatus has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onGetVehicleStatusAfter(fn func(args mockSelfDrivingGetVehicleStatusArgs, result vehicle.VehicleStatus)) func() {
+	return m.hooks.GetVehicleStatus.After(fn)
+}
+
+// notifyGetVehicleStatusCalled returns a channel that is closed when GetVehicleStatus is next called, before it responds
+func (m *mockSelfDriving) notifyGetVehicleStatusCalled() <-chan struct{} {
+	return m.hooks.GetVehicleStatus.Notify()
+}
+
+// blockGetVehicleStatus holds every later call to GetVehicleStatus mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockGetVehicleStatus() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetVehicleStatus.SetGate(g)
+	return g
+}
+
+// unblockGetVehicleStatus removes the gate set by blockGetVehicleStatus, releasing any held calls
+func (m *mockSelfDriving) unblockGetVehicleStatus() {
+	m.mocked.GetVehicleStatus.ClearGate()
+}
+
+// assertGetVehicleStatusMaxConcurrency fails the test if more than n calls to GetVehicleStatus were ever in flight at once
+func (m *mockSelfDriving) assertGetVehicleStatusMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetVehicleStatus, n)
+}
 
+// assertGetVehicleStatusNeverConcurrent fails the test if any two calls to GetVehicleStatus were in flight at the same time
+func (m *mockSelfDriving) assertGetVehicleStatusNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetVehicleStatus)
+}
+
+// getGetVehicleStatusConcurrencyStats returns the in-flight counts and overlapping calls to GetVehicleStatus
+func (m *mockSelfDriving) getGetVehicleStatusConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetVehicleStatus.ConcurrencyStats()
 }
 
 // setGetVehicleStatusFunc sets the function for GetVehicleStatus
 func (m *mockSelfDriving) setGetVehicleStatusFunc(f func() vehicle.VehicleStatus) {
-	m.mocked.GetVehicleStatus.Fallback = f
+	m.mocked.GetVehicleStatus.SetResponseFunc(f)
 }
 
 // enableGetVehicleStatusMock turns the mock on
 func (m *mockSelfDriving) enableGetVehicleStatusMock() {
-	m.mocked.GetVehicleStatus.Enabled = true
+	m.mocked.GetVehicleStatus.Enable()
 }
 
 // disableGetVehicleStatusMock turns the mock off
 func (m *mockSelfDriving) disableGetVehicleStatusMock() {
-	m.mocked.GetVehicleStatus.Enabled = false
+	m.mocked.GetVehicleStatus.Disable()
 }
 
 // enqueueGetVehicleStatusResponseFunc enqueues a function response for GetVehicleStatus
@@ -976,25 +3002,101 @@ func (m *mockSelfDriving) enqueueGetVehicleStatusResponseFuncWithDelay(f func()
 	m.mocked.GetVehicleStatus.EnqueueWithDelay(f, d)
 }
 
-// captureGetVehicleStatusResult sets up a channel to capture GetVehicleStatus results.
-func (m *mockSelfDriving) captureGetVehicleStatusResult() <-chan vehicle.VehicleStatus {
-	ch := make(chan vehicle.VehicleStatus, 1)
-	m.responseChans["GetVehicleStatus"] = ch
-	return ch
+// subscribeGetVehicleStatus returns a subscription that receives an event for every call to GetVehicleStatus. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeGetVehicleStatus(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingGetVehicleStatusEvent] {
+	return m.events.GetVehicleStatus.Subscribe(opts)
+}
+
+// captureGetVehicleStatusResult returns a channel that receives the result of every call to GetVehicleStatus.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureGetVehicleStatusResult(t stubs.TB) <-chan vehicle.VehicleStatus {
+	sub := stubs.SubscribeMapped(&m.events.GetVehicleStatus, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingGetVehicleStatusEvent) vehicle.VehicleStatus {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetVehicleStatusCallSpy starts watching for GetVehicleStatus spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureGetVehicleStatusCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetVehicleStatusCalls, timeout)
-		ch <- m.getGetVehicleStatusCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureGetVehicleStatusCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetVehicleStatus, "GetVehicleStatus spy call", timeout)
 }
 
-type mockSelfDrivingGetVehicleStatusResult struct {
-	Output0 vehicle.VehicleStatus
+// waitForGetVehicleStatusCalls waits until at least n GetVehicleStatus spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForGetVehicleStatusCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetVehicleStatus, n, timeout)
+}
+
+// waitForGetVehicleStatusCallWithin waits until a GetVehicleStatus spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForGetVehicleStatusCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetVehicleStatus, match, timeout)
+}
+
+// eventuallyGetVehicleStatusCalls waits until cond holds for the recorded GetVehicleStatus spy calls
+func (m *mockSelfDriving) eventuallyGetVehicleStatusCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetVehicleStatus, cond, timeout)
+}
+
+// consistentlyGetVehicleStatusNotCalled fails the test if GetVehicleStatus is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyGetVehicleStatusNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetVehicleStatus, window)
+}
+
+// setGetVehicleStatusPanic makes every mocked call to GetVehicleStatus panic with v
+func (m *mockSelfDriving) setGetVehicleStatusPanic(v any) {
+	m.mocked.GetVehicleStatus.SetPanicResponse(v)
+}
+
+// enqueueGetVehicleStatusPanic enqueues a response for GetVehicleStatus that panics with v
+func (m *mockSelfDriving) enqueueGetVehicleStatusPanic(v any) {
+	m.mocked.GetVehicleStatus.EnqueuePanic(v)
+}
+
+// injectGetVehicleStatusFaults applies a seeded fault policy to GetVehicleStatus. GetVehicleStatus has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectGetVehicleStatusFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetVehicleStatusFaults: GetVehicleStatus has no error output to inject errors into")
+	}
+	m.mocked.GetVehicleStatus.SetFaults(policy)
+}
+
+// clearGetVehicleStatusFaults removes the fault policy from GetVehicleStatus
+func (m *mockSelfDriving) clearGetVehicleStatusFaults() {
+	m.mocked.GetVehicleStatus.ClearFaults()
+}
+
+// mockSelfDrivingGetVehicleStatusArgs holds the arguments of a call to GetVehicleStatus
+type mockSelfDrivingGetVehicleStatusArgs struct {
+}
+
+// mockSelfDrivingGetVehicleStatusEvent is published to subscribers on every call to GetVehicleStatus
+type mockSelfDrivingGetVehicleStatusEvent struct {
+	Args   mockSelfDrivingGetVehicleStatusArgs
+	Result vehicle.VehicleStatus
+}
+
+// mockSelfDrivingGetVehicleStatusCall is a typed record of a call to GetVehicleStatus. Outputs are zero until the call returns.
+type mockSelfDrivingGetVehicleStatusCall struct {
+	VehicleStatus vehicle.VehicleStatus
+}
+
+// getGetVehicleStatusTypedCalls returns the spied calls to GetVehicleStatus with typed arguments and results
+func (m *mockSelfDriving) getGetVehicleStatusTypedCalls() []mockSelfDrivingGetVehicleStatusCall {
+	calls := m.mocked.GetVehicleStatus.Calls()
+	typed := make([]mockSelfDrivingGetVehicleStatusCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].VehicleStatus, _ = call.Results[0].(vehicle.VehicleStatus)
+		}
+	}
+	return typed
 }
 
 // setGetVehicleStatusResponse sets the response for GetVehicleStatus
@@ -1014,16 +3116,15 @@ func (m *mockSelfDriving) enqueueGetVehicleStatusResponse(output0 vehicle.Vehicl
 // enqueueGetVehicleStatusResponseWithDelay enqueues a static response with delay for GetVehicleStatus
 func (m *mockSelfDriving) enqueueGetVehicleStatusResponseWithDelay(output0 vehicle.VehicleStatus, d time.Duration) {
 	m.mocked.GetVehicleStatus.EnqueueWithDelay(func() vehicle.VehicleStatus {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- TurnOffAC Mock Helpers --------------------------- */
 
-// enableTurnOffACMock turns the spy on
+// enableTurnOffACSpy turns the spy on
 func (m *mockSelfDriving) enableTurnOffACSpy() {
-	m.mocked.TurnOffAC.SpyEnabled = true
+	m.mocked.TurnOffAC.EnableSpy()
 }
 
 // getTurnOffACCalls returns recorded calls to TurnOffAC
@@ -1031,49 +3132,112 @@ func (m *mockSelfDriving) getTurnOffACCalls() []stubs.MethodCall {
 	return m.mocked.TurnOffAC.Calls()
 }
 
-// enableTurnOffACMock turns the spy off
+// enableTurnOffACSpy turns the spy off
 func (m *mockSelfDriving) disableTurnOffACSpy() {
-	m.mocked.TurnOffAC.SpyEnabled = false
+	m.mocked.TurnOffAC.DisableSpy()
+}
+
+// disableTurnOffACArgCloning records arguments and results of TurnOffAC by reference instead of deep copying them
+func (m *mockSelfDriving) disableTurnOffACArgCloning() {
+	m.mocked.TurnOffAC.DisableArgCloning()
+}
+
+// enableTurnOffACArgCloning deep copies arguments and results of TurnOffAC as they are recorded
+func (m *mockSelfDriving) enableTurnOffACArgCloning() {
+	m.mocked.TurnOffAC.EnableArgCloning()
 }
 
 // TurnOffAC overrides the method to return the mock response
 func (m *mockSelfDriving) TurnOffAC() error {
-	m.mocked.TurnOffAC.RecordCall()
+	callID := m.mocked.TurnOffAC.RecordCall()
+	defer m.mocked.TurnOffAC.EndCall(callID)
+	callArgs := mockSelfDrivingTurnOffACArgs{}
+	m.hooks.TurnOffAC.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.TurnOffAC.Enabled {
+	if gateErr := m.mocked.TurnOffAC.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.TurnOffAC.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.TurnOffAC.IsEnabled() {
 		out0 = m.mocked.TurnOffAC.NextResponse(func() error {
 			return m.real.TurnOffAC()
 		})()
-
 	} else {
 		out0 = m.real.TurnOffAC()
-
 	}
 
-	if ch, ok := m.responseChans["TurnOffAC"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.TurnOffAC.RecordResults(callID, out0)
+	m.hooks.TurnOffAC.RunAfter(callArgs, out0)
+	m.events.TurnOffAC.Publish(mockSelfDrivingTurnOffACEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onTurnOffACBefore runs fn on every call to TurnOffAC before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onTurnOffACBefore(fn func(args mockSelfDrivingTurnOffACArgs)) func() {
+	return m.hooks.TurnOffAC.Before(fn)
+}
+
+// onTurnOffACAfter runs fn once each call to TurnOffAC has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onTurnOffACAfter(fn func(args mockSelfDrivingTurnOffACArgs, result error)) func() {
+	return m.hooks.TurnOffAC.After(fn)
+}
+
+// notifyTurnOffACCalled returns a channel that is closed when TurnOffAC is next called, before it responds
+func (m *mockSelfDriving) notifyTurnOffACCalled() <-chan struct{} {
+	return m.hooks.TurnOffAC.Notify()
+}
+
+// blockTurnOffAC holds every later call to TurnOffAC mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockTurnOffAC() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.TurnOffAC.SetGate(g)
+	return g
+}
+
+// unblockTurnOffAC removes the gate set by blockTurnOffAC, releasing any held calls
+func (m *mockSelfDriving) unblockTurnOffAC() {
+	m.mocked.TurnOffAC.ClearGate()
+}
+
+// assertTurnOffACMaxConcurrency fails the test if more than n calls to TurnOffAC were ever in flight at once
+func (m *mockSelfDriving) assertTurnOffACMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.TurnOffAC, n)
+}
+
+// assertTurnOffACNeverConcurrent fails the test if any two calls to TurnOffAC were in flight at the same time
+func (m *mockSelfDriving) assertTurnOffACNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.TurnOffAC)
+}
 
+// getTurnOffACConcurrencyStats returns the in-flight counts and overlapping calls to TurnOffAC
+func (m *mockSelfDriving) getTurnOffACConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.TurnOffAC.ConcurrencyStats()
 }
 
 // setTurnOffACFunc sets the function for TurnOffAC
 func (m *mockSelfDriving) setTurnOffACFunc(f func() error) {
-	m.mocked.TurnOffAC.Fallback = f
+	m.mocked.TurnOffAC.SetResponseFunc(f)
 }
 
 // enableTurnOffACMock turns the mock on
 func (m *mockSelfDriving) enableTurnOffACMock() {
-	m.mocked.TurnOffAC.Enabled = true
+	m.mocked.TurnOffAC.Enable()
 }
 
 // disableTurnOffACMock turns the mock off
 func (m *mockSelfDriving) disableTurnOffACMock() {
-	m.mocked.TurnOffAC.Enabled = false
+	m.mocked.TurnOffAC.Disable()
 }
 
 // enqueueTurnOffACResponseFunc enqueues a function response for TurnOffAC
@@ -1086,25 +3250,98 @@ func (m *mockSelfDriving) enqueueTurnOffACResponseFuncWithDelay(f func() error,
 	m.mocked.TurnOffAC.EnqueueWithDelay(f, d)
 }
 
-// captureTurnOffACResult sets up a channel to capture TurnOffAC results.
-func (m *mockSelfDriving) captureTurnOffACResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["TurnOffAC"] = ch
-	return ch
+// subscribeTurnOffAC returns a subscription that receives an event for every call to TurnOffAC. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeTurnOffAC(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingTurnOffACEvent] {
+	return m.events.TurnOffAC.Subscribe(opts)
+}
+
+// captureTurnOffACResult returns a channel that receives the result of every call to TurnOffAC.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureTurnOffACResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.TurnOffAC, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingTurnOffACEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureTurnOffACCallSpy starts watching for TurnOffAC spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureTurnOffACCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getTurnOffACCalls, timeout)
-		ch <- m.getTurnOffACCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureTurnOffACCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.TurnOffAC, "TurnOffAC spy call", timeout)
+}
+
+// waitForTurnOffACCalls waits until at least n TurnOffAC spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForTurnOffACCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.TurnOffAC, n, timeout)
+}
+
+// waitForTurnOffACCallWithin waits until a TurnOffAC spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForTurnOffACCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.TurnOffAC, match, timeout)
+}
+
+// eventuallyTurnOffACCalls waits until cond holds for the recorded TurnOffAC spy calls
+func (m *mockSelfDriving) eventuallyTurnOffACCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.TurnOffAC, cond, timeout)
+}
+
+// consistentlyTurnOffACNotCalled fails the test if TurnOffAC is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyTurnOffACNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.TurnOffAC, window)
+}
+
+// setTurnOffACPanic makes every mocked call to TurnOffAC panic with v
+func (m *mockSelfDriving) setTurnOffACPanic(v any) {
+	m.mocked.TurnOffAC.SetPanicResponse(v)
+}
+
+// enqueueTurnOffACPanic enqueues a response for TurnOffAC that panics with v
+func (m *mockSelfDriving) enqueueTurnOffACPanic(v any) {
+	m.mocked.TurnOffAC.EnqueuePanic(v)
+}
+
+// injectTurnOffACFaults applies a seeded fault policy to TurnOffAC. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectTurnOffACFaults(policy stubs.FaultPolicy) {
+	m.mocked.TurnOffAC.SetFaults(policy)
+}
+
+// clearTurnOffACFaults removes the fault policy from TurnOffAC
+func (m *mockSelfDriving) clearTurnOffACFaults() {
+	m.mocked.TurnOffAC.ClearFaults()
+}
+
+// mockSelfDrivingTurnOffACArgs holds the arguments of a call to TurnOffAC
+type mockSelfDrivingTurnOffACArgs struct {
 }
 
-type mockSelfDrivingTurnOffACResult struct {
-	Output0 error
+// mockSelfDrivingTurnOffACEvent is published to subscribers on every call to TurnOffAC
+type mockSelfDrivingTurnOffACEvent struct {
+	Args   mockSelfDrivingTurnOffACArgs
+	Result error
+}
+
+// mockSelfDrivingTurnOffACCall is a typed record of a call to TurnOffAC. Outputs are zero until the call returns.
+type mockSelfDrivingTurnOffACCall struct {
+	Err error
+}
+
+// getTurnOffACTypedCalls returns the spied calls to TurnOffAC with typed arguments and results
+func (m *mockSelfDriving) getTurnOffACTypedCalls() []mockSelfDrivingTurnOffACCall {
+	calls := m.mocked.TurnOffAC.Calls()
+	typed := make([]mockSelfDrivingTurnOffACCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setTurnOffACResponse sets the response for TurnOffAC
@@ -1124,16 +3361,15 @@ func (m *mockSelfDriving) enqueueTurnOffACResponse(output0 error) {
 // enqueueTurnOffACResponseWithDelay enqueues a static response with delay for TurnOffAC
 func (m *mockSelfDriving) enqueueTurnOffACResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.TurnOffAC.EnqueueWithDelay(func() error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- TurnOffMusic Mock Helpers --------------------------- */
 
-// enableTurnOffMusicMock turns the spy on
+// enableTurnOffMusicSpy turns the spy on
 func (m *mockSelfDriving) enableTurnOffMusicSpy() {
-	m.mocked.TurnOffMusic.SpyEnabled = true
+	m.mocked.TurnOffMusic.EnableSpy()
 }
 
 // getTurnOffMusicCalls returns recorded calls to TurnOffMusic
@@ -1141,80 +3377,216 @@ func (m *mockSelfDriving) getTurnOffMusicCalls() []stubs.MethodCall {
 	return m.mocked.TurnOffMusic.Calls()
 }
 
-// enableTurnOffMusicMock turns the spy off
+// enableTurnOffMusicSpy turns the spy off
 func (m *mockSelfDriving) disableTurnOffMusicSpy() {
-	m.mocked.TurnOffMusic.SpyEnabled = false
+	m.mocked.TurnOffMusic.DisableSpy()
+}
+
+// disableTurnOffMusicArgCloning records arguments and results of TurnOffMusic by reference instead of deep copying them
+func (m *mockSelfDriving) disableTurnOffMusicArgCloning() {
+	m.mocked.TurnOffMusic.DisableArgCloning()
+}
+
+// enableTurnOffMusicArgCloning deep copies arguments and results of TurnOffMusic as they are recorded
+func (m *mockSelfDriving) enableTurnOffMusicArgCloning() {
+	m.mocked.TurnOffMusic.EnableArgCloning()
 }
 
 // TurnOffMusic overrides the method to return the mock response
 func (m *mockSelfDriving) TurnOffMusic() error {
-	m.mocked.TurnOffMusic.RecordCall()
+	callID := m.mocked.TurnOffMusic.RecordCall()
+	defer m.mocked.TurnOffMusic.EndCall(callID)
+	callArgs := mockSelfDrivingTurnOffMusicArgs{}
+	m.hooks.TurnOffMusic.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.TurnOffMusic.Enabled {
+	if gateErr := m.mocked.TurnOffMusic.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.TurnOffMusic.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.TurnOffMusic.IsEnabled() {
 		out0 = m.mocked.TurnOffMusic.NextResponse(func() error {
 			return m.real.TurnOffMusic()
 		})()
-
 	} else {
 		out0 = m.real.TurnOffMusic()
-
 	}
 
-	if ch, ok := m.responseChans["TurnOffMusic"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
-	return out0
+	m.mocked.TurnOffMusic.RecordResults(callID, out0)
+	m.hooks.TurnOffMusic.RunAfter(callArgs, out0)
+	m.events.TurnOffMusic.Publish(mockSelfDrivingTurnOffMusicEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
+	return out0
+}
+
+// onTurnOffMusicBefore runs fn on every call to TurnOffMusic before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onTurnOffMusicBefore(fn func(args mockSelfDrivingTurnOffMusicArgs)) func() {
+	return m.hooks.TurnOffMusic.Before(fn)
+}
+
+// onTurnOffMusicAfter runs fn once each call to TurnOffMusic has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onTurnOffMusicAfter(fn func(args mockSelfDrivingTurnOffMusicArgs, result error)) func() {
+	return m.hooks.TurnOffMusic.After(fn)
+}
+
+// notifyTurnOffMusicCalled returns a channel that is closed when TurnOffMusic is next called, before it responds
+func (m *mockSelfDriving) notifyTurnOffMusicCalled() <-chan struct{} {
+	return m.hooks.TurnOffMusic.Notify()
+}
+
+// blockTurnOffMusic holds every later call to TurnOffMusic mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockTurnOffMusic() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.TurnOffMusic.SetGate(g)
+	return g
+}
+
+// unblockTurnOffMusic removes the gate set by blockTurnOffMusic, releasing any held calls
+func (m *mockSelfDriving) unblockTurnOffMusic() {
+	m.mocked.TurnOffMusic.ClearGate()
+}
+
+// assertTurnOffMusicMaxConcurrency fails the test if more than n calls to TurnOffMusic were ever in flight at once
+func (m *mockSelfDriving) assertTurnOffMusicMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.TurnOffMusic, n)
+}
+
+// assertTurnOffMusicNeverConcurrent fails the test if any two calls to TurnOffMusic were in flight at the same time
+func (m *mockSelfDriving) assertTurnOffMusicNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.TurnOffMusic)
+}
+
+// getTurnOffMusicConcurrencyStats returns the in-flight counts and overlapping calls to TurnOffMusic
+func (m *mockSelfDriving) getTurnOffMusicConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.TurnOffMusic.ConcurrencyStats()
+}
+
+// setTurnOffMusicFunc sets the function for TurnOffMusic
+func (m *mockSelfDriving) setTurnOffMusicFunc(f func() error) {
+	m.mocked.TurnOffMusic.SetResponseFunc(f)
+}
+
+// enableTurnOffMusicMock turns the mock on
+func (m *mockSelfDriving) enableTurnOffMusicMock() {
+	m.mocked.TurnOffMusic.Enable()
+}
+
+// disableTurnOffMusicMock turns the mock off
+func (m *mockSelfDriving) disableTurnOffMusicMock() {
+	m.mocked.TurnOffMusic.Disable()
+}
+
+// enqueueTurnOffMusicResponseFunc enqueues a function response for TurnOffMusic
+func (m *mockSelfDriving) enqueueTurnOffMusicResponseFunc(f func() error) {
+	m.mocked.TurnOffMusic.EnqueueWithDelay(f, 0)
+}
+
+// enqueueTurnOffMusicResponseFuncWithDelay enqueues a function response with delay for TurnOffMusic
+func (m *mockSelfDriving) enqueueTurnOffMusicResponseFuncWithDelay(f func() error, d time.Duration) {
+	m.mocked.TurnOffMusic.EnqueueWithDelay(f, d)
+}
+
+// subscribeTurnOffMusic returns a subscription that receives an event for every call to TurnOffMusic. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeTurnOffMusic(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingTurnOffMusicEvent] {
+	return m.events.TurnOffMusic.Subscribe(opts)
+}
+
+// captureTurnOffMusicResult returns a channel that receives the result of every call to TurnOffMusic.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureTurnOffMusicResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.TurnOffMusic, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingTurnOffMusicEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
+}
+
+// captureTurnOffMusicCallSpy starts watching for TurnOffMusic spy calls and sends them into a channel.
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureTurnOffMusicCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.TurnOffMusic, "TurnOffMusic spy call", timeout)
+}
+
+// waitForTurnOffMusicCalls waits until at least n TurnOffMusic spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForTurnOffMusicCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.TurnOffMusic, n, timeout)
+}
+
+// waitForTurnOffMusicCallWithin waits until a TurnOffMusic spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForTurnOffMusicCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.TurnOffMusic, match, timeout)
+}
+
+// eventuallyTurnOffMusicCalls waits until cond holds for the recorded TurnOffMusic spy calls
+func (m *mockSelfDriving) eventuallyTurnOffMusicCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.TurnOffMusic, cond, timeout)
+}
 
+// consistentlyTurnOffMusicNotCalled fails the test if TurnOffMusic is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyTurnOffMusicNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.TurnOffMusic, window)
 }
 
-// setTurnOffMusicFunc sets the function for TurnOffMusic
-func (m *mockSelfDriving) setTurnOffMusicFunc(f func() error) {
-	m.mocked.TurnOffMusic.Fallback = f
+// setTurnOffMusicPanic makes every mocked call to TurnOffMusic panic with v
+func (m *mockSelfDriving) setTurnOffMusicPanic(v any) {
+	m.mocked.TurnOffMusic.SetPanicResponse(v)
 }
 
-// enableTurnOffMusicMock turns the mock on
-func (m *mockSelfDriving) enableTurnOffMusicMock() {
-	m.mocked.TurnOffMusic.Enabled = true
+// enqueueTurnOffMusicPanic enqueues a response for TurnOffMusic that panics with v
+func (m *mockSelfDriving) enqueueTurnOffMusicPanic(v any) {
+	m.mocked.TurnOffMusic.EnqueuePanic(v)
 }
 
-// disableTurnOffMusicMock turns the mock off
-func (m *mockSelfDriving) disableTurnOffMusicMock() {
-	m.mocked.TurnOffMusic.Enabled = false
+// injectTurnOffMusicFaults applies a seeded fault policy to TurnOffMusic. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectTurnOffMusicFaults(policy stubs.FaultPolicy) {
+	m.mocked.TurnOffMusic.SetFaults(policy)
 }
 
-// enqueueTurnOffMusicResponseFunc enqueues a function response for TurnOffMusic
-func (m *mockSelfDriving) enqueueTurnOffMusicResponseFunc(f func() error) {
-	m.mocked.TurnOffMusic.EnqueueWithDelay(f, 0)
+// clearTurnOffMusicFaults removes the fault policy from TurnOffMusic
+func (m *mockSelfDriving) clearTurnOffMusicFaults() {
+	m.mocked.TurnOffMusic.ClearFaults()
 }
 
-// enqueueTurnOffMusicResponseFuncWithDelay enqueues a function response with delay for TurnOffMusic
-func (m *mockSelfDriving) enqueueTurnOffMusicResponseFuncWithDelay(f func() error, d time.Duration) {
-	m.mocked.TurnOffMusic.EnqueueWithDelay(f, d)
+// mockSelfDrivingTurnOffMusicArgs holds the arguments of a call to TurnOffMusic
+type mockSelfDrivingTurnOffMusicArgs struct {
 }
 
-// captureTurnOffMusicResult sets up a channel to capture TurnOffMusic results.
-func (m *mockSelfDriving) captureTurnOffMusicResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["TurnOffMusic"] = ch
-	return ch
+// mockSelfDrivingTurnOffMusicEvent is published to subscribers on every call to TurnOffMusic
+type mockSelfDrivingTurnOffMusicEvent struct {
+	Args   mockSelfDrivingTurnOffMusicArgs
+	Result error
 }
 
-// captureTurnOffMusicCallSpy starts watching for TurnOffMusic spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureTurnOffMusicCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getTurnOffMusicCalls, timeout)
-		ch <- m.getTurnOffMusicCalls()
-	}()
-	return ch
+// mockSelfDrivingTurnOffMusicCall is a typed record of a call to TurnOffMusic. Outputs are zero until the call returns.
+type mockSelfDrivingTurnOffMusicCall struct {
+	Err error
 }
 
-type mockSelfDrivingTurnOffMusicResult struct {
-	Output0 error
+// getTurnOffMusicTypedCalls returns the spied calls to TurnOffMusic with typed arguments and results
+func (m *mockSelfDriving) getTurnOffMusicTypedCalls() []mockSelfDrivingTurnOffMusicCall {
+	calls := m.mocked.TurnOffMusic.Calls()
+	typed := make([]mockSelfDrivingTurnOffMusicCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setTurnOffMusicResponse sets the response for TurnOffMusic
@@ -1234,16 +3606,15 @@ func (m *mockSelfDriving) enqueueTurnOffMusicResponse(output0 error) {
 // enqueueTurnOffMusicResponseWithDelay enqueues a static response with delay for TurnOffMusic
 func (m *mockSelfDriving) enqueueTurnOffMusicResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.TurnOffMusic.EnqueueWithDelay(func() error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- CloseWindows Mock Helpers --------------------------- */
 
-// enableCloseWindowsMock turns the spy on
+// enableCloseWindowsSpy turns the spy on
 func (m *mockSelfDriving) enableCloseWindowsSpy() {
-	m.mocked.CloseWindows.SpyEnabled = true
+	m.mocked.CloseWindows.EnableSpy()
 }
 
 // getCloseWindowsCalls returns recorded calls to CloseWindows
@@ -1251,49 +3622,112 @@ func (m *mockSelfDriving) getCloseWindowsCalls() []stubs.MethodCall {
 	return m.mocked.CloseWindows.Calls()
 }
 
-// enableCloseWindowsMock turns the spy off
+// enableCloseWindowsSpy turns the spy off
 func (m *mockSelfDriving) disableCloseWindowsSpy() {
-	m.mocked.CloseWindows.SpyEnabled = false
+	m.mocked.CloseWindows.DisableSpy()
+}
+
+// disableCloseWindowsArgCloning records arguments and results of CloseWindows by reference instead of deep copying them
+func (m *mockSelfDriving) disableCloseWindowsArgCloning() {
+	m.mocked.CloseWindows.DisableArgCloning()
+}
+
+// enableCloseWindowsArgCloning deep copies arguments and results of CloseWindows as they are recorded
+func (m *mockSelfDriving) enableCloseWindowsArgCloning() {
+	m.mocked.CloseWindows.EnableArgCloning()
 }
 
 // CloseWindows overrides the method to return the mock response
 func (m *mockSelfDriving) CloseWindows() error {
-	m.mocked.CloseWindows.RecordCall()
+	callID := m.mocked.CloseWindows.RecordCall()
+	defer m.mocked.CloseWindows.EndCall(callID)
+	callArgs := mockSelfDrivingCloseWindowsArgs{}
+	m.hooks.CloseWindows.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.CloseWindows.Enabled {
+	if gateErr := m.mocked.CloseWindows.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.CloseWindows.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.CloseWindows.IsEnabled() {
 		out0 = m.mocked.CloseWindows.NextResponse(func() error {
 			return m.real.CloseWindows()
 		})()
-
 	} else {
 		out0 = m.real.CloseWindows()
-
 	}
 
-	if ch, ok := m.responseChans["CloseWindows"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.CloseWindows.RecordResults(callID, out0)
+	m.hooks.CloseWindows.RunAfter(callArgs, out0)
+	m.events.CloseWindows.Publish(mockSelfDrivingCloseWindowsEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onCloseWindowsBefore runs fn on every call to CloseWindows before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onCloseWindowsBefore(fn func(args mockSelfDrivingCloseWindowsArgs)) func() {
+	return m.hooks.CloseWindows.Before(fn)
+}
+
+// onCloseWindowsAfter runs fn once each call to CloseWindows has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onCloseWindowsAfter(fn func(args mockSelfDrivingCloseWindowsArgs, result error)) func() {
+	return m.hooks.CloseWindows.After(fn)
+}
+
+// notifyCloseWindowsCalled returns a channel that is closed when CloseWindows is next called, before it responds
+func (m *mockSelfDriving) notifyCloseWindowsCalled() <-chan struct{} {
+	return m.hooks.CloseWindows.Notify()
+}
 
+// blockCloseWindows holds every later call to CloseWindows mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockCloseWindows() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.CloseWindows.SetGate(g)
+	return g
+}
+
+// unblockCloseWindows removes the gate set by blockCloseWindows, releasing any held calls
+func (m *mockSelfDriving) unblockCloseWindows() {
+	m.mocked.CloseWindows.ClearGate()
+}
+
+// assertCloseWindowsMaxConcurrency fails the test if more than n calls to CloseWindows were ever in flight at once
+func (m *mockSelfDriving) assertCloseWindowsMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.CloseWindows, n)
+}
+
+// assertCloseWindowsNeverConcurrent fails the test if any two calls to CloseWindows were in flight at the same time
+func (m *mockSelfDriving) assertCloseWindowsNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.CloseWindows)
+}
+
+// getCloseWindowsConcurrencyStats returns the in-flight counts and overlapping calls to CloseWindows
+func (m *mockSelfDriving) getCloseWindowsConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.CloseWindows.ConcurrencyStats()
 }
 
 // setCloseWindowsFunc sets the function for CloseWindows
 func (m *mockSelfDriving) setCloseWindowsFunc(f func() error) {
-	m.mocked.CloseWindows.Fallback = f
+	m.mocked.CloseWindows.SetResponseFunc(f)
 }
 
 // enableCloseWindowsMock turns the mock on
 func (m *mockSelfDriving) enableCloseWindowsMock() {
-	m.mocked.CloseWindows.Enabled = true
+	m.mocked.CloseWindows.Enable()
 }
 
 // disableCloseWindowsMock turns the mock off
 func (m *mockSelfDriving) disableCloseWindowsMock() {
-	m.mocked.CloseWindows.Enabled = false
+	m.mocked.CloseWindows.Disable()
 }
 
 // enqueueCloseWindowsResponseFunc enqueues a function response for CloseWindows
@@ -1306,25 +3740,98 @@ func (m *mockSelfDriving) enqueueCloseWindowsResponseFuncWithDelay(f func() erro
 	m.mocked.CloseWindows.EnqueueWithDelay(f, d)
 }
 
-// captureCloseWindowsResult sets up a channel to capture CloseWindows results.
-func (m *mockSelfDriving) captureCloseWindowsResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["CloseWindows"] = ch
-	return ch
+// subscribeCloseWindows returns a subscription that receives an event for every call to CloseWindows. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeCloseWindows(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingCloseWindowsEvent] {
+	return m.events.CloseWindows.Subscribe(opts)
+}
+
+// captureCloseWindowsResult returns a channel that receives the result of every call to CloseWindows.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureCloseWindowsResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.CloseWindows, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingCloseWindowsEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureCloseWindowsCallSpy starts watching for CloseWindows spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureCloseWindowsCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getCloseWindowsCalls, timeout)
-		ch <- m.getCloseWindowsCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureCloseWindowsCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.CloseWindows, "CloseWindows spy call", timeout)
+}
+
+// waitForCloseWindowsCalls waits until at least n CloseWindows spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForCloseWindowsCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.CloseWindows, n, timeout)
+}
+
+// waitForCloseWindowsCallWithin waits until a CloseWindows spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForCloseWindowsCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.CloseWindows, match, timeout)
+}
+
+// eventuallyCloseWindowsCalls waits until cond holds for the recorded CloseWindows spy calls
+func (m *mockSelfDriving) eventuallyCloseWindowsCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.CloseWindows, cond, timeout)
+}
+
+// consistentlyCloseWindowsNotCalled fails the test if CloseWindows is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyCloseWindowsNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.CloseWindows, window)
+}
+
+// setCloseWindowsPanic makes every mocked call to CloseWindows panic with v
+func (m *mockSelfDriving) setCloseWindowsPanic(v any) {
+	m.mocked.CloseWindows.SetPanicResponse(v)
+}
+
+// enqueueCloseWindowsPanic enqueues a response for CloseWindows that panics with v
+func (m *mockSelfDriving) enqueueCloseWindowsPanic(v any) {
+	m.mocked.CloseWindows.EnqueuePanic(v)
+}
+
+// injectCloseWindowsFaults applies a seeded fault policy to CloseWindows. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectCloseWindowsFaults(policy stubs.FaultPolicy) {
+	m.mocked.CloseWindows.SetFaults(policy)
+}
+
+// clearCloseWindowsFaults removes the fault policy from CloseWindows
+func (m *mockSelfDriving) clearCloseWindowsFaults() {
+	m.mocked.CloseWindows.ClearFaults()
+}
+
+// mockSelfDrivingCloseWindowsArgs holds the arguments of a call to CloseWindows
+type mockSelfDrivingCloseWindowsArgs struct {
+}
+
+// mockSelfDrivingCloseWindowsEvent is published to subscribers on every call to CloseWindows
+type mockSelfDrivingCloseWindowsEvent struct {
+	Args   mockSelfDrivingCloseWindowsArgs
+	Result error
+}
+
+// mockSelfDrivingCloseWindowsCall is a typed record of a call to CloseWindows. Outputs are zero until the call returns.
+type mockSelfDrivingCloseWindowsCall struct {
+	Err error
 }
 
-type mockSelfDrivingCloseWindowsResult struct {
-	Output0 error
+// getCloseWindowsTypedCalls returns the spied calls to CloseWindows with typed arguments and results
+func (m *mockSelfDriving) getCloseWindowsTypedCalls() []mockSelfDrivingCloseWindowsCall {
+	calls := m.mocked.CloseWindows.Calls()
+	typed := make([]mockSelfDrivingCloseWindowsCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setCloseWindowsResponse sets the response for CloseWindows
@@ -1344,16 +3851,15 @@ func (m *mockSelfDriving) enqueueCloseWindowsResponse(output0 error) {
 // enqueueCloseWindowsResponseWithDelay enqueues a static response with delay for CloseWindows
 func (m *mockSelfDriving) enqueueCloseWindowsResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.CloseWindows.EnqueueWithDelay(func() error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Reverse Mock Helpers --------------------------- */
 
-// enableReverseMock turns the spy on
+// enableReverseSpy turns the spy on
 func (m *mockSelfDriving) enableReverseSpy() {
-	m.mocked.Reverse.SpyEnabled = true
+	m.mocked.Reverse.EnableSpy()
 }
 
 // getReverseCalls returns recorded calls to Reverse
@@ -1361,55 +3867,114 @@ func (m *mockSelfDriving) getReverseCalls() []stubs.MethodCall {
 	return m.mocked.Reverse.Calls()
 }
 
-// enableReverseMock turns the spy off
+// enableReverseSpy turns the spy off
 func (m *mockSelfDriving) disableReverseSpy() {
-	m.mocked.Reverse.SpyEnabled = false
+	m.mocked.Reverse.DisableSpy()
+}
+
+// disableReverseArgCloning records arguments and results of Reverse by reference instead of deep copying them
+func (m *mockSelfDriving) disableReverseArgCloning() {
+	m.mocked.Reverse.DisableArgCloning()
+}
+
+// enableReverseArgCloning deep copies arguments and results of Reverse as they are recorded
+func (m *mockSelfDriving) enableReverseArgCloning() {
+	m.mocked.Reverse.EnableArgCloning()
 }
 
 // Reverse overrides the method to return the mock response
 func (m *mockSelfDriving) Reverse() (string, error) {
-	m.mocked.Reverse.RecordCall()
+	callID := m.mocked.Reverse.RecordCall()
+	defer m.mocked.Reverse.EndCall(callID)
+	callArgs := mockSelfDrivingReverseArgs{}
+	m.hooks.Reverse.RunBefore(callArgs)
 	var (
 		out0 string
 		out1 error
-
-		result mockSelfDrivingReverseResult
 	)
 
-	if m.mocked.Reverse.Enabled {
+	if gateErr := m.mocked.Reverse.PassGate(); gateErr != nil {
+		out1 = gateErr
+	} else if faultErr := m.mocked.Reverse.ApplyFault(); faultErr != nil {
+		out1 = faultErr
+	} else if m.mocked.Reverse.IsEnabled() {
 		out0, out1 = m.mocked.Reverse.NextResponse(func() (string, error) {
 			return m.real.Reverse()
 		})()
-
 	} else {
 		out0, out1 = m.real.Reverse()
-
 	}
 
-	result = mockSelfDrivingReverseResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["Reverse"]; ok {
-		chTyped := ch.(chan mockSelfDrivingReverseResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.Reverse.RecordResults(callID, out0, out1)
+	callResult := mockSelfDrivingReverseResult{Location: out0, Err: out1}
+	m.hooks.Reverse.RunAfter(callArgs, callResult)
+	m.events.Reverse.Publish(mockSelfDrivingReverseEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onReverseBefore runs fn on every call to Reverse before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onReverseBefore(fn func(args mockSelfDrivingReverseArgs)) func() {
+	return m.hooks.Reverse.Before(fn)
+}
+
+// onReverseAfter runs fn once each call to Reverse has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onReverseAfter(fn func(args mockSelfDrivingReverseArgs, result mockSelfDrivingReverseResult)) func() {
+	return m.hooks.Reverse.After(fn)
+}
+
+// notifyReverseCalled returns a channel that is closed when Reverse is next called, before it responds
+func (m *mockSelfDriving) notifyReverseCalled() <-chan struct{} {
+	return m.hooks.Reverse.Notify()
+}
+
+// blockReverse holds every later call to Reverse mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockReverse() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Reverse.SetGate(g)
+	return g
+}
+
+// unblockReverse removes the gate set by blockReverse, releasing any held calls
+func (m *mockSelfDriving) unblockReverse() {
+	m.mocked.Reverse.ClearGate()
+}
+
+// assertReverseMaxConcurrency fails the test if more than n calls to Reverse were ever in flight at once
+func (m *mockSelfDriving) assertReverseMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Reverse, n)
+}
+
+// assertReverseNeverConcurrent fails the test if any two calls to Reverse were in flight at the same time
+func (m *mockSelfDriving) assertReverseNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Reverse)
+}
 
+// getReverseConcurrencyStats returns the in-flight counts and overlapping calls to Reverse
+func (m *mockSelfDriving) getReverseConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Reverse.ConcurrencyStats()
 }
 
 // setReverseFunc sets the function for Reverse
 func (m *mockSelfDriving) setReverseFunc(f func() (string, error)) {
-	m.mocked.Reverse.Fallback = f
+	m.mocked.Reverse.SetResponseFunc(f)
 }
 
 // enableReverseMock turns the mock on
 func (m *mockSelfDriving) enableReverseMock() {
-	m.mocked.Reverse.Enabled = true
+	m.mocked.Reverse.Enable()
 }
 
 // disableReverseMock turns the mock off
 func (m *mockSelfDriving) disableReverseMock() {
-	m.mocked.Reverse.Enabled = false
+	m.mocked.Reverse.Disable()
 }
 
 // enqueueReverseResponseFunc enqueues a function response for Reverse
@@ -1422,26 +3987,116 @@ func (m *mockSelfDriving) enqueueReverseResponseFuncWithDelay(f func() (string,
 	m.mocked.Reverse.EnqueueWithDelay(f, d)
 }
 
-// captureReverseResult sets up a channel to capture Reverse results.
-func (m *mockSelfDriving) captureReverseResult() <-chan mockSelfDrivingReverseResult {
-	ch := make(chan mockSelfDrivingReverseResult, 1)
-	m.responseChans["Reverse"] = ch
-	return ch
+// subscribeReverse returns a subscription that receives an event for every call to Reverse. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeReverse(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingReverseEvent] {
+	return m.events.Reverse.Subscribe(opts)
+}
+
+// captureReverseResult returns a channel that receives the result of every call to Reverse.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureReverseResult(t stubs.TB) <-chan mockSelfDrivingReverseResult {
+	sub := stubs.SubscribeMapped(&m.events.Reverse, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingReverseEvent) mockSelfDrivingReverseResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureReverseCallSpy starts watching for Reverse spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureReverseCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getReverseCalls, timeout)
-		ch <- m.getReverseCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureReverseCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Reverse, "Reverse spy call", timeout)
+}
+
+// waitForReverseCalls waits until at least n Reverse spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForReverseCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Reverse, n, timeout)
 }
 
+// waitForReverseCallWithin waits until a Reverse spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForReverseCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Reverse, match, timeout)
+}
+
+// eventuallyReverseCalls waits until cond holds for the recorded Reverse spy calls
+func (m *mockSelfDriving) eventuallyReverseCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Reverse, cond, timeout)
+}
+
+// consistentlyReverseNotCalled fails the test if Reverse is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyReverseNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Reverse, window)
+}
+
+// setReversePanic makes every mocked call to Reverse panic with v
+func (m *mockSelfDriving) setReversePanic(v any) {
+	m.mocked.Reverse.SetPanicResponse(v)
+}
+
+// enqueueReversePanic enqueues a response for Reverse that panics with v
+func (m *mockSelfDriving) enqueueReversePanic(v any) {
+	m.mocked.Reverse.EnqueuePanic(v)
+}
+
+// injectReverseFaults applies a seeded fault policy to Reverse. Injected errors are returned in output 1.
+func (m *mockSelfDriving) injectReverseFaults(policy stubs.FaultPolicy) {
+	m.mocked.Reverse.SetFaults(policy)
+}
+
+// clearReverseFaults removes the fault policy from Reverse
+func (m *mockSelfDriving) clearReverseFaults() {
+	m.mocked.Reverse.ClearFaults()
+}
+
+// mockSelfDrivingReverseResult holds the outputs of a call to Reverse
 type mockSelfDrivingReverseResult struct {
-	Output0 string
-	Output1 error
+	Location string
+	Err      error
+}
+
+// Values returns the outputs in the order Reverse returns them
+func (r mockSelfDrivingReverseResult) Values() (string, error) {
+	return r.Location, r.Err
+}
+
+// ReturnedErr returns the error output
+func (r mockSelfDrivingReverseResult) ReturnedErr() error {
+	return r.Err
+}
+
+// mockSelfDrivingReverseArgs holds the arguments of a call to Reverse
+type mockSelfDrivingReverseArgs struct {
+}
+
+// mockSelfDrivingReverseEvent is published to subscribers on every call to Reverse
+type mockSelfDrivingReverseEvent struct {
+	Args   mockSelfDrivingReverseArgs
+	Result mockSelfDrivingReverseResult
+}
+
+// mockSelfDrivingReverseCall is a typed record of a call to Reverse. Outputs are zero until the call returns.
+type mockSelfDrivingReverseCall struct {
+	Location string
+	Err      error
+}
+
+// getReverseTypedCalls returns the spied calls to Reverse with typed arguments and results
+func (m *mockSelfDriving) getReverseTypedCalls() []mockSelfDrivingReverseCall {
+	calls := m.mocked.Reverse.Calls()
+	typed := make([]mockSelfDrivingReverseCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Location, _ = call.Results[0].(string)
+			typed[i].Err, _ = call.Results[1].(error)
+		}
+	}
+	return typed
 }
 
 // setReverseResponse sets the response for Reverse
@@ -1461,16 +4116,15 @@ func (m *mockSelfDriving) enqueueReverseResponse(output0 string, output1 error)
 // enqueueReverseResponseWithDelay enqueues a static response with delay for Reverse
 func (m *mockSelfDriving) enqueueReverseResponseWithDelay(output0 string, output1 error, d time.Duration) {
 	m.mocked.Reverse.EnqueueWithDelay(func() (string, error) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- IsMoving Mock Helpers --------------------------- */
 
-// enableIsMovingMock turns the spy on
+// enableIsMovingSpy turns the spy on
 func (m *mockSelfDriving) enableIsMovingSpy() {
-	m.mocked.IsMoving.SpyEnabled = true
+	m.mocked.IsMoving.EnableSpy()
 }
 
 // getIsMovingCalls returns recorded calls to IsMoving
@@ -1478,49 +4132,115 @@ func (m *mockSelfDriving) getIsMovingCalls() []stubs.MethodCall {
 	return m.mocked.IsMoving.Calls()
 }
 
-// enableIsMovingMock turns the spy off
+// enableIsMovingSpy turns the spy off
 func (m *mockSelfDriving) disableIsMovingSpy() {
-	m.mocked.IsMoving.SpyEnabled = false
+	m.mocked.IsMoving.DisableSpy()
+}
+
+// disableIsMovingArgCloning records arguments and results of IsMoving by reference instead of deep copying them
+func (m *mockSelfDriving) disableIsMovingArgCloning() {
+	m.mocked.IsMoving.DisableArgCloning()
+}
+
+// enableIsMovingArgCloning deep copies arguments and results of IsMoving as they are recorded
+func (m *mockSelfDriving) enableIsMovingArgCloning() {
+	m.mocked.IsMoving.EnableArgCloning()
 }
 
 // IsMoving overrides the method to return the mock response
 func (m *mockSelfDriving) IsMoving() bool {
-	m.mocked.IsMoving.RecordCall()
+	callID := m.mocked.IsMoving.RecordCall()
+	defer m.mocked.IsMoving.EndCall(callID)
+	callArgs := mockSelfDrivingIsMovingArgs{}
+	m.hooks.IsMoving.RunBefore(callArgs)
 	var (
 		out0 bool
 	)
 
-	if m.mocked.IsMoving.Enabled {
+	gatePassed := m.mocked.IsMoving.PassGateOrReport()
+	if gatePassed {
+		m.mocked.IsMoving.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.IsMoving.IsEnabled() {
 		out0 = m.mocked.IsMoving.NextResponse(func() bool {
 			return m.real.IsMoving()
 		})()
-
 	} else {
 		out0 = m.real.IsMoving()
-
 	}
 
-	if ch, ok := m.responseChans["IsMoving"]; ok {
-		chTyped := ch.(chan bool)
-		chTyped <- out0
-	}
+	m.mocked.IsMoving.RecordResults(callID, out0)
+	m.hooks.IsMoving.RunAfter(callArgs, out0)
+	m.events.IsMoving.Publish(mockSelfDrivingIsMovingEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onIsMovingBefore runs fn on every call to IsMoving before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onIsMovingBefore(fn func(args mockSelfDrivingIsMovingArgs)) func() {
+	return m.hooks.IsMoving.Before(fn)
+}
+
+// onIsMovingAfter runs fn once each call to IsMoving has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onIsMovingAfter(fn func(args mockSelfDrivingIsMovingArgs, result bool)) func() {
+	return m.hooks.IsMoving.After(fn)
+}
+
+// notifyIsMovingCalled returns a channel that is closed when IsMoving is next called, before it responds
+func (m *mockSelfDriving) notifyIsMovingCalled() <-chan struct{} {
+	return m.hooks.IsMoving.Notify()
+}
+
+// blockIsMoving holds every later call to IsMoving mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockIsMoving() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.IsMoving.SetGate(g)
+	return g
+}
+
+// unblockIsMoving removes the gate set by blockIsMoving, releasing any held calls
+func (m *mockSelfDriving) unblockIsMoving() {
+	m.mocked.IsMoving.ClearGate()
+}
+
+// assertIsMovingMaxConcurrency fails the test if more than n calls to IsMoving were ever in flight at once
+func (m *mockSelfDriving) assertIsMovingMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.IsMoving, n)
+}
+
+// assertIsMovingNeverConcurrent fails the test if any two calls to IsMoving were in flight at the same time
+func (m *mockSelfDriving) assertIsMovingNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.IsMoving)
+}
 
+// getIsMovingConcurrencyStats returns the in-flight counts and overlapping calls to IsMoving
+func (m *mockSelfDriving) getIsMovingConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.IsMoving.ConcurrencyStats()
 }
 
 // setIsMovingFunc sets the function for IsMoving
 func (m *mockSelfDriving) setIsMovingFunc(f func() bool) {
-	m.mocked.IsMoving.Fallback = f
+	m.mocked.IsMoving.SetResponseFunc(f)
 }
 
 // enableIsMovingMock turns the mock on
 func (m *mockSelfDriving) enableIsMovingMock() {
-	m.mocked.IsMoving.Enabled = true
+	m.mocked.IsMoving.Enable()
 }
 
 // disableIsMovingMock turns the mock off
 func (m *mockSelfDriving) disableIsMovingMock() {
-	m.mocked.IsMoving.Enabled = false
+	m.mocked.IsMoving.Disable()
 }
 
 // enqueueIsMovingResponseFunc enqueues a function response for IsMoving
@@ -1533,25 +4253,101 @@ func (m *mockSelfDriving) enqueueIsMovingResponseFuncWithDelay(f func() bool, d
 	m.mocked.IsMoving.EnqueueWithDelay(f, d)
 }
 
-// captureIsMovingResult sets up a channel to capture IsMoving results.
-func (m *mockSelfDriving) captureIsMovingResult() <-chan bool {
-	ch := make(chan bool, 1)
-	m.responseChans["IsMoving"] = ch
-	return ch
+// subscribeIsMoving returns a subscription that receives an event for every call to IsMoving. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeIsMoving(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingIsMovingEvent] {
+	return m.events.IsMoving.Subscribe(opts)
+}
+
+// captureIsMovingResult returns a channel that receives the result of every call to IsMoving.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureIsMovingResult(t stubs.TB) <-chan bool {
+	sub := stubs.SubscribeMapped(&m.events.IsMoving, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingIsMovingEvent) bool {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureIsMovingCallSpy starts watching for IsMoving spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureIsMovingCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getIsMovingCalls, timeout)
-		ch <- m.getIsMovingCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureIsMovingCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.IsMoving, "IsMoving spy call", timeout)
+}
+
+// waitForIsMovingCalls waits until at least n IsMoving spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForIsMovingCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.IsMoving, n, timeout)
+}
+
+// waitForIsMovingCallWithin waits until a IsMoving spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForIsMovingCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.IsMoving, match, timeout)
+}
+
+// eventuallyIsMovingCalls waits until cond holds for the recorded IsMoving spy calls
+func (m *mockSelfDriving) eventuallyIsMovingCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.IsMoving, cond, timeout)
+}
+
+// consistentlyIsMovingNotCalled fails the test if IsMoving is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyIsMovingNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.IsMoving, window)
 }
 
-type mockSelfDrivingIsMovingResult struct {
-	Output0 bool
+// setIsMovingPanic makes every mocked call to IsMoving panic with v
+func (m *mockSelfDriving) setIsMovingPanic(v any) {
+	m.mocked.IsMoving.SetPanicResponse(v)
+}
+
+// enqueueIsMovingPanic enqueues a response for IsMoving that panics with v
+func (m *mockSelfDriving) enqueueIsMovingPanic(v any) {
+	m.mocked.IsMoving.EnqueuePanic(v)
+}
+
+// injectIsMovingFaults applies a seeded fault policy to IsMoving. IsMoving has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectIsMovingFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectIsMovingFaults: IsMoving has no error output to inject errors into")
+	}
+	m.mocked.IsMoving.SetFaults(policy)
+}
+
+// clearIsMovingFaults removes the fault policy from IsMoving
+func (m *mockSelfDriving) clearIsMovingFaults() {
+	m.mocked.IsMoving.ClearFaults()
+}
+
+// mockSelfDrivingIsMovingArgs holds the arguments of a call to IsMoving
+type mockSelfDrivingIsMovingArgs struct {
+}
+
+// mockSelfDrivingIsMovingEvent is published to subscribers on every call to IsMoving
+type mockSelfDrivingIsMovingEvent struct {
+	Args   mockSelfDrivingIsMovingArgs
+	Result bool
+}
+
+// mockSelfDrivingIsMovingCall is a typed record of a call to IsMoving. Outputs are zero until the call returns.
+type mockSelfDrivingIsMovingCall struct {
+	IsMoving bool
+}
+
+// getIsMovingTypedCalls returns the spied calls to IsMoving with typed arguments and results
+func (m *mockSelfDriving) getIsMovingTypedCalls() []mockSelfDrivingIsMovingCall {
+	calls := m.mocked.IsMoving.Calls()
+	typed := make([]mockSelfDrivingIsMovingCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].IsMoving, _ = call.Results[0].(bool)
+		}
+	}
+	return typed
 }
 
 // setIsMovingResponse sets the response for IsMoving
@@ -1571,16 +4367,15 @@ func (m *mockSelfDriving) enqueueIsMovingResponse(output0 bool) {
 // enqueueIsMovingResponseWithDelay enqueues a static response with delay for IsMoving
 func (m *mockSelfDriving) enqueueIsMovingResponseWithDelay(output0 bool, d time.Duration) {
 	m.mocked.IsMoving.EnqueueWithDelay(func() bool {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- ChangeGears Mock Helpers --------------------------- */
 
-// enableChangeGearsMock turns the spy on
+// enableChangeGearsSpy turns the spy on
 func (m *mockSelfDriving) enableChangeGearsSpy() {
-	m.mocked.ChangeGears.SpyEnabled = true
+	m.mocked.ChangeGears.EnableSpy()
 }
 
 // getChangeGearsCalls returns recorded calls to ChangeGears
@@ -1588,55 +4383,117 @@ func (m *mockSelfDriving) getChangeGearsCalls() []stubs.MethodCall {
 	return m.mocked.ChangeGears.Calls()
 }
 
-// enableChangeGearsMock turns the spy off
+// enableChangeGearsSpy turns the spy off
 func (m *mockSelfDriving) disableChangeGearsSpy() {
-	m.mocked.ChangeGears.SpyEnabled = false
+	m.mocked.ChangeGears.DisableSpy()
+}
+
+// disableChangeGearsArgCloning records arguments and results of ChangeGears by reference instead of deep copying them
+func (m *mockSelfDriving) disableChangeGearsArgCloning() {
+	m.mocked.ChangeGears.DisableArgCloning()
+}
+
+// enableChangeGearsArgCloning deep copies arguments and results of ChangeGears as they are recorded
+func (m *mockSelfDriving) enableChangeGearsArgCloning() {
+	m.mocked.ChangeGears.EnableArgCloning()
 }
 
 // ChangeGears overrides the method to return the mock response
 func (m *mockSelfDriving) ChangeGears(gear int) (int, int) {
-	m.mocked.ChangeGears.RecordCall(gear)
+	callID := m.mocked.ChangeGears.RecordCall(gear)
+	defer m.mocked.ChangeGears.EndCall(callID)
+	callArgs := mockSelfDrivingChangeGearsArgs{Gear: gear}
+	m.hooks.ChangeGears.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 int
-
-		result mockSelfDrivingChangeGearsResult
 	)
 
-	if m.mocked.ChangeGears.Enabled {
+	gatePassed := m.mocked.ChangeGears.PassGateOrReport()
+	if gatePassed {
+		m.mocked.ChangeGears.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.ChangeGears.IsEnabled() {
 		out0, out1 = m.mocked.ChangeGears.NextResponse(func(gear int) (int, int) {
 			return m.real.ChangeGears(gear)
 		})(gear)
-
 	} else {
 		out0, out1 = m.real.ChangeGears(gear)
-
 	}
 
-	result = mockSelfDrivingChangeGearsResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["ChangeGears"]; ok {
-		chTyped := ch.(chan mockSelfDrivingChangeGearsResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.ChangeGears.RecordResults(callID, out0, out1)
+	callResult := mockSelfDrivingChangeGearsResult{Before: out0, After: out1}
+	m.hooks.ChangeGears.RunAfter(callArgs, callResult)
+	m.events.ChangeGears.Publish(mockSelfDrivingChangeGearsEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onChangeGearsBefore runs fn on every call to ChangeGears before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onChangeGearsBefore(fn func(args mockSelfDrivingChangeGearsArgs)) func() {
+	return m.hooks.ChangeGears.Before(fn)
+}
+
+// onChangeGearsAfter runs fn once each call to ChangeGears has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onChangeGearsAfter(fn func(args mockSelfDrivingChangeGearsArgs, result mockSelfDrivingChangeGearsResult)) func() {
+	return m.hooks.ChangeGears.After(fn)
+}
+
+// notifyChangeGearsCalled returns a channel that is closed when ChangeGears is next called, before it responds
+func (m *mockSelfDriving) notifyChangeGearsCalled() <-chan struct{} {
+	return m.hooks.ChangeGears.Notify()
+}
+
+// blockChangeGears holds every later call to ChangeGears mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockChangeGears() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.ChangeGears.SetGate(g)
+	return g
+}
+
+// unblockChangeGears removes the gate set by blockChangeGears, releasing any held calls
+func (m *mockSelfDriving) unblockChangeGears() {
+	m.mocked.ChangeGears.ClearGate()
+}
+
+// assertChangeGearsMaxConcurrency fails the test if more than n calls to ChangeGears were ever in flight at once
+func (m *mockSelfDriving) assertChangeGearsMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.ChangeGears, n)
+}
+
+// assertChangeGearsNeverConcurrent fails the test if any two calls to ChangeGears were in flight at the same time
+func (m *mockSelfDriving) assertChangeGearsNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.ChangeGears)
+}
 
+// getChangeGearsConcurrencyStats returns the in-flight counts and overlapping calls to ChangeGears
+func (m *mockSelfDriving) getChangeGearsConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.ChangeGears.ConcurrencyStats()
 }
 
 // setChangeGearsFunc sets the function for ChangeGears
 func (m *mockSelfDriving) setChangeGearsFunc(f func(int) (int, int)) {
-	m.mocked.ChangeGears.Fallback = f
+	m.mocked.ChangeGears.SetResponseFunc(f)
 }
 
 // enableChangeGearsMock turns the mock on
 func (m *mockSelfDriving) enableChangeGearsMock() {
-	m.mocked.ChangeGears.Enabled = true
+	m.mocked.ChangeGears.Enable()
 }
 
 // disableChangeGearsMock turns the mock off
 func (m *mockSelfDriving) disableChangeGearsMock() {
-	m.mocked.ChangeGears.Enabled = false
+	m.mocked.ChangeGears.Disable()
 }
 
 // enqueueChangeGearsResponseFunc enqueues a function response for ChangeGears
@@ -1649,26 +4506,124 @@ func (m *mockSelfDriving) enqueueChangeGearsResponseFuncWithDelay(f func(int) (i
 	m.mocked.ChangeGears.EnqueueWithDelay(f, d)
 }
 
-// captureChangeGearsResult sets up a channel to capture ChangeGears results.
-func (m *mockSelfDriving) captureChangeGearsResult() <-chan mockSelfDrivingChangeGearsResult {
-	ch := make(chan mockSelfDrivingChangeGearsResult, 1)
-	m.responseChans["ChangeGears"] = ch
-	return ch
+// subscribeChangeGears returns a subscription that receives an event for every call to ChangeGears. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeChangeGears(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingChangeGearsEvent] {
+	return m.events.ChangeGears.Subscribe(opts)
+}
+
+// captureChangeGearsResult returns a channel that receives the result of every call to ChangeGears.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureChangeGearsResult(t stubs.TB) <-chan mockSelfDrivingChangeGearsResult {
+	sub := stubs.SubscribeMapped(&m.events.ChangeGears, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingChangeGearsEvent) mockSelfDrivingChangeGearsResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureChangeGearsCallSpy starts watching for ChangeGears spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureChangeGearsCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getChangeGearsCalls, timeout)
-		ch <- m.getChangeGearsCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureChangeGearsCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.ChangeGears, "ChangeGears spy call", timeout)
+}
+
+// waitForChangeGearsCalls waits until at least n ChangeGears spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForChangeGearsCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.ChangeGears, n, timeout)
+}
+
+// waitForChangeGearsCallWithin waits until a ChangeGears spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForChangeGearsCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.ChangeGears, match, timeout)
+}
+
+// eventuallyChangeGearsCalls waits until cond holds for the recorded ChangeGears spy calls
+func (m *mockSelfDriving) eventuallyChangeGearsCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.ChangeGears, cond, timeout)
+}
+
+// waitForChangeGearsCallWithArgs waits until ChangeGears is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForChangeGearsCallWithArgs(t stubs.TB, timeout time.Duration, wantGear int) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.ChangeGears, timeout, wantGear)
+}
+
+// consistentlyChangeGearsNotCalled fails the test if ChangeGears is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyChangeGearsNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.ChangeGears, window)
+}
+
+// setChangeGearsPanic makes every mocked call to ChangeGears panic with v
+func (m *mockSelfDriving) setChangeGearsPanic(v any) {
+	m.mocked.ChangeGears.SetPanicResponse(v)
+}
+
+// enqueueChangeGearsPanic enqueues a response for ChangeGears that panics with v
+func (m *mockSelfDriving) enqueueChangeGearsPanic(v any) {
+	m.mocked.ChangeGears.EnqueuePanic(v)
+}
+
+// injectChangeGearsFaults applies a seeded fault policy to ChangeGears. ChangeGears has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectChangeGearsFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectChangeGearsFaults: ChangeGears has no error output to inject errors into")
+	}
+	m.mocked.ChangeGears.SetFaults(policy)
+}
+
+// clearChangeGearsFaults removes the fault policy from ChangeGears
+func (m *mockSelfDriving) clearChangeGearsFaults() {
+	m.mocked.ChangeGears.ClearFaults()
 }
 
+// mockSelfDrivingChangeGearsResult holds the outputs of a call to ChangeGears
 type mockSelfDrivingChangeGearsResult struct {
-	Output0 int
-	Output1 int
+	Before int
+	After  int
+}
+
+// Values returns the outputs in the order ChangeGears returns them
+func (r mockSelfDrivingChangeGearsResult) Values() (int, int) {
+	return r.Before, r.After
+}
+
+// mockSelfDrivingChangeGearsArgs holds the arguments of a call to ChangeGears
+type mockSelfDrivingChangeGearsArgs struct {
+	Gear int
+}
+
+// mockSelfDrivingChangeGearsEvent is published to subscribers on every call to ChangeGears
+type mockSelfDrivingChangeGearsEvent struct {
+	Args   mockSelfDrivingChangeGearsArgs
+	Result mockSelfDrivingChangeGearsResult
+}
+
+// mockSelfDrivingChangeGearsCall is a typed record of a call to ChangeGears. Outputs are zero until the call returns.
+type mockSelfDrivingChangeGearsCall struct {
+	Gear   int
+	Before int
+	After  int
+}
+
+// getChangeGearsTypedCalls returns the spied calls to ChangeGears with typed arguments and results
+func (m *mockSelfDriving) getChangeGearsTypedCalls() []mockSelfDrivingChangeGearsCall {
+	calls := m.mocked.ChangeGears.Calls()
+	typed := make([]mockSelfDrivingChangeGearsCall, len(calls))
+	for i, call := range calls {
+		typed[i].Gear, _ = call.Args[0].(int)
+		if call.Returned {
+			typed[i].Before, _ = call.Results[0].(int)
+			typed[i].After, _ = call.Results[1].(int)
+		}
+	}
+	return typed
 }
 
 // setChangeGearsResponse sets the response for ChangeGears
@@ -1688,16 +4643,15 @@ func (m *mockSelfDriving) enqueueChangeGearsResponse(output0 int, output1 int) {
 // enqueueChangeGearsResponseWithDelay enqueues a static response with delay for ChangeGears
 func (m *mockSelfDriving) enqueueChangeGearsResponseWithDelay(output0 int, output1 int, d time.Duration) {
 	m.mocked.ChangeGears.EnqueueWithDelay(func(int) (int, int) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- Telemetry Mock Helpers --------------------------- */
 
-// enableTelemetryMock turns the spy on
+// enableTelemetrySpy turns the spy on
 func (m *mockSelfDriving) enableTelemetrySpy() {
-	m.mocked.Telemetry.SpyEnabled = true
+	m.mocked.Telemetry.EnableSpy()
 }
 
 // getTelemetryCalls returns recorded calls to Telemetry
@@ -1705,49 +4659,115 @@ func (m *mockSelfDriving) getTelemetryCalls() []stubs.MethodCall {
 	return m.mocked.Telemetry.Calls()
 }
 
-// enableTelemetryMock turns the spy off
+// enableTelemetrySpy turns the spy off
 func (m *mockSelfDriving) disableTelemetrySpy() {
-	m.mocked.Telemetry.SpyEnabled = false
+	m.mocked.Telemetry.DisableSpy()
+}
+
+// disableTelemetryArgCloning records arguments and results of Telemetry by reference instead of deep copying them
+func (m *mockSelfDriving) disableTelemetryArgCloning() {
+	m.mocked.Telemetry.DisableArgCloning()
+}
+
+// enableTelemetryArgCloning deep copies arguments and results of Telemetry as they are recorded
+func (m *mockSelfDriving) enableTelemetryArgCloning() {
+	m.mocked.Telemetry.EnableArgCloning()
 }
 
 // Telemetry overrides the method to return the mock response
 func (m *mockSelfDriving) Telemetry() map[string]float64 {
-	m.mocked.Telemetry.RecordCall()
+	callID := m.mocked.Telemetry.RecordCall()
+	defer m.mocked.Telemetry.EndCall(callID)
+	callArgs := mockSelfDrivingTelemetryArgs{}
+	m.hooks.Telemetry.RunBefore(callArgs)
 	var (
 		out0 map[string]float64
 	)
 
-	if m.mocked.Telemetry.Enabled {
+	gatePassed := m.mocked.Telemetry.PassGateOrReport()
+	if gatePassed {
+		m.mocked.Telemetry.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.Telemetry.IsEnabled() {
 		out0 = m.mocked.Telemetry.NextResponse(func() map[string]float64 {
 			return m.real.Telemetry()
 		})()
+	} else {
+		out0 = m.real.Telemetry()
+	}
+
+	m.mocked.Telemetry.RecordResults(callID, out0)
+	m.hooks.Telemetry.RunAfter(callArgs, out0)
+	m.events.Telemetry.Publish(mockSelfDrivingTelemetryEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
+	return out0
+}
+
+// onTelemetryBefore runs fn on every call to Telemetry before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onTelemetryBefore(fn func(args mockSelfDrivingTelemetryArgs)) func() {
+	return m.hooks.Telemetry.Before(fn)
+}
+
+// onTelemetryAfter runs fn once each call to Telemetry has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onTelemetryAfter(fn func(args mockSelfDrivingTelemetryArgs, result map[string]float64)) func() {
+	return m.hooks.Telemetry.After(fn)
+}
+
+// notifyTelemetryCalled returns a channel that is closed when Telemetry is next called, before it responds
+func (m *mockSelfDriving) notifyTelemetryCalled() <-chan struct{} {
+	return m.hooks.Telemetry.Notify()
+}
 
-	} else {
-		out0 = m.real.Telemetry()
+// blockTelemetry holds every later call to Telemetry mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockTelemetry() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Telemetry.SetGate(g)
+	return g
+}
 
-	}
+// unblockTelemetry removes the gate set by blockTelemetry, releasing any held calls
+func (m *mockSelfDriving) unblockTelemetry() {
+	m.mocked.Telemetry.ClearGate()
+}
 
-	if ch, ok := m.responseChans["Telemetry"]; ok {
-		chTyped := ch.(chan map[string]float64)
-		chTyped <- out0
-	}
-	return out0
+// assertTelemetryMaxConcurrency fails the test if more than n calls to Telemetry were ever in flight at once
+func (m *mockSelfDriving) assertTelemetryMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Telemetry, n)
+}
 
+// assertTelemetryNeverConcurrent fails the test if any two calls to Telemetry were in flight at the same time
+func (m *mockSelfDriving) assertTelemetryNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Telemetry)
+}
+
+// getTelemetryConcurrencyStats returns the in-flight counts and overlapping calls to Telemetry
+func (m *mockSelfDriving) getTelemetryConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Telemetry.ConcurrencyStats()
 }
 
 // setTelemetryFunc sets the function for Telemetry
 func (m *mockSelfDriving) setTelemetryFunc(f func() map[string]float64) {
-	m.mocked.Telemetry.Fallback = f
+	m.mocked.Telemetry.SetResponseFunc(f)
 }
 
 // enableTelemetryMock turns the mock on
 func (m *mockSelfDriving) enableTelemetryMock() {
-	m.mocked.Telemetry.Enabled = true
+	m.mocked.Telemetry.Enable()
 }
 
 // disableTelemetryMock turns the mock off
 func (m *mockSelfDriving) disableTelemetryMock() {
-	m.mocked.Telemetry.Enabled = false
+	m.mocked.Telemetry.Disable()
 }
 
 // enqueueTelemetryResponseFunc enqueues a function response for Telemetry
@@ -1760,25 +4780,101 @@ func (m *mockSelfDriving) enqueueTelemetryResponseFuncWithDelay(f func() map[str
 	m.mocked.Telemetry.EnqueueWithDelay(f, d)
 }
 
-// captureTelemetryResult sets up a channel to capture Telemetry results.
-func (m *mockSelfDriving) captureTelemetryResult() <-chan map[string]float64 {
-	ch := make(chan map[string]float64, 1)
-	m.responseChans["Telemetry"] = ch
-	return ch
+// subscribeTelemetry returns a subscription that receives an event for every call to Telemetry. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeTelemetry(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingTelemetryEvent] {
+	return m.events.Telemetry.Subscribe(opts)
+}
+
+// captureTelemetryResult returns a channel that receives the result of every call to Telemetry.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureTelemetryResult(t stubs.TB) <-chan map[string]float64 {
+	sub := stubs.SubscribeMapped(&m.events.Telemetry, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingTelemetryEvent) map[string]float64 {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureTelemetryCallSpy starts watching for Telemetry spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureTelemetryCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getTelemetryCalls, timeout)
-		ch <- m.getTelemetryCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureTelemetryCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Telemetry, "Telemetry spy call", timeout)
+}
+
+// waitForTelemetryCalls waits until at least n Telemetry spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForTelemetryCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Telemetry, n, timeout)
+}
+
+// waitForTelemetryCallWithin waits until a Telemetry spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForTelemetryCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Telemetry, match, timeout)
+}
+
+// eventuallyTelemetryCalls waits until cond holds for the recorded Telemetry spy calls
+func (m *mockSelfDriving) eventuallyTelemetryCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Telemetry, cond, timeout)
+}
+
+// consistentlyTelemetryNotCalled fails the test if Telemetry is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyTelemetryNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Telemetry, window)
+}
+
+// setTelemetryPanic makes every mocked call to Telemetry panic with v
+func (m *mockSelfDriving) setTelemetryPanic(v any) {
+	m.mocked.Telemetry.SetPanicResponse(v)
+}
+
+// enqueueTelemetryPanic enqueues a response for Telemetry that panics with v
+func (m *mockSelfDriving) enqueueTelemetryPanic(v any) {
+	m.mocked.Telemetry.EnqueuePanic(v)
+}
+
+// injectTelemetryFaults applies a seeded fault policy to Telemetry. Telemetry has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectTelemetryFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectTelemetryFaults: Telemetry has no error output to inject errors into")
+	}
+	m.mocked.Telemetry.SetFaults(policy)
+}
+
+// clearTelemetryFaults removes the fault policy from Telemetry
+func (m *mockSelfDriving) clearTelemetryFaults() {
+	m.mocked.Telemetry.ClearFaults()
+}
+
+// mockSelfDrivingTelemetryArgs holds the arguments of a call to Telemetry
+type mockSelfDrivingTelemetryArgs struct {
 }
 
-type mockSelfDrivingTelemetryResult struct {
-	Output0 map[string]float64
+// mockSelfDrivingTelemetryEvent is published to subscribers on every call to Telemetry
+type mockSelfDrivingTelemetryEvent struct {
+	Args   mockSelfDrivingTelemetryArgs
+	Result map[string]float64
+}
+
+// mockSelfDrivingTelemetryCall is a typed record of a call to Telemetry. Outputs are zero until the call returns.
+type mockSelfDrivingTelemetryCall struct {
+	TelemetryData map[string]float64
+}
+
+// getTelemetryTypedCalls returns the spied calls to Telemetry with typed arguments and results
+func (m *mockSelfDriving) getTelemetryTypedCalls() []mockSelfDrivingTelemetryCall {
+	calls := m.mocked.Telemetry.Calls()
+	typed := make([]mockSelfDrivingTelemetryCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].TelemetryData, _ = call.Results[0].(map[string]float64)
+		}
+	}
+	return typed
 }
 
 // setTelemetryResponse sets the response for Telemetry
@@ -1798,16 +4894,15 @@ func (m *mockSelfDriving) enqueueTelemetryResponse(output0 map[string]float64) {
 // enqueueTelemetryResponseWithDelay enqueues a static response with delay for Telemetry
 func (m *mockSelfDriving) enqueueTelemetryResponseWithDelay(output0 map[string]float64, d time.Duration) {
 	m.mocked.Telemetry.EnqueueWithDelay(func() map[string]float64 {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Accelerate Mock Helpers --------------------------- */
 
-// enableAccelerateMock turns the spy on
+// enableAccelerateSpy turns the spy on
 func (m *mockSelfDriving) enableAccelerateSpy() {
-	m.mocked.Accelerate.SpyEnabled = true
+	m.mocked.Accelerate.EnableSpy()
 }
 
 // getAccelerateCalls returns recorded calls to Accelerate
@@ -1815,21 +4910,37 @@ func (m *mockSelfDriving) getAccelerateCalls() []stubs.MethodCall {
 	return m.mocked.Accelerate.Calls()
 }
 
-// enableAccelerateMock turns the spy off
+// enableAccelerateSpy turns the spy off
 func (m *mockSelfDriving) disableAccelerateSpy() {
-	m.mocked.Accelerate.SpyEnabled = false
+	m.mocked.Accelerate.DisableSpy()
+}
+
+// disableAccelerateArgCloning records arguments and results of Accelerate by reference instead of deep copying them
+func (m *mockSelfDriving) disableAccelerateArgCloning() {
+	m.mocked.Accelerate.DisableArgCloning()
+}
+
+// enableAccelerateArgCloning deep copies arguments and results of Accelerate as they are recorded
+func (m *mockSelfDriving) enableAccelerateArgCloning() {
+	m.mocked.Accelerate.EnableArgCloning()
 }
 
 // Accelerate overrides the method to return the mock response
 func (m *mockSelfDriving) Accelerate(speed int, unit string) (int, error) {
-	m.mocked.Accelerate.RecordCall(speed, unit)
+	callID := m.mocked.Accelerate.RecordCall(speed, unit)
+	defer m.mocked.Accelerate.EndCall(callID)
+	callArgs := mockSelfDrivingAccelerateArgs{Speed: speed, Unit: unit}
+	m.hooks.Accelerate.RunBefore(callArgs)
 	var (
-		out0   int
-		out1   error
-		result mockSelfDrivingAccelerateResult
+		out0 int
+		out1 error
 	)
 
-	if m.mocked.Accelerate.Enabled {
+	if gateErr := m.mocked.Accelerate.PassGate(); gateErr != nil {
+		out1 = gateErr
+	} else if faultErr := m.mocked.Accelerate.ApplyFault(); faultErr != nil {
+		out1 = faultErr
+	} else if m.mocked.Accelerate.IsEnabled() {
 		out0, out1 = m.mocked.Accelerate.NextResponse(func(speed int, unit string) (int, error) {
 			return m.real.Accelerate(speed, unit)
 		})(speed, unit)
@@ -1837,30 +4948,76 @@ func (m *mockSelfDriving) Accelerate(speed int, unit string) (int, error) {
 		out0, out1 = m.real.Accelerate(speed, unit)
 	}
 
-	result = mockSelfDrivingAccelerateResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["Accelerate"]; ok {
-		chTyped := ch.(chan mockSelfDrivingAccelerateResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.Accelerate.RecordResults(callID, out0, out1)
+	callResult := mockSelfDrivingAccelerateResult{NewSpeed: out0, Err: out1}
+	m.hooks.Accelerate.RunAfter(callArgs, callResult)
+	m.events.Accelerate.Publish(mockSelfDrivingAccelerateEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onAccelerateBefore runs fn on every call to Accelerate before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onAccelerateBefore(fn func(args mockSelfDrivingAccelerateArgs)) func() {
+	return m.hooks.Accelerate.Before(fn)
+}
+
+// onAccelerateAfter runs fn once each call to Accelerate has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onAccelerateAfter(fn func(args mockSelfDrivingAccelerateArgs, result mockSelfDrivingAccelerateResult)) func() {
+	return m.hooks.Accelerate.After(fn)
+}
+
+// notifyAccelerateCalled returns a channel that is closed when Accelerate is next called, before it responds
+func (m *mockSelfDriving) notifyAccelerateCalled() <-chan struct{} {
+	return m.hooks.Accelerate.Notify()
+}
+
+// blockAccelerate holds every later call to Accelerate mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockAccelerate() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Accelerate.SetGate(g)
+	return g
+}
+
+// unblockAccelerate removes the gate set by blockAccelerate, releasing any held calls
+func (m *mockSelfDriving) unblockAccelerate() {
+	m.mocked.Accelerate.ClearGate()
+}
+
+// assertAccelerateMaxConcurrency fails the test if more than n calls to Accelerate were ever in flight at once
+func (m *mockSelfDriving) assertAccelerateMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Accelerate, n)
+}
+
+// assertAccelerateNeverConcurrent fails the test if any two calls to Accelerate were in flight at the same time
+func (m *mockSelfDriving) assertAccelerateNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Accelerate)
+}
 
+// getAccelerateConcurrencyStats returns the in-flight counts and overlapping calls to Accelerate
+func (m *mockSelfDriving) getAccelerateConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Accelerate.ConcurrencyStats()
 }
 
 // setAccelerateFunc sets the function for Accelerate
 func (m *mockSelfDriving) setAccelerateFunc(f func(int, string) (int, error)) {
-	m.mocked.Accelerate.Fallback = f
+	m.mocked.Accelerate.SetResponseFunc(f)
 }
 
 // enableAccelerateMock turns the mock on
 func (m *mockSelfDriving) enableAccelerateMock() {
-	m.mocked.Accelerate.Enabled = true
+	m.mocked.Accelerate.Enable()
 }
 
 // disableAccelerateMock turns the mock off
 func (m *mockSelfDriving) disableAccelerateMock() {
-	m.mocked.Accelerate.Enabled = false
+	m.mocked.Accelerate.Disable()
 }
 
 // enqueueAccelerateResponseFunc enqueues a function response for Accelerate
@@ -1873,26 +5030,129 @@ func (m *mockSelfDriving) enqueueAccelerateResponseFuncWithDelay(f func(int, str
 	m.mocked.Accelerate.EnqueueWithDelay(f, d)
 }
 
-// captureAccelerateResult sets up a channel to capture Accelerate results.
-func (m *mockSelfDriving) captureAccelerateResult() <-chan mockSelfDrivingAccelerateResult {
-	ch := make(chan mockSelfDrivingAccelerateResult, 1)
-	m.responseChans["Accelerate"] = ch
-	return ch
+// subscribeAccelerate returns a subscription that receives an event for every call to Accelerate. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeAccelerate(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingAccelerateEvent] {
+	return m.events.Accelerate.Subscribe(opts)
+}
+
+// captureAccelerateResult returns a channel that receives the result of every call to Accelerate.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureAccelerateResult(t stubs.TB) <-chan mockSelfDrivingAccelerateResult {
+	sub := stubs.SubscribeMapped(&m.events.Accelerate, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingAccelerateEvent) mockSelfDrivingAccelerateResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureAccelerateCallSpy starts watching for Accelerate spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureAccelerateCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getAccelerateCalls, timeout)
-		ch <- m.getAccelerateCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureAccelerateCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Accelerate, "Accelerate spy call", timeout)
 }
 
+// waitForAccelerateCalls waits until at least n Accelerate spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForAccelerateCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Accelerate, n, timeout)
+}
+
+// waitForAccelerateCallWithin waits until a Accelerate spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForAccelerateCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Accelerate, match, timeout)
+}
+
+// eventuallyAccelerateCalls waits until cond holds for the recorded Accelerate spy calls
+func (m *mockSelfDriving) eventuallyAccelerateCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Accelerate, cond, timeout)
+}
+
+// waitForAccelerateCallWithArgs waits until Accelerate is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForAccelerateCallWithArgs(t stubs.TB, timeout time.Duration, wantSpeed int, wantUnit string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.Accelerate, timeout, wantSpeed, wantUnit)
+}
+
+// consistentlyAccelerateNotCalled fails the test if Accelerate is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyAccelerateNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Accelerate, window)
+}
+
+// setAcceleratePanic makes every mocked call to Accelerate panic with v
+func (m *mockSelfDriving) setAcceleratePanic(v any) {
+	m.mocked.Accelerate.SetPanicResponse(v)
+}
+
+// enqueueAcceleratePanic enqueues a response for Accelerate that panics with v
+func (m *mockSelfDriving) enqueueAcceleratePanic(v any) {
+	m.mocked.Accelerate.EnqueuePanic(v)
+}
+
+// injectAccelerateFaults applies a seeded fault policy to Accelerate. Injected errors are returned in output 1.
+func (m *mockSelfDriving) injectAccelerateFaults(policy stubs.FaultPolicy) {
+	m.mocked.Accelerate.SetFaults(policy)
+}
+
+// clearAccelerateFaults removes the fault policy from Accelerate
+func (m *mockSelfDriving) clearAccelerateFaults() {
+	m.mocked.Accelerate.ClearFaults()
+}
+
+// mockSelfDrivingAccelerateResult holds the outputs of a call to Accelerate
 type mockSelfDrivingAccelerateResult struct {
-	Output0 int
-	Output1 error
+	NewSpeed int
+	Err      error
+}
+
+// Values returns the outputs in the order Accelerate returns them
+func (r mockSelfDrivingAccelerateResult) Values() (int, error) {
+	return r.NewSpeed, r.Err
+}
+
+// ReturnedErr returns the error output
+func (r mockSelfDrivingAccelerateResult) ReturnedErr() error {
+	return r.Err
+}
+
+// mockSelfDrivingAccelerateArgs holds the arguments of a call to Accelerate
+type mockSelfDrivingAccelerateArgs struct {
+	Speed int
+	Unit  string
+}
+
+// mockSelfDrivingAccelerateEvent is published to subscribers on every call to Accelerate
+type mockSelfDrivingAccelerateEvent struct {
+	Args   mockSelfDrivingAccelerateArgs
+	Result mockSelfDrivingAccelerateResult
+}
+
+// mockSelfDrivingAccelerateCall is a typed record of a call to Accelerate. Outputs are zero until the call returns.
+type mockSelfDrivingAccelerateCall struct {
+	Speed    int
+	Unit     string
+	NewSpeed int
+	Err      error
+}
+
+// getAccelerateTypedCalls returns the spied calls to Accelerate with typed arguments and results
+func (m *mockSelfDriving) getAccelerateTypedCalls() []mockSelfDrivingAccelerateCall {
+	calls := m.mocked.Accelerate.Calls()
+	typed := make([]mockSelfDrivingAccelerateCall, len(calls))
+	for i, call := range calls {
+		typed[i].Speed, _ = call.Args[0].(int)
+		typed[i].Unit, _ = call.Args[1].(string)
+		if call.Returned {
+			typed[i].NewSpeed, _ = call.Results[0].(int)
+			typed[i].Err, _ = call.Results[1].(error)
+		}
+	}
+	return typed
 }
 
 // setAccelerateResponse sets the response for Accelerate
@@ -1912,16 +5172,15 @@ func (m *mockSelfDriving) enqueueAccelerateResponse(output0 int, output1 error)
 // enqueueAccelerateResponseWithDelay enqueues a static response with delay for Accelerate
 func (m *mockSelfDriving) enqueueAccelerateResponseWithDelay(output0 int, output1 error, d time.Duration) {
 	m.mocked.Accelerate.EnqueueWithDelay(func(int, string) (int, error) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- DriveSelf Mock Helpers --------------------------- */
 
-// enableDriveSelfMock turns the spy on
+// enableDriveSelfSpy turns the spy on
 func (m *mockSelfDriving) enableDriveSelfSpy() {
-	m.mocked.DriveSelf.SpyEnabled = true
+	m.mocked.DriveSelf.EnableSpy()
 }
 
 // getDriveSelfCalls returns recorded calls to DriveSelf
@@ -1929,47 +5188,112 @@ func (m *mockSelfDriving) getDriveSelfCalls() []stubs.MethodCall {
 	return m.mocked.DriveSelf.Calls()
 }
 
-// enableDriveSelfMock turns the spy off
+// enableDriveSelfSpy turns the spy off
 func (m *mockSelfDriving) disableDriveSelfSpy() {
-	m.mocked.DriveSelf.SpyEnabled = false
+	m.mocked.DriveSelf.DisableSpy()
+}
+
+// disableDriveSelfArgCloning records arguments and results of DriveSelf by reference instead of deep copying them
+func (m *mockSelfDriving) disableDriveSelfArgCloning() {
+	m.mocked.DriveSelf.DisableArgCloning()
+}
+
+// enableDriveSelfArgCloning deep copies arguments and results of DriveSelf as they are recorded
+func (m *mockSelfDriving) enableDriveSelfArgCloning() {
+	m.mocked.DriveSelf.EnableArgCloning()
 }
 
 // DriveSelf overrides the method to return the mock response
 func (m *mockSelfDriving) DriveSelf(endLocation string) error {
-	m.mocked.DriveSelf.RecordCall(endLocation)
-	var out0 error
+	callID := m.mocked.DriveSelf.RecordCall(endLocation)
+	defer m.mocked.DriveSelf.EndCall(callID)
+	callArgs := mockSelfDrivingDriveSelfArgs{EndLocation: endLocation}
+	m.hooks.DriveSelf.RunBefore(callArgs)
+	var (
+		out0 error
+	)
 
-	if m.mocked.DriveSelf.Enabled {
+	if gateErr := m.mocked.DriveSelf.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.DriveSelf.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.DriveSelf.IsEnabled() {
 		out0 = m.mocked.DriveSelf.NextResponse(func(endLocation string) error {
 			return m.real.DriveSelf(endLocation)
 		})(endLocation)
-
 	} else {
 		out0 = m.real.DriveSelf(endLocation)
-
 	}
 
-	if ch, ok := m.responseChans["DriveSelf"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.DriveSelf.RecordResults(callID, out0)
+	m.hooks.DriveSelf.RunAfter(callArgs, out0)
+	m.events.DriveSelf.Publish(mockSelfDrivingDriveSelfEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onDriveSelfBefore runs fn on every call to DriveSelf before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onDriveSelfBefore(fn func(args mockSelfDrivingDriveSelfArgs)) func() {
+	return m.hooks.DriveSelf.Before(fn)
+}
+
+// onDriveSelfAfter runs fn once each call to DriveSelf has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onDriveSelfAfter(fn func(args mockSelfDrivingDriveSelfArgs, result error)) func() {
+	return m.hooks.DriveSelf.After(fn)
+}
 
+// notifyDriveSelfCalled returns a channel that is closed when DriveSelf is next called, before it responds
+func (m *mockSelfDriving) notifyDriveSelfCalled() <-chan struct{} {
+	return m.hooks.DriveSelf.Notify()
+}
+
+// blockDriveSelf holds every later call to DriveSelf mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockDriveSelf() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.DriveSelf.SetGate(g)
+	return g
+}
+
+// unblockDriveSelf removes the gate set by blockDriveSelf, releasing any held calls
+func (m *mockSelfDriving) unblockDriveSelf() {
+	m.mocked.DriveSelf.ClearGate()
+}
+
+// assertDriveSelfMaxConcurrency fails the test if more than n calls to DriveSelf were ever in flight at once
+func (m *mockSelfDriving) assertDriveSelfMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.DriveSelf, n)
+}
+
+// assertDriveSelfNeverConcurrent fails the test if any two calls to DriveSelf were in flight at the same time
+func (m *mockSelfDriving) assertDriveSelfNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.DriveSelf)
+}
+
+// getDriveSelfConcurrencyStats returns the in-flight counts and overlapping calls to DriveSelf
+func (m *mockSelfDriving) getDriveSelfConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.DriveSelf.ConcurrencyStats()
 }
 
 // setDriveSelfFunc sets the function for DriveSelf
 func (m *mockSelfDriving) setDriveSelfFunc(f func(string) error) {
-	m.mocked.DriveSelf.Fallback = f
+	m.mocked.DriveSelf.SetResponseFunc(f)
 }
 
 // enableDriveSelfMock turns the mock on
 func (m *mockSelfDriving) enableDriveSelfMock() {
-	m.mocked.DriveSelf.Enabled = true
+	m.mocked.DriveSelf.Enable()
 }
 
 // disableDriveSelfMock turns the mock off
 func (m *mockSelfDriving) disableDriveSelfMock() {
-	m.mocked.DriveSelf.Enabled = false
+	m.mocked.DriveSelf.Disable()
 }
 
 // enqueueDriveSelfResponseFunc enqueues a function response for DriveSelf
@@ -1982,25 +5306,108 @@ func (m *mockSelfDriving) enqueueDriveSelfResponseFuncWithDelay(f func(string) e
 	m.mocked.DriveSelf.EnqueueWithDelay(f, d)
 }
 
-// captureDriveSelfResult sets up a channel to capture DriveSelf results.
-func (m *mockSelfDriving) captureDriveSelfResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["DriveSelf"] = ch
-	return ch
+// subscribeDriveSelf returns a subscription that receives an event for every call to DriveSelf. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeDriveSelf(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingDriveSelfEvent] {
+	return m.events.DriveSelf.Subscribe(opts)
+}
+
+// captureDriveSelfResult returns a channel that receives the result of every call to DriveSelf.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureDriveSelfResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.DriveSelf, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingDriveSelfEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureDriveSelfCallSpy starts watching for DriveSelf spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureDriveSelfCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getDriveSelfCalls, timeout)
-		ch <- m.getDriveSelfCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureDriveSelfCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.DriveSelf, "DriveSelf spy call", timeout)
+}
+
+// waitForDriveSelfCalls waits until at least n DriveSelf spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForDriveSelfCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.DriveSelf, n, timeout)
+}
+
+// waitForDriveSelfCallWithin waits until a DriveSelf spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForDriveSelfCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.DriveSelf, match, timeout)
+}
+
+// eventuallyDriveSelfCalls waits until cond holds for the recorded DriveSelf spy calls
+func (m *mockSelfDriving) eventuallyDriveSelfCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.DriveSelf, cond, timeout)
+}
+
+// waitForDriveSelfCallWithArgs waits until DriveSelf is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForDriveSelfCallWithArgs(t stubs.TB, timeout time.Duration, wantEndLocation string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.DriveSelf, timeout, wantEndLocation)
+}
+
+// consistentlyDriveSelfNotCalled fails the test if DriveSelf is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyDriveSelfNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.DriveSelf, window)
+}
+
+// setDriveSelfPanic makes every mocked call to DriveSelf panic with v
+func (m *mockSelfDriving) setDriveSelfPanic(v any) {
+	m.mocked.DriveSelf.SetPanicResponse(v)
+}
+
+// enqueueDriveSelfPanic enqueues a response for DriveSelf that panics with v
+func (m *mockSelfDriving) enqueueDriveSelfPanic(v any) {
+	m.mocked.DriveSelf.EnqueuePanic(v)
+}
+
+// injectDriveSelfFaults applies a seeded fault policy to DriveSelf. Injected errors are returned in output 0.
+func (m *mockSelfDriving) injectDriveSelfFaults(policy stubs.FaultPolicy) {
+	m.mocked.DriveSelf.SetFaults(policy)
+}
+
+// clearDriveSelfFaults removes the fault policy from DriveSelf
+func (m *mockSelfDriving) clearDriveSelfFaults() {
+	m.mocked.DriveSelf.ClearFaults()
+}
+
+// mockSelfDrivingDriveSelfArgs holds the arguments of a call to DriveSelf
+type mockSelfDrivingDriveSelfArgs struct {
+	EndLocation string
+}
+
+// mockSelfDrivingDriveSelfEvent is published to subscribers on every call to DriveSelf
+type mockSelfDrivingDriveSelfEvent struct {
+	Args   mockSelfDrivingDriveSelfArgs
+	Result error
+}
+
+// mockSelfDrivingDriveSelfCall is a typed record of a call to DriveSelf. Outputs are zero until the call returns.
+type mockSelfDrivingDriveSelfCall struct {
+	EndLocation string
+	Err         error
 }
 
-type mockSelfDrivingDriveSelfResult struct {
-	Output0 error
+// getDriveSelfTypedCalls returns the spied calls to DriveSelf with typed arguments and results
+func (m *mockSelfDriving) getDriveSelfTypedCalls() []mockSelfDrivingDriveSelfCall {
+	calls := m.mocked.DriveSelf.Calls()
+	typed := make([]mockSelfDrivingDriveSelfCall, len(calls))
+	for i, call := range calls {
+		typed[i].EndLocation, _ = call.Args[0].(string)
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setDriveSelfResponse sets the response for DriveSelf
@@ -2020,16 +5427,15 @@ func (m *mockSelfDriving) enqueueDriveSelfResponse(output0 error) {
 // enqueueDriveSelfResponseWithDelay enqueues a static response with delay for DriveSelf
 func (m *mockSelfDriving) enqueueDriveSelfResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.DriveSelf.EnqueueWithDelay(func(string) error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Turn Mock Helpers --------------------------- */
 
-// enableTurnMock turns the spy on
+// enableTurnSpy turns the spy on
 func (m *mockSelfDriving) enableTurnSpy() {
-	m.mocked.Turn.SpyEnabled = true
+	m.mocked.Turn.EnableSpy()
 }
 
 // getTurnCalls returns recorded calls to Turn
@@ -2037,49 +5443,115 @@ func (m *mockSelfDriving) getTurnCalls() []stubs.MethodCall {
 	return m.mocked.Turn.Calls()
 }
 
-// enableTurnMock turns the spy off
+// enableTurnSpy turns the spy off
 func (m *mockSelfDriving) disableTurnSpy() {
-	m.mocked.Turn.SpyEnabled = false
+	m.mocked.Turn.DisableSpy()
+}
+
+// disableTurnArgCloning records arguments and results of Turn by reference instead of deep copying them
+func (m *mockSelfDriving) disableTurnArgCloning() {
+	m.mocked.Turn.DisableArgCloning()
+}
+
+// enableTurnArgCloning deep copies arguments and results of Turn as they are recorded
+func (m *mockSelfDriving) enableTurnArgCloning() {
+	m.mocked.Turn.EnableArgCloning()
 }
 
 // Turn overrides the method to return the mock response
 func (m *mockSelfDriving) Turn(dir string) string {
-	m.mocked.Turn.RecordCall(dir)
+	callID := m.mocked.Turn.RecordCall(dir)
+	defer m.mocked.Turn.EndCall(callID)
+	callArgs := mockSelfDrivingTurnArgs{Dir: dir}
+	m.hooks.Turn.RunBefore(callArgs)
 	var (
 		out0 string
 	)
 
-	if m.mocked.Turn.Enabled {
+	gatePassed := m.mocked.Turn.PassGateOrReport()
+	if gatePassed {
+		m.mocked.Turn.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.Turn.IsEnabled() {
 		out0 = m.mocked.Turn.NextResponse(func(dir string) string {
 			return m.real.Turn(dir)
 		})(dir)
-
 	} else {
 		out0 = m.real.Turn(dir)
-
 	}
 
-	if ch, ok := m.responseChans["Turn"]; ok {
-		chTyped := ch.(chan string)
-		chTyped <- out0
-	}
+	m.mocked.Turn.RecordResults(callID, out0)
+	m.hooks.Turn.RunAfter(callArgs, out0)
+	m.events.Turn.Publish(mockSelfDrivingTurnEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onTurnBefore runs fn on every call to Turn before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onTurnBefore(fn func(args mockSelfDrivingTurnArgs)) func() {
+	return m.hooks.Turn.Before(fn)
+}
+
+// onTurnAfter runs fn once each call to Turn has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onTurnAfter(fn func(args mockSelfDrivingTurnArgs, result string)) func() {
+	return m.hooks.Turn.After(fn)
+}
+
+// notifyTurnCalled returns a channel that is closed when Turn is next called, before it responds
+func (m *mockSelfDriving) notifyTurnCalled() <-chan struct{} {
+	return m.hooks.Turn.Notify()
+}
+
+// blockTurn holds every later call to Turn mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockTurn() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Turn.SetGate(g)
+	return g
+}
+
+// unblockTurn removes the gate set by blockTurn, releasing any held calls
+func (m *mockSelfDriving) unblockTurn() {
+	m.mocked.Turn.ClearGate()
+}
+
+// assertTurnMaxConcurrency fails the test if more than n calls to Turn were ever in flight at once
+func (m *mockSelfDriving) assertTurnMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Turn, n)
+}
+
+// assertTurnNeverConcurrent fails the test if any two calls to Turn were in flight at the same time
+func (m *mockSelfDriving) assertTurnNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Turn)
+}
 
+// getTurnConcurrencyStats returns the in-flight counts and overlapping calls to Turn
+func (m *mockSelfDriving) getTurnConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Turn.ConcurrencyStats()
 }
 
 // setTurnFunc sets the function for Turn
 func (m *mockSelfDriving) setTurnFunc(f func(string) string) {
-	m.mocked.Turn.Fallback = f
+	m.mocked.Turn.SetResponseFunc(f)
 }
 
 // enableTurnMock turns the mock on
 func (m *mockSelfDriving) enableTurnMock() {
-	m.mocked.Turn.Enabled = true
+	m.mocked.Turn.Enable()
 }
 
 // disableTurnMock turns the mock off
 func (m *mockSelfDriving) disableTurnMock() {
-	m.mocked.Turn.Enabled = false
+	m.mocked.Turn.Disable()
 }
 
 // enqueueTurnResponseFunc enqueues a function response for Turn
@@ -2092,27 +5564,113 @@ func (m *mockSelfDriving) enqueueTurnResponseFuncWithDelay(f func(string) string
 	m.mocked.Turn.EnqueueWithDelay(f, d)
 }
 
-// captureTurnResult sets up a channel to capture Turn results.
-func (m *mockSelfDriving) captureTurnResult() <-chan string {
-	ch := make(chan string, 1)
-	m.responseChans["Turn"] = ch
-	return ch
+// subscribeTurn returns a subscription that receives an event for every call to Turn. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeTurn(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingTurnEvent] {
+	return m.events.Turn.Subscribe(opts)
+}
+
+// captureTurnResult returns a channel that receives the result of every call to Turn.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureTurnResult(t stubs.TB) <-chan string {
+	sub := stubs.SubscribeMapped(&m.events.Turn, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingTurnEvent) string {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureTurnCallSpy starts watching for Turn spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureTurnCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getTurnCalls, timeout)
-		ch <- m.getTurnCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureTurnCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Turn, "Turn spy call", timeout)
+}
+
+// waitForTurnCalls waits until at least n Turn spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForTurnCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Turn, n, timeout)
+}
+
+// waitForTurnCallWithin waits until a Turn spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForTurnCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Turn, match, timeout)
+}
+
+// eventuallyTurnCalls waits until cond holds for the recorded Turn spy calls
+func (m *mockSelfDriving) eventuallyTurnCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Turn, cond, timeout)
+}
+
+// waitForTurnCallWithArgs waits until Turn is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockSelfDriving) waitForTurnCallWithArgs(t stubs.TB, timeout time.Duration, wantDir string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.Turn, timeout, wantDir)
+}
+
+// consistentlyTurnNotCalled fails the test if Turn is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyTurnNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Turn, window)
+}
+
+// setTurnPanic makes every mocked call to Turn panic with v
+func (m *mockSelfDriving) setTurnPanic(v any) {
+	m.mocked.Turn.SetPanicResponse(v)
+}
+
+// enqueueTurnPanic enqueues a response for Turn that panics with v
+func (m *mockSelfDriving) enqueueTurnPanic(v any) {
+	m.mocked.Turn.EnqueuePanic(v)
 }
 
-type mockSelfDrivingTurnResult struct {
+// injectTurnFaults applies a seeded fault policy to Turn. Turn has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectTurnFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectTurnFaults: Turn has no error output to inject errors into")
+	}
+	m.mocked.Turn.SetFaults(policy)
+}
+
+// clearTurnFaults removes the fault policy from Turn
+func (m *mockSelfDriving) clearTurnFaults() {
+	m.mocked.Turn.ClearFaults()
+}
+
+// mockSelfDrivingTurnArgs holds the arguments of a call to Turn
+type mockSelfDrivingTurnArgs struct {
+	Dir string
+}
+
+// mockSelfDrivingTurnEvent is published to subscribers on every call to Turn
+type mockSelfDrivingTurnEvent struct {
+	Args   mockSelfDrivingTurnArgs
+	Result string
+}
+
+// mockSelfDrivingTurnCall is a typed record of a call to Turn. Outputs are zero until the call returns.
+type mockSelfDrivingTurnCall struct {
+	Dir     string
 	Output0 string
 }
 
+// getTurnTypedCalls returns the spied calls to Turn with typed arguments and results
+func (m *mockSelfDriving) getTurnTypedCalls() []mockSelfDrivingTurnCall {
+	calls := m.mocked.Turn.Calls()
+	typed := make([]mockSelfDrivingTurnCall, len(calls))
+	for i, call := range calls {
+		typed[i].Dir, _ = call.Args[0].(string)
+		if call.Returned {
+			typed[i].Output0, _ = call.Results[0].(string)
+		}
+	}
+	return typed
+}
+
 // setTurnResponse sets the response for Turn
 func (m *mockSelfDriving) setTurnResponse(output0 string) {
 	m.setTurnFunc(func(string) string {
@@ -2130,16 +5688,15 @@ func (m *mockSelfDriving) enqueueTurnResponse(output0 string) {
 // enqueueTurnResponseWithDelay enqueues a static response with delay for Turn
 func (m *mockSelfDriving) enqueueTurnResponseWithDelay(output0 string, d time.Duration) {
 	m.mocked.Turn.EnqueueWithDelay(func(string) string {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- GetPassengers Mock Helpers --------------------------- */
 
-// enableGetPassengersMock turns the spy on
+// enableGetPassengersSpy turns the spy on
 func (m *mockSelfDriving) enableGetPassengersSpy() {
-	m.mocked.GetPassengers.SpyEnabled = true
+	m.mocked.GetPassengers.EnableSpy()
 }
 
 // getGetPassengersCalls returns recorded calls to GetPassengers
@@ -2147,49 +5704,115 @@ func (m *mockSelfDriving) getGetPassengersCalls() []stubs.MethodCall {
 	return m.mocked.GetPassengers.Calls()
 }
 
-// enableGetPassengersMock turns the spy off
+// enableGetPassengersSpy turns the spy off
 func (m *mockSelfDriving) disableGetPassengersSpy() {
-	m.mocked.GetPassengers.SpyEnabled = false
+	m.mocked.GetPassengers.DisableSpy()
+}
+
+// disableGetPassengersArgCloning records arguments and results of GetPassengers by reference instead of deep copying them
+func (m *mockSelfDriving) disableGetPassengersArgCloning() {
+	m.mocked.GetPassengers.DisableArgCloning()
+}
+
+// enableGetPassengersArgCloning deep copies arguments and results of GetPassengers as they are recorded
+func (m *mockSelfDriving) enableGetPassengersArgCloning() {
+	m.mocked.GetPassengers.EnableArgCloning()
 }
 
 // GetPassengers overrides the method to return the mock response
 func (m *mockSelfDriving) GetPassengers() []string {
-	m.mocked.GetPassengers.RecordCall()
+	callID := m.mocked.GetPassengers.RecordCall()
+	defer m.mocked.GetPassengers.EndCall(callID)
+	callArgs := mockSelfDrivingGetPassengersArgs{}
+	m.hooks.GetPassengers.RunBefore(callArgs)
 	var (
 		out0 []string
 	)
 
-	if m.mocked.GetPassengers.Enabled {
+	gatePassed := m.mocked.GetPassengers.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetPassengers.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetPassengers.IsEnabled() {
 		out0 = m.mocked.GetPassengers.NextResponse(func() []string {
 			return m.real.GetPassengers()
 		})()
-
 	} else {
 		out0 = m.real.GetPassengers()
-
 	}
 
-	if ch, ok := m.responseChans["GetPassengers"]; ok {
-		chTyped := ch.(chan []string)
-		chTyped <- out0
-	}
+	m.mocked.GetPassengers.RecordResults(callID, out0)
+	m.hooks.GetPassengers.RunAfter(callArgs, out0)
+	m.events.GetPassengers.Publish(mockSelfDrivingGetPassengersEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onGetPassengersBefore runs fn on every call to GetPassengers before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockSelfDriving) onGetPassengersBefore(fn func(args mockSelfDrivingGetPassengersArgs)) func() {
+	return m.hooks.GetPassengers.Before(fn)
+}
+
+// onGetPassengersAfter runs fn once each call to GetPassengers has its result. The returned func removes the hook.
+func (m *mockSelfDriving) onGetPassengersAfter(fn func(args mockSelfDrivingGetPassengersArgs, result []string)) func() {
+	return m.hooks.GetPassengers.After(fn)
+}
+
+// notifyGetPassengersCalled returns a channel that is closed when GetPassengers is next called, before it responds
+func (m *mockSelfDriving) notifyGetPassengersCalled() <-chan struct{} {
+	return m.hooks.GetPassengers.Notify()
+}
+
+// blockGetPassengers holds every later call to GetPassengers mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockSelfDriving) blockGetPassengers() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetPassengers.SetGate(g)
+	return g
+}
+
+// unblockGetPassengers removes the gate set by blockGetPassengers, releasing any held calls
+func (m *mockSelfDriving) unblockGetPassengers() {
+	m.mocked.GetPassengers.ClearGate()
+}
+
+// assertGetPassengersMaxConcurrency fails the test if more than n calls to GetPassengers were ever in flight at once
+func (m *mockSelfDriving) assertGetPassengersMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetPassengers, n)
+}
+
+// assertGetPassengersNeverConcurrent fails the test if any two calls to GetPassengers were in flight at the same time
+func (m *mockSelfDriving) assertGetPassengersNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetPassengers)
+}
 
+// getGetPassengersConcurrencyStats returns the in-flight counts and overlapping calls to GetPassengers
+func (m *mockSelfDriving) getGetPassengersConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetPassengers.ConcurrencyStats()
 }
 
 // setGetPassengersFunc sets the function for GetPassengers
 func (m *mockSelfDriving) setGetPassengersFunc(f func() []string) {
-	m.mocked.GetPassengers.Fallback = f
+	m.mocked.GetPassengers.SetResponseFunc(f)
 }
 
 // enableGetPassengersMock turns the mock on
 func (m *mockSelfDriving) enableGetPassengersMock() {
-	m.mocked.GetPassengers.Enabled = true
+	m.mocked.GetPassengers.Enable()
 }
 
 // disableGetPassengersMock turns the mock off
 func (m *mockSelfDriving) disableGetPassengersMock() {
-	m.mocked.GetPassengers.Enabled = false
+	m.mocked.GetPassengers.Disable()
 }
 
 // enqueueGetPassengersResponseFunc enqueues a function response for GetPassengers
@@ -2202,25 +5825,101 @@ func (m *mockSelfDriving) enqueueGetPassengersResponseFuncWithDelay(f func() []s
 	m.mocked.GetPassengers.EnqueueWithDelay(f, d)
 }
 
-// captureGetPassengersResult sets up a channel to capture GetPassengers results.
-func (m *mockSelfDriving) captureGetPassengersResult() <-chan []string {
-	ch := make(chan []string, 1)
-	m.responseChans["GetPassengers"] = ch
-	return ch
+// subscribeGetPassengers returns a subscription that receives an event for every call to GetPassengers. Call Unsubscribe when done.
+func (m *mockSelfDriving) subscribeGetPassengers(opts stubs.SubscribeOptions) *stubs.Subscription[mockSelfDrivingGetPassengersEvent] {
+	return m.events.GetPassengers.Subscribe(opts)
+}
+
+// captureGetPassengersResult returns a channel that receives the result of every call to GetPassengers.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockSelfDriving) captureGetPassengersResult(t stubs.TB) <-chan []string {
+	sub := stubs.SubscribeMapped(&m.events.GetPassengers, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockSelfDrivingGetPassengersEvent) []string {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetPassengersCallSpy starts watching for GetPassengers spy calls and sends them into a channel.
-func (m *mockSelfDriving) captureGetPassengersCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetPassengersCalls, timeout)
-		ch <- m.getGetPassengersCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockSelfDriving) captureGetPassengersCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetPassengers, "GetPassengers spy call", timeout)
+}
+
+// waitForGetPassengersCalls waits until at least n GetPassengers spy calls are recorded and returns them
+func (m *mockSelfDriving) waitForGetPassengersCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetPassengers, n, timeout)
+}
+
+// waitForGetPassengersCallWithin waits until a GetPassengers spy call matching match is recorded and returns it
+func (m *mockSelfDriving) waitForGetPassengersCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetPassengers, match, timeout)
+}
+
+// eventuallyGetPassengersCalls waits until cond holds for the recorded GetPassengers spy calls
+func (m *mockSelfDriving) eventuallyGetPassengersCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetPassengers, cond, timeout)
+}
+
+// consistentlyGetPassengersNotCalled fails the test if GetPassengers is called during window, or if its spy is not enabled
+func (m *mockSelfDriving) consistentlyGetPassengersNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetPassengers, window)
 }
 
-type mockSelfDrivingGetPassengersResult struct {
-	Output0 []string
+// setGetPassengersPanic makes every mocked call to GetPassengers panic with v
+func (m *mockSelfDriving) setGetPassengersPanic(v any) {
+	m.mocked.GetPassengers.SetPanicResponse(v)
+}
+
+// enqueueGetPassengersPanic enqueues a response for GetPassengers that panics with v
+func (m *mockSelfDriving) enqueueGetPassengersPanic(v any) {
+	m.mocked.GetPassengers.EnqueuePanic(v)
+}
+
+// injectGetPassengersFaults applies a seeded fault policy to GetPassengers. GetPassengers has no error output, so only latency and panics can be injected.
+func (m *mockSelfDriving) injectGetPassengersFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetPassengersFaults: GetPassengers has no error output to inject errors into")
+	}
+	m.mocked.GetPassengers.SetFaults(policy)
+}
+
+// clearGetPassengersFaults removes the fault policy from GetPassengers
+func (m *mockSelfDriving) clearGetPassengersFaults() {
+	m.mocked.GetPassengers.ClearFaults()
+}
+
+// mockSelfDrivingGetPassengersArgs holds the arguments of a call to GetPassengers
+type mockSelfDrivingGetPassengersArgs struct {
+}
+
+// mockSelfDrivingGetPassengersEvent is published to subscribers on every call to GetPassengers
+type mockSelfDrivingGetPassengersEvent struct {
+	Args   mockSelfDrivingGetPassengersArgs
+	Result []string
+}
+
+// mockSelfDrivingGetPassengersCall is a typed record of a call to GetPassengers. Outputs are zero until the call returns.
+type mockSelfDrivingGetPassengersCall struct {
+	Passengers []string
+}
+
+// getGetPassengersTypedCalls returns the spied calls to GetPassengers with typed arguments and results
+func (m *mockSelfDriving) getGetPassengersTypedCalls() []mockSelfDrivingGetPassengersCall {
+	calls := m.mocked.GetPassengers.Calls()
+	typed := make([]mockSelfDrivingGetPassengersCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Passengers, _ = call.Results[0].([]string)
+		}
+	}
+	return typed
 }
 
 // setGetPassengersResponse sets the response for GetPassengers
@@ -2240,7 +5939,6 @@ func (m *mockSelfDriving) enqueueGetPassengersResponse(output0 []string) {
 // enqueueGetPassengersResponseWithDelay enqueues a static response with delay for GetPassengers
 func (m *mockSelfDriving) enqueueGetPassengersResponseWithDelay(output0 []string, d time.Duration) {
 	m.mocked.GetPassengers.EnqueueWithDelay(func() []string {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
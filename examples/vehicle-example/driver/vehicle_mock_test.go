@@ -1,7 +1,6 @@
 package driver
 
 import (
-	"testing"
 	"time"
 
 	"github.com/jackclarke/GoStubGen/examples/vehicle-example/vehicle"
@@ -28,25 +27,609 @@ type mockVehicleConfig struct {
 
 // mockVehicle embeds a concrete Vehicle and its mocks
 type mockVehicle struct {
-	real          vehicle.Vehicle
-	mocked        mockVehicleConfig
-	responseChans map[string]any
+	real   vehicle.Vehicle
+	mocked mockVehicleConfig
+	events mockVehicleEvents
+	hooks  mockVehicleHooks
+}
+
+// mockVehicleEvents fans out an event for every call to each method
+type mockVehicleEvents struct {
+	GetTopSpeed      stubs.Broadcaster[mockVehicleGetTopSpeedEvent]
+	Turn             stubs.Broadcaster[mockVehicleTurnEvent]
+	Reverse          stubs.Broadcaster[mockVehicleReverseEvent]
+	IsMoving         stubs.Broadcaster[mockVehicleIsMovingEvent]
+	GetEngineSpecs   stubs.Broadcaster[mockVehicleGetEngineSpecsEvent]
+	ApplyBrakes      stubs.Broadcaster[mockVehicleApplyBrakesEvent]
+	ChangeGears      stubs.Broadcaster[mockVehicleChangeGearsEvent]
+	Telemetry        stubs.Broadcaster[mockVehicleTelemetryEvent]
+	Accelerate       stubs.Broadcaster[mockVehicleAccelerateEvent]
+	Honk             stubs.Broadcaster[mockVehicleHonkEvent]
+	GetPassengers    stubs.Broadcaster[mockVehicleGetPassengersEvent]
+	LoadCargo        stubs.Broadcaster[mockVehicleLoadCargoEvent]
+	GetVehicleStatus stubs.Broadcaster[mockVehicleGetVehicleStatusEvent]
+	UpdateStatus     stubs.Broadcaster[mockVehicleUpdateStatusEvent]
+}
+
+// mockVehicleHooks holds the before and after hooks of each method
+type mockVehicleHooks struct {
+	GetTopSpeed      stubs.Hooks[mockVehicleGetTopSpeedArgs, int]
+	Turn             stubs.Hooks[mockVehicleTurnArgs, string]
+	Reverse          stubs.Hooks[mockVehicleReverseArgs, mockVehicleReverseResult]
+	IsMoving         stubs.Hooks[mockVehicleIsMovingArgs, bool]
+	GetEngineSpecs   stubs.Hooks[mockVehicleGetEngineSpecsArgs, mockVehicleGetEngineSpecsResult]
+	ApplyBrakes      stubs.Hooks[mockVehicleApplyBrakesArgs, bool]
+	ChangeGears      stubs.Hooks[mockVehicleChangeGearsArgs, mockVehicleChangeGearsResult]
+	Telemetry        stubs.Hooks[mockVehicleTelemetryArgs, map[string]float64]
+	Accelerate       stubs.Hooks[mockVehicleAccelerateArgs, mockVehicleAccelerateResult]
+	Honk             stubs.Hooks[mockVehicleHonkArgs, struct{}]
+	GetPassengers    stubs.Hooks[mockVehicleGetPassengersArgs, []string]
+	LoadCargo        stubs.Hooks[mockVehicleLoadCargoArgs, mockVehicleLoadCargoResult]
+	GetVehicleStatus stubs.Hooks[mockVehicleGetVehicleStatusArgs, vehicle.VehicleStatus]
+	UpdateStatus     stubs.Hooks[mockVehicleUpdateStatusArgs, error]
+}
+
+// newVehicleMock returns a new mock. Its usage is counted in the stubs coverage report when STUBS_COVERAGE is set.
+func newVehicleMock(v vehicle.Vehicle) *mockVehicle {
+	m := &mockVehicle{
+		real: v,
+	}
+	m.mocked.GetTopSpeed.TrackCoverage("vehicle.Vehicle", "GetTopSpeed")
+	m.mocked.Turn.TrackCoverage("vehicle.Vehicle", "Turn")
+	m.mocked.Reverse.TrackCoverage("vehicle.Vehicle", "Reverse")
+	m.mocked.IsMoving.TrackCoverage("vehicle.Vehicle", "IsMoving")
+	m.mocked.GetEngineSpecs.TrackCoverage("vehicle.Vehicle", "GetEngineSpecs")
+	m.mocked.ApplyBrakes.TrackCoverage("vehicle.Vehicle", "ApplyBrakes")
+	m.mocked.ChangeGears.TrackCoverage("vehicle.Vehicle", "ChangeGears")
+	m.mocked.Telemetry.TrackCoverage("vehicle.Vehicle", "Telemetry")
+	m.mocked.Accelerate.TrackCoverage("vehicle.Vehicle", "Accelerate")
+	m.mocked.Honk.TrackCoverage("vehicle.Vehicle", "Honk")
+	m.mocked.GetPassengers.TrackCoverage("vehicle.Vehicle", "GetPassengers")
+	m.mocked.LoadCargo.TrackCoverage("vehicle.Vehicle", "LoadCargo")
+	m.mocked.GetVehicleStatus.TrackCoverage("vehicle.Vehicle", "GetVehicleStatus")
+	m.mocked.UpdateStatus.TrackCoverage("vehicle.Vehicle", "UpdateStatus")
+	return m
+}
+
+// newVehicleMockWithClock returns a new mock whose delays, call timestamps and wait helpers use clock
+func newVehicleMockWithClock(v vehicle.Vehicle, clock stubs.Clock) *mockVehicle {
+	m := newVehicleMock(v)
+	m.mocked.GetTopSpeed.SetClock(clock)
+	m.mocked.Turn.SetClock(clock)
+	m.mocked.Reverse.SetClock(clock)
+	m.mocked.IsMoving.SetClock(clock)
+	m.mocked.GetEngineSpecs.SetClock(clock)
+	m.mocked.ApplyBrakes.SetClock(clock)
+	m.mocked.ChangeGears.SetClock(clock)
+	m.mocked.Telemetry.SetClock(clock)
+	m.mocked.Accelerate.SetClock(clock)
+	m.mocked.Honk.SetClock(clock)
+	m.mocked.GetPassengers.SetClock(clock)
+	m.mocked.LoadCargo.SetClock(clock)
+	m.mocked.GetVehicleStatus.SetClock(clock)
+	m.mocked.UpdateStatus.SetClock(clock)
+	return m
+}
+
+// newVehicleMockForTest returns a new mock that is reset when t finishes, after checking with
+// stubs.VerifyNoLeaks that no call to it is left held at a gate or blocked on a subscription.
+// Calls without an error output report gate failures on t, and the mock stops counting towards the
+// coverage report when t finishes. When STUBS_RECORD is set, calls are recorded with every other mock
+// built for t and printed if t fails.
+func newVehicleMockForTest(t stubs.TB, v vehicle.Vehicle) *mockVehicle {
+	t.Helper()
+	m := newVehicleMock(v)
+	t.Cleanup(m.reset)
+	if stubs.RecordingEnabled() {
+		m.attachRecorder(stubs.RecorderFor(t))
+	}
+	stubs.VerifyNoLeaks(t)
+	m.mocked.GetTopSpeed.TrackLeaks(t)
+	m.mocked.GetTopSpeed.ReportTo(t)
+	m.events.GetTopSpeed.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetTopSpeed.FinishCoverage)
+	m.mocked.Turn.TrackLeaks(t)
+	m.mocked.Turn.ReportTo(t)
+	m.events.Turn.TrackLeaks(t)
+	t.Cleanup(m.mocked.Turn.FinishCoverage)
+	m.mocked.Reverse.TrackLeaks(t)
+	m.mocked.Reverse.ReportTo(t)
+	m.events.Reverse.TrackLeaks(t)
+	t.Cleanup(m.mocked.Reverse.FinishCoverage)
+	m.mocked.IsMoving.TrackLeaks(t)
+	m.mocked.IsMoving.ReportTo(t)
+	m.events.IsMoving.TrackLeaks(t)
+	t.Cleanup(m.mocked.IsMoving.FinishCoverage)
+	m.mocked.GetEngineSpecs.TrackLeaks(t)
+	m.mocked.GetEngineSpecs.ReportTo(t)
+	m.events.GetEngineSpecs.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetEngineSpecs.FinishCoverage)
+	m.mocked.ApplyBrakes.TrackLeaks(t)
+	m.mocked.ApplyBrakes.ReportTo(t)
+	m.events.ApplyBrakes.TrackLeaks(t)
+	t.Cleanup(m.mocked.ApplyBrakes.FinishCoverage)
+	m.mocked.ChangeGears.TrackLeaks(t)
+	m.mocked.ChangeGears.ReportTo(t)
+	m.events.ChangeGears.TrackLeaks(t)
+	t.Cleanup(m.mocked.ChangeGears.FinishCoverage)
+	m.mocked.Telemetry.TrackLeaks(t)
+	m.mocked.Telemetry.ReportTo(t)
+	m.events.Telemetry.TrackLeaks(t)
+	t.Cleanup(m.mocked.Telemetry.FinishCoverage)
+	m.mocked.Accelerate.TrackLeaks(t)
+	m.mocked.Accelerate.ReportTo(t)
+	m.events.Accelerate.TrackLeaks(t)
+	t.Cleanup(m.mocked.Accelerate.FinishCoverage)
+	m.mocked.Honk.TrackLeaks(t)
+	m.mocked.Honk.ReportTo(t)
+	m.events.Honk.TrackLeaks(t)
+	t.Cleanup(m.mocked.Honk.FinishCoverage)
+	m.mocked.GetPassengers.TrackLeaks(t)
+	m.mocked.GetPassengers.ReportTo(t)
+	m.events.GetPassengers.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetPassengers.FinishCoverage)
+	m.mocked.LoadCargo.TrackLeaks(t)
+	m.mocked.LoadCargo.ReportTo(t)
+	m.events.LoadCargo.TrackLeaks(t)
+	t.Cleanup(m.mocked.LoadCargo.FinishCoverage)
+	m.mocked.GetVehicleStatus.TrackLeaks(t)
+	m.mocked.GetVehicleStatus.ReportTo(t)
+	m.events.GetVehicleStatus.TrackLeaks(t)
+	t.Cleanup(m.mocked.GetVehicleStatus.FinishCoverage)
+	m.mocked.UpdateStatus.TrackLeaks(t)
+	m.mocked.UpdateStatus.ReportTo(t)
+	m.events.UpdateStatus.TrackLeaks(t)
+	t.Cleanup(m.mocked.UpdateStatus.FinishCoverage)
+	return m
+}
+
+// attachSequence records every call on the mock into seq so call order can be asserted across mocks
+func (m *mockVehicle) attachSequence(seq *stubs.Sequence) {
+	m.mocked.GetTopSpeed.AttachSequence(seq, "Vehicle", "GetTopSpeed")
+	m.mocked.Turn.AttachSequence(seq, "Vehicle", "Turn")
+	m.mocked.Reverse.AttachSequence(seq, "Vehicle", "Reverse")
+	m.mocked.IsMoving.AttachSequence(seq, "Vehicle", "IsMoving")
+	m.mocked.GetEngineSpecs.AttachSequence(seq, "Vehicle", "GetEngineSpecs")
+	m.mocked.ApplyBrakes.AttachSequence(seq, "Vehicle", "ApplyBrakes")
+	m.mocked.ChangeGears.AttachSequence(seq, "Vehicle", "ChangeGears")
+	m.mocked.Telemetry.AttachSequence(seq, "Vehicle", "Telemetry")
+	m.mocked.Accelerate.AttachSequence(seq, "Vehicle", "Accelerate")
+	m.mocked.Honk.AttachSequence(seq, "Vehicle", "Honk")
+	m.mocked.GetPassengers.AttachSequence(seq, "Vehicle", "GetPassengers")
+	m.mocked.LoadCargo.AttachSequence(seq, "Vehicle", "LoadCargo")
+	m.mocked.GetVehicleStatus.AttachSequence(seq, "Vehicle", "GetVehicleStatus")
+	m.mocked.UpdateStatus.AttachSequence(seq, "Vehicle", "UpdateStatus")
+}
+
+// attachRecorder records every call on the mock into rec with its arguments, results, timing and goroutine
+func (m *mockVehicle) attachRecorder(rec *stubs.Recorder) {
+	m.mocked.GetTopSpeed.AttachRecorder(rec, "Vehicle", "GetTopSpeed")
+	m.mocked.Turn.AttachRecorder(rec, "Vehicle", "Turn")
+	m.mocked.Reverse.AttachRecorder(rec, "Vehicle", "Reverse")
+	m.mocked.IsMoving.AttachRecorder(rec, "Vehicle", "IsMoving")
+	m.mocked.GetEngineSpecs.AttachRecorder(rec, "Vehicle", "GetEngineSpecs")
+	m.mocked.ApplyBrakes.AttachRecorder(rec, "Vehicle", "ApplyBrakes")
+	m.mocked.ChangeGears.AttachRecorder(rec, "Vehicle", "ChangeGears")
+	m.mocked.Telemetry.AttachRecorder(rec, "Vehicle", "Telemetry")
+	m.mocked.Accelerate.AttachRecorder(rec, "Vehicle", "Accelerate")
+	m.mocked.Honk.AttachRecorder(rec, "Vehicle", "Honk")
+	m.mocked.GetPassengers.AttachRecorder(rec, "Vehicle", "GetPassengers")
+	m.mocked.LoadCargo.AttachRecorder(rec, "Vehicle", "LoadCargo")
+	m.mocked.GetVehicleStatus.AttachRecorder(rec, "Vehicle", "GetVehicleStatus")
+	m.mocked.UpdateStatus.AttachRecorder(rec, "Vehicle", "UpdateStatus")
+}
+
+// mockVehicleSnapshot is a point-in-time copy of a mockVehicle's configuration, spy calls, subscriptions and hooks
+type mockVehicleSnapshot struct {
+	methods struct {
+		GetTopSpeed      stubs.MethodSnapshot[func() int]
+		Turn             stubs.MethodSnapshot[func(string) string]
+		Reverse          stubs.MethodSnapshot[func() (string, error)]
+		IsMoving         stubs.MethodSnapshot[func() bool]
+		GetEngineSpecs   stubs.MethodSnapshot[func() (int, string)]
+		ApplyBrakes      stubs.MethodSnapshot[func(float64) bool]
+		ChangeGears      stubs.MethodSnapshot[func(int) (int, int)]
+		Telemetry        stubs.MethodSnapshot[func() map[string]float64]
+		Accelerate       stubs.MethodSnapshot[func(int, string) (int, error)]
+		Honk             stubs.MethodSnapshot[func(int)]
+		GetPassengers    stubs.MethodSnapshot[func() []string]
+		LoadCargo        stubs.MethodSnapshot[func([]string) (int, error)]
+		GetVehicleStatus stubs.MethodSnapshot[func() vehicle.VehicleStatus]
+		UpdateStatus     stubs.MethodSnapshot[func(vehicle.VehicleStatus) error]
+	}
+	events struct {
+		GetTopSpeed      uint64
+		Turn             uint64
+		Reverse          uint64
+		IsMoving         uint64
+		GetEngineSpecs   uint64
+		ApplyBrakes      uint64
+		ChangeGears      uint64
+		Telemetry        uint64
+		Accelerate       uint64
+		Honk             uint64
+		GetPassengers    uint64
+		LoadCargo        uint64
+		GetVehicleStatus uint64
+		UpdateStatus     uint64
+	}
+	hooks struct {
+		GetTopSpeed      uint64
+		Turn             uint64
+		Reverse          uint64
+		IsMoving         uint64
+		GetEngineSpecs   uint64
+		ApplyBrakes      uint64
+		ChangeGears      uint64
+		Telemetry        uint64
+		Accelerate       uint64
+		Honk             uint64
+		GetPassengers    uint64
+		LoadCargo        uint64
+		GetVehicleStatus uint64
+		UpdateStatus     uint64
+	}
 }
 
-// newVehicleMock returns a new mock
-func newVehicleMock(v vehicle.Vehicle) *mockVehicle {
-	return &mockVehicle{
-		real:          v,
-		mocked:        mockVehicleConfig{},
-		responseChans: make(map[string]any),
+// reset clears the configuration, spy calls, subscriptions and hooks of every method
+func (m *mockVehicle) reset() {
+	m.mocked.GetTopSpeed.Reset()
+	m.events.GetTopSpeed.UnsubscribeAll()
+	m.hooks.GetTopSpeed.RemoveAll()
+	m.mocked.Turn.Reset()
+	m.events.Turn.UnsubscribeAll()
+	m.hooks.Turn.RemoveAll()
+	m.mocked.Reverse.Reset()
+	m.events.Reverse.UnsubscribeAll()
+	m.hooks.Reverse.RemoveAll()
+	m.mocked.IsMoving.Reset()
+	m.events.IsMoving.UnsubscribeAll()
+	m.hooks.IsMoving.RemoveAll()
+	m.mocked.GetEngineSpecs.Reset()
+	m.events.GetEngineSpecs.UnsubscribeAll()
+	m.hooks.GetEngineSpecs.RemoveAll()
+	m.mocked.ApplyBrakes.Reset()
+	m.events.ApplyBrakes.UnsubscribeAll()
+	m.hooks.ApplyBrakes.RemoveAll()
+	m.mocked.ChangeGears.Reset()
+	m.events.ChangeGears.UnsubscribeAll()
+	m.hooks.ChangeGears.RemoveAll()
+	m.mocked.Telemetry.Reset()
+	m.events.Telemetry.UnsubscribeAll()
+	m.hooks.Telemetry.RemoveAll()
+	m.mocked.Accelerate.Reset()
+	m.events.Accelerate.UnsubscribeAll()
+	m.hooks.Accelerate.RemoveAll()
+	m.mocked.Honk.Reset()
+	m.events.Honk.UnsubscribeAll()
+	m.hooks.Honk.RemoveAll()
+	m.mocked.GetPassengers.Reset()
+	m.events.GetPassengers.UnsubscribeAll()
+	m.hooks.GetPassengers.RemoveAll()
+	m.mocked.LoadCargo.Reset()
+	m.events.LoadCargo.UnsubscribeAll()
+	m.hooks.LoadCargo.RemoveAll()
+	m.mocked.GetVehicleStatus.Reset()
+	m.events.GetVehicleStatus.UnsubscribeAll()
+	m.hooks.GetVehicleStatus.RemoveAll()
+	m.mocked.UpdateStatus.Reset()
+	m.events.UpdateStatus.UnsubscribeAll()
+	m.hooks.UpdateStatus.RemoveAll()
+}
+
+// snapshot captures the configuration, spy calls, subscriptions and hooks of every method
+func (m *mockVehicle) snapshot() mockVehicleSnapshot {
+	var snap mockVehicleSnapshot
+	snap.methods.GetTopSpeed = m.mocked.GetTopSpeed.Snapshot()
+	snap.events.GetTopSpeed = m.events.GetTopSpeed.Mark()
+	snap.hooks.GetTopSpeed = m.hooks.GetTopSpeed.Mark()
+	snap.methods.Turn = m.mocked.Turn.Snapshot()
+	snap.events.Turn = m.events.Turn.Mark()
+	snap.hooks.Turn = m.hooks.Turn.Mark()
+	snap.methods.Reverse = m.mocked.Reverse.Snapshot()
+	snap.events.Reverse = m.events.Reverse.Mark()
+	snap.hooks.Reverse = m.hooks.Reverse.Mark()
+	snap.methods.IsMoving = m.mocked.IsMoving.Snapshot()
+	snap.events.IsMoving = m.events.IsMoving.Mark()
+	snap.hooks.IsMoving = m.hooks.IsMoving.Mark()
+	snap.methods.GetEngineSpecs = m.mocked.GetEngineSpecs.Snapshot()
+	snap.events.GetEngineSpecs = m.events.GetEngineSpecs.Mark()
+	snap.hooks.GetEngineSpecs = m.hooks.GetEngineSpecs.Mark()
+	snap.methods.ApplyBrakes = m.mocked.ApplyBrakes.Snapshot()
+	snap.events.ApplyBrakes = m.events.ApplyBrakes.Mark()
+	snap.hooks.ApplyBrakes = m.hooks.ApplyBrakes.Mark()
+	snap.methods.ChangeGears = m.mocked.ChangeGears.Snapshot()
+	snap.events.ChangeGears = m.events.ChangeGears.Mark()
+	snap.hooks.ChangeGears = m.hooks.ChangeGears.Mark()
+	snap.methods.Telemetry = m.mocked.Telemetry.Snapshot()
+	snap.events.Telemetry = m.events.Telemetry.Mark()
+	snap.hooks.Telemetry = m.hooks.Telemetry.Mark()
+	snap.methods.Accelerate = m.mocked.Accelerate.Snapshot()
+	snap.events.Accelerate = m.events.Accelerate.Mark()
+	snap.hooks.Accelerate = m.hooks.Accelerate.Mark()
+	snap.methods.Honk = m.mocked.Honk.Snapshot()
+	snap.events.Honk = m.events.Honk.Mark()
+	snap.hooks.Honk = m.hooks.Honk.Mark()
+	snap.methods.GetPassengers = m.mocked.GetPassengers.Snapshot()
+	snap.events.GetPassengers = m.events.GetPassengers.Mark()
+	snap.hooks.GetPassengers = m.hooks.GetPassengers.Mark()
+	snap.methods.LoadCargo = m.mocked.LoadCargo.Snapshot()
+	snap.events.LoadCargo = m.events.LoadCargo.Mark()
+	snap.hooks.LoadCargo = m.hooks.LoadCargo.Mark()
+	snap.methods.GetVehicleStatus = m.mocked.GetVehicleStatus.Snapshot()
+	snap.events.GetVehicleStatus = m.events.GetVehicleStatus.Mark()
+	snap.hooks.GetVehicleStatus = m.hooks.GetVehicleStatus.Mark()
+	snap.methods.UpdateStatus = m.mocked.UpdateStatus.Snapshot()
+	snap.events.UpdateStatus = m.events.UpdateStatus.Mark()
+	snap.hooks.UpdateStatus = m.hooks.UpdateStatus.Mark()
+	return snap
+}
+
+// restore returns every method to the state captured by snap and removes subscriptions and hooks added since
+func (m *mockVehicle) restore(snap mockVehicleSnapshot) {
+	m.mocked.GetTopSpeed.Restore(snap.methods.GetTopSpeed)
+	m.events.GetTopSpeed.UnsubscribeAfter(snap.events.GetTopSpeed)
+	m.hooks.GetTopSpeed.RemoveAfter(snap.hooks.GetTopSpeed)
+	m.mocked.Turn.Restore(snap.methods.Turn)
+	m.events.Turn.UnsubscribeAfter(snap.events.Turn)
+	m.hooks.Turn.RemoveAfter(snap.hooks.Turn)
+	m.mocked.Reverse.Restore(snap.methods.Reverse)
+	m.events.Reverse.UnsubscribeAfter(snap.events.Reverse)
+	m.hooks.Reverse.RemoveAfter(snap.hooks.Reverse)
+	m.mocked.IsMoving.Restore(snap.methods.IsMoving)
+	m.events.IsMoving.UnsubscribeAfter(snap.events.IsMoving)
+	m.hooks.IsMoving.RemoveAfter(snap.hooks.IsMoving)
+	m.mocked.GetEngineSpecs.Restore(snap.methods.GetEngineSpecs)
+	m.events.GetEngineSpecs.UnsubscribeAfter(snap.events.GetEngineSpecs)
+	m.hooks.GetEngineSpecs.RemoveAfter(snap.hooks.GetEngineSpecs)
+	m.mocked.ApplyBrakes.Restore(snap.methods.ApplyBrakes)
+	m.events.ApplyBrakes.UnsubscribeAfter(snap.events.ApplyBrakes)
+	m.hooks.ApplyBrakes.RemoveAfter(snap.hooks.ApplyBrakes)
+	m.mocked.ChangeGears.Restore(snap.methods.ChangeGears)
+	m.events.ChangeGears.UnsubscribeAfter(snap.events.ChangeGears)
+	m.hooks.ChangeGears.RemoveAfter(snap.hooks.ChangeGears)
+	m.mocked.Telemetry.Restore(snap.methods.Telemetry)
+	m.events.Telemetry.UnsubscribeAfter(snap.events.Telemetry)
+	m.hooks.Telemetry.RemoveAfter(snap.hooks.Telemetry)
+	m.mocked.Accelerate.Restore(snap.methods.Accelerate)
+	m.events.Accelerate.UnsubscribeAfter(snap.events.Accelerate)
+	m.hooks.Accelerate.RemoveAfter(snap.hooks.Accelerate)
+	m.mocked.Honk.Restore(snap.methods.Honk)
+	m.events.Honk.UnsubscribeAfter(snap.events.Honk)
+	m.hooks.Honk.RemoveAfter(snap.hooks.Honk)
+	m.mocked.GetPassengers.Restore(snap.methods.GetPassengers)
+	m.events.GetPassengers.UnsubscribeAfter(snap.events.GetPassengers)
+	m.hooks.GetPassengers.RemoveAfter(snap.hooks.GetPassengers)
+	m.mocked.LoadCargo.Restore(snap.methods.LoadCargo)
+	m.events.LoadCargo.UnsubscribeAfter(snap.events.LoadCargo)
+	m.hooks.LoadCargo.RemoveAfter(snap.hooks.LoadCargo)
+	m.mocked.GetVehicleStatus.Restore(snap.methods.GetVehicleStatus)
+	m.events.GetVehicleStatus.UnsubscribeAfter(snap.events.GetVehicleStatus)
+	m.hooks.GetVehicleStatus.RemoveAfter(snap.hooks.GetVehicleStatus)
+	m.mocked.UpdateStatus.Restore(snap.methods.UpdateStatus)
+	m.events.UpdateStatus.UnsubscribeAfter(snap.events.UpdateStatus)
+	m.hooks.UpdateStatus.RemoveAfter(snap.hooks.UpdateStatus)
+}
+
+// scope rolls back configuration made during t when t and its subtests finish.
+// Only methods configured since scope was called are restored, so parallel subtests
+// that configure different methods of a shared mock do not undo each other.
+// Subscriptions and hooks added since scope was called are removed.
+func (m *mockVehicle) scope(t stubs.TB) {
+	snap := m.snapshot()
+	t.Cleanup(func() {
+		if m.mocked.GetTopSpeed.ModifiedSince(snap.methods.GetTopSpeed) {
+			m.mocked.GetTopSpeed.Restore(snap.methods.GetTopSpeed)
+		}
+		m.events.GetTopSpeed.UnsubscribeAfter(snap.events.GetTopSpeed)
+		m.hooks.GetTopSpeed.RemoveAfter(snap.hooks.GetTopSpeed)
+		if m.mocked.Turn.ModifiedSince(snap.methods.Turn) {
+			m.mocked.Turn.Restore(snap.methods.Turn)
+		}
+		m.events.Turn.UnsubscribeAfter(snap.events.Turn)
+		m.hooks.Turn.RemoveAfter(snap.hooks.Turn)
+		if m.mocked.Reverse.ModifiedSince(snap.methods.Reverse) {
+			m.mocked.Reverse.Restore(snap.methods.Reverse)
+		}
+		m.events.Reverse.UnsubscribeAfter(snap.events.Reverse)
+		m.hooks.Reverse.RemoveAfter(snap.hooks.Reverse)
+		if m.mocked.IsMoving.ModifiedSince(snap.methods.IsMoving) {
+			m.mocked.IsMoving.Restore(snap.methods.IsMoving)
+		}
+		m.events.IsMoving.UnsubscribeAfter(snap.events.IsMoving)
+		m.hooks.IsMoving.RemoveAfter(snap.hooks.IsMoving)
+		if m.mocked.GetEngineSpecs.ModifiedSince(snap.methods.GetEngineSpecs) {
+			m.mocked.GetEngineSpecs.Restore(snap.methods.GetEngineSpecs)
+		}
+		m.events.GetEngineSpecs.UnsubscribeAfter(snap.events.GetEngineSpecs)
+		m.hooks.GetEngineSpecs.RemoveAfter(snap.hooks.GetEngineSpecs)
+		if m.mocked.ApplyBrakes.ModifiedSince(snap.methods.ApplyBrakes) {
+			m.mocked.ApplyBrakes.Restore(snap.methods.ApplyBrakes)
+		}
+		m.events.ApplyBrakes.UnsubscribeAfter(snap.events.ApplyBrakes)
+		m.hooks.ApplyBrakes.RemoveAfter(snap.hooks.ApplyBrakes)
+		if m.mocked.ChangeGears.ModifiedSince(snap.methods.ChangeGears) {
+			m.mocked.ChangeGears.Restore(snap.methods.ChangeGears)
+		}
+		m.events.ChangeGears.UnsubscribeAfter(snap.events.ChangeGears)
+		m.hooks.ChangeGears.RemoveAfter(snap.hooks.ChangeGears)
+		if m.mocked.Telemetry.ModifiedSince(snap.methods.Telemetry) {
+			m.mocked.Telemetry.Restore(snap.methods.Telemetry)
+		}
+		m.events.Telemetry.UnsubscribeAfter(snap.events.Telemetry)
+		m.hooks.Telemetry.RemoveAfter(snap.hooks.Telemetry)
+		if m.mocked.Accelerate.ModifiedSince(snap.methods.Accelerate) {
+			m.mocked.Accelerate.Restore(snap.methods.Accelerate)
+		}
+		m.events.Accelerate.UnsubscribeAfter(snap.events.Accelerate)
+		m.hooks.Accelerate.RemoveAfter(snap.hooks.Accelerate)
+		if m.mocked.Honk.ModifiedSince(snap.methods.Honk) {
+			m.mocked.Honk.Restore(snap.methods.Honk)
+		}
+		m.events.Honk.UnsubscribeAfter(snap.events.Honk)
+		m.hooks.Honk.RemoveAfter(snap.hooks.Honk)
+		if m.mocked.GetPassengers.ModifiedSince(snap.methods.GetPassengers) {
+			m.mocked.GetPassengers.Restore(snap.methods.GetPassengers)
+		}
+		m.events.GetPassengers.UnsubscribeAfter(snap.events.GetPassengers)
+		m.hooks.GetPassengers.RemoveAfter(snap.hooks.GetPassengers)
+		if m.mocked.LoadCargo.ModifiedSince(snap.methods.LoadCargo) {
+			m.mocked.LoadCargo.Restore(snap.methods.LoadCargo)
+		}
+		m.events.LoadCargo.UnsubscribeAfter(snap.events.LoadCargo)
+		m.hooks.LoadCargo.RemoveAfter(snap.hooks.LoadCargo)
+		if m.mocked.GetVehicleStatus.ModifiedSince(snap.methods.GetVehicleStatus) {
+			m.mocked.GetVehicleStatus.Restore(snap.methods.GetVehicleStatus)
+		}
+		m.events.GetVehicleStatus.UnsubscribeAfter(snap.events.GetVehicleStatus)
+		m.hooks.GetVehicleStatus.RemoveAfter(snap.hooks.GetVehicleStatus)
+		if m.mocked.UpdateStatus.ModifiedSince(snap.methods.UpdateStatus) {
+			m.mocked.UpdateStatus.Restore(snap.methods.UpdateStatus)
+		}
+		m.events.UpdateStatus.UnsubscribeAfter(snap.events.UpdateStatus)
+		m.hooks.UpdateStatus.RemoveAfter(snap.hooks.UpdateStatus)
+	})
+}
+
+// loadVehicleScenario configures the mock from a YAML or JSON scenario file, see stubs.Scenario
+func (m *mockVehicle) loadVehicleScenario(path string) error {
+	sc, err := stubs.LoadScenario(path)
+	if err != nil {
+		return err
 	}
+	return sc.Apply(map[string]func(stubs.ScenarioMethod) error{
+		"GetTopSpeed": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetTopSpeed.ApplyScenario("GetTopSpeed", sm,
+				[]string{},
+				[]string{""})
+		},
+		"Turn": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Turn.ApplyScenario("Turn", sm,
+				[]string{"dir"},
+				[]string{""})
+		},
+		"Reverse": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Reverse.ApplyScenario("Reverse", sm,
+				[]string{},
+				[]string{"location", "err"})
+		},
+		"IsMoving": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.IsMoving.ApplyScenario("IsMoving", sm,
+				[]string{},
+				[]string{"isMoving"})
+		},
+		"GetEngineSpecs": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetEngineSpecs.ApplyScenario("GetEngineSpecs", sm,
+				[]string{},
+				[]string{"power", "fuelType"})
+		},
+		"ApplyBrakes": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.ApplyBrakes.ApplyScenario("ApplyBrakes", sm,
+				[]string{"force"},
+				[]string{"applied"})
+		},
+		"ChangeGears": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.ChangeGears.ApplyScenario("ChangeGears", sm,
+				[]string{"gear"},
+				[]string{"before", "after"})
+		},
+		"Telemetry": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Telemetry.ApplyScenario("Telemetry", sm,
+				[]string{},
+				[]string{"telemetryData"})
+		},
+		"Accelerate": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Accelerate.ApplyScenario("Accelerate", sm,
+				[]string{"speed", "unit"},
+				[]string{"newSpeed", "err"})
+		},
+		"Honk": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.Honk.ApplyScenario("Honk", sm,
+				[]string{"times"},
+				[]string{})
+		},
+		"GetPassengers": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetPassengers.ApplyScenario("GetPassengers", sm,
+				[]string{},
+				[]string{"passengers"})
+		},
+		"LoadCargo": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.LoadCargo.ApplyScenario("LoadCargo", sm,
+				[]string{"items"},
+				[]string{"loaded", "err"})
+		},
+		"GetVehicleStatus": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.GetVehicleStatus.ApplyScenario("GetVehicleStatus", sm,
+				[]string{},
+				[]string{"vehicleStatus"})
+		},
+		"UpdateStatus": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.UpdateStatus.ApplyScenario("UpdateStatus", sm,
+				[]string{"status"},
+				[]string{"err"})
+		},
+	})
+}
+
+// useVehicleGolden records every method to g or replays them from it, see stubs.Golden
+func (m *mockVehicle) useVehicleGolden(g *stubs.Golden) {
+	m.mocked.GetTopSpeed.UseGolden(g, "Vehicle.GetTopSpeed",
+		[]string{},
+		[]string{""})
+	m.mocked.Turn.UseGolden(g, "Vehicle.Turn",
+		[]string{"dir"},
+		[]string{""})
+	m.mocked.Reverse.UseGolden(g, "Vehicle.Reverse",
+		[]string{},
+		[]string{"location", "err"})
+	m.mocked.IsMoving.UseGolden(g, "Vehicle.IsMoving",
+		[]string{},
+		[]string{"isMoving"})
+	m.mocked.GetEngineSpecs.UseGolden(g, "Vehicle.GetEngineSpecs",
+		[]string{},
+		[]string{"power", "fuelType"})
+	m.mocked.ApplyBrakes.UseGolden(g, "Vehicle.ApplyBrakes",
+		[]string{"force"},
+		[]string{"applied"})
+	m.mocked.ChangeGears.UseGolden(g, "Vehicle.ChangeGears",
+		[]string{"gear"},
+		[]string{"before", "after"})
+	m.mocked.Telemetry.UseGolden(g, "Vehicle.Telemetry",
+		[]string{},
+		[]string{"telemetryData"})
+	m.mocked.Accelerate.UseGolden(g, "Vehicle.Accelerate",
+		[]string{"speed", "unit"},
+		[]string{"newSpeed", "err"})
+	m.mocked.Honk.UseGolden(g, "Vehicle.Honk",
+		[]string{"times"},
+		[]string{})
+	m.mocked.GetPassengers.UseGolden(g, "Vehicle.GetPassengers",
+		[]string{},
+		[]string{"passengers"})
+	m.mocked.LoadCargo.UseGolden(g, "Vehicle.LoadCargo",
+		[]string{"items"},
+		[]string{"loaded", "err"})
+	m.mocked.GetVehicleStatus.UseGolden(g, "Vehicle.GetVehicleStatus",
+		[]string{},
+		[]string{"vehicleStatus"})
+	m.mocked.UpdateStatus.UseGolden(g, "Vehicle.UpdateStatus",
+		[]string{"status"},
+		[]string{"err"})
+}
+
+// useVehicleGoldenFile records to or replays from path in the mode given by stubs.GoldenModeFromFlags
+func (m *mockVehicle) useVehicleGoldenFile(t stubs.TB, path string) *stubs.Golden {
+	t.Helper()
+	g := stubs.NewGolden(t, path, stubs.GoldenModeFromFlags())
+	m.useVehicleGolden(g)
+	return g
 }
 
 /* -------------------------- GetTopSpeed Mock Helpers --------------------------- */
 
-// enableGetTopSpeedMock turns the spy on
+// enableGetTopSpeedSpy turns the spy on
 func (m *mockVehicle) enableGetTopSpeedSpy() {
-	m.mocked.GetTopSpeed.SpyEnabled = true
+	m.mocked.GetTopSpeed.EnableSpy()
 }
 
 // getGetTopSpeedCalls returns recorded calls to GetTopSpeed
@@ -54,49 +637,115 @@ func (m *mockVehicle) getGetTopSpeedCalls() []stubs.MethodCall {
 	return m.mocked.GetTopSpeed.Calls()
 }
 
-// enableGetTopSpeedMock turns the spy off
+// enableGetTopSpeedSpy turns the spy off
 func (m *mockVehicle) disableGetTopSpeedSpy() {
-	m.mocked.GetTopSpeed.SpyEnabled = false
+	m.mocked.GetTopSpeed.DisableSpy()
+}
+
+// disableGetTopSpeedArgCloning records arguments and results of GetTopSpeed by reference instead of deep copying them
+func (m *mockVehicle) disableGetTopSpeedArgCloning() {
+	m.mocked.GetTopSpeed.DisableArgCloning()
+}
+
+// enableGetTopSpeedArgCloning deep copies arguments and results of GetTopSpeed as they are recorded
+func (m *mockVehicle) enableGetTopSpeedArgCloning() {
+	m.mocked.GetTopSpeed.EnableArgCloning()
 }
 
 // GetTopSpeed overrides the method to return the mock response
 func (m *mockVehicle) GetTopSpeed() int {
-	m.mocked.GetTopSpeed.RecordCall()
+	callID := m.mocked.GetTopSpeed.RecordCall()
+	defer m.mocked.GetTopSpeed.EndCall(callID)
+	callArgs := mockVehicleGetTopSpeedArgs{}
+	m.hooks.GetTopSpeed.RunBefore(callArgs)
 	var (
 		out0 int
 	)
 
-	if m.mocked.GetTopSpeed.Enabled {
+	gatePassed := m.mocked.GetTopSpeed.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetTopSpeed.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetTopSpeed.IsEnabled() {
 		out0 = m.mocked.GetTopSpeed.NextResponse(func() int {
 			return m.real.GetTopSpeed()
 		})()
-
 	} else {
 		out0 = m.real.GetTopSpeed()
-
 	}
 
-	if ch, ok := m.responseChans["GetTopSpeed"]; ok {
-		chTyped := ch.(chan int)
-		chTyped <- out0
-	}
+	m.mocked.GetTopSpeed.RecordResults(callID, out0)
+	m.hooks.GetTopSpeed.RunAfter(callArgs, out0)
+	m.events.GetTopSpeed.Publish(mockVehicleGetTopSpeedEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onGetTopSpeedBefore runs fn on every call to GetTopSpeed before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onGetTopSpeedBefore(fn func(args mockVehicleGetTopSpeedArgs)) func() {
+	return m.hooks.GetTopSpeed.Before(fn)
+}
+
+// onGetTopSpeedAfter runs fn once each call to GetTopSpeed has its result. The returned func removes the hook.
+func (m *mockVehicle) onGetTopSpeedAfter(fn func(args mockVehicleGetTopSpeedArgs, result int)) func() {
+	return m.hooks.GetTopSpeed.After(fn)
+}
+
+// notifyGetTopSpeedCalled returns a channel that is closed when GetTopSpeed is next called, before it responds
+func (m *mockVehicle) notifyGetTopSpeedCalled() <-chan struct{} {
+	return m.hooks.GetTopSpeed.Notify()
+}
+
+// blockGetTopSpeed holds every later call to GetTopSpeed mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockGetTopSpeed() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetTopSpeed.SetGate(g)
+	return g
+}
 
+// unblockGetTopSpeed removes the gate set by blockGetTopSpeed, releasing any held calls
+func (m *mockVehicle) unblockGetTopSpeed() {
+	m.mocked.GetTopSpeed.ClearGate()
+}
+
+// assertGetTopSpeedMaxConcurrency fails the test if more than n calls to GetTopSpeed were ever in flight at once
+func (m *mockVehicle) assertGetTopSpeedMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetTopSpeed, n)
+}
+
+// assertGetTopSpeedNeverConcurrent fails the test if any two calls to GetTopSpeed were in flight at the same time
+func (m *mockVehicle) assertGetTopSpeedNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetTopSpeed)
+}
+
+// getGetTopSpeedConcurrencyStats returns the in-flight counts and overlapping calls to GetTopSpeed
+func (m *mockVehicle) getGetTopSpeedConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetTopSpeed.ConcurrencyStats()
 }
 
 // setGetTopSpeedFunc sets the function for GetTopSpeed
 func (m *mockVehicle) setGetTopSpeedFunc(f func() int) {
-	m.mocked.GetTopSpeed.Fallback = f
+	m.mocked.GetTopSpeed.SetResponseFunc(f)
 }
 
 // enableGetTopSpeedMock turns the mock on
 func (m *mockVehicle) enableGetTopSpeedMock() {
-	m.mocked.GetTopSpeed.Enabled = true
+	m.mocked.GetTopSpeed.Enable()
 }
 
 // disableGetTopSpeedMock turns the mock off
 func (m *mockVehicle) disableGetTopSpeedMock() {
-	m.mocked.GetTopSpeed.Enabled = false
+	m.mocked.GetTopSpeed.Disable()
 }
 
 // enqueueGetTopSpeedResponseFunc enqueues a function response for GetTopSpeed
@@ -109,27 +758,103 @@ func (m *mockVehicle) enqueueGetTopSpeedResponseFuncWithDelay(f func() int, d ti
 	m.mocked.GetTopSpeed.EnqueueWithDelay(f, d)
 }
 
-// captureGetTopSpeedResult sets up a channel to capture GetTopSpeed results.
-func (m *mockVehicle) captureGetTopSpeedResult() <-chan int {
-	ch := make(chan int, 1)
-	m.responseChans["GetTopSpeed"] = ch
-	return ch
+// subscribeGetTopSpeed returns a subscription that receives an event for every call to GetTopSpeed. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeGetTopSpeed(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleGetTopSpeedEvent] {
+	return m.events.GetTopSpeed.Subscribe(opts)
+}
+
+// captureGetTopSpeedResult returns a channel that receives the result of every call to GetTopSpeed.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureGetTopSpeedResult(t stubs.TB) <-chan int {
+	sub := stubs.SubscribeMapped(&m.events.GetTopSpeed, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleGetTopSpeedEvent) int {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetTopSpeedCallSpy starts watching for GetTopSpeed spy calls and sends them into a channel.
-func (m *mockVehicle) captureGetTopSpeedCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetTopSpeedCalls, timeout)
-		ch <- m.getGetTopSpeedCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureGetTopSpeedCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetTopSpeed, "GetTopSpeed spy call", timeout)
+}
+
+// waitForGetTopSpeedCalls waits until at least n GetTopSpeed spy calls are recorded and returns them
+func (m *mockVehicle) waitForGetTopSpeedCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetTopSpeed, n, timeout)
+}
+
+// waitForGetTopSpeedCallWithin waits until a GetTopSpeed spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForGetTopSpeedCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetTopSpeed, match, timeout)
+}
+
+// eventuallyGetTopSpeedCalls waits until cond holds for the recorded GetTopSpeed spy calls
+func (m *mockVehicle) eventuallyGetTopSpeedCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetTopSpeed, cond, timeout)
+}
+
+// consistentlyGetTopSpeedNotCalled fails the test if GetTopSpeed is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyGetTopSpeedNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetTopSpeed, window)
+}
+
+// setGetTopSpeedPanic makes every mocked call to GetTopSpeed panic with v
+func (m *mockVehicle) setGetTopSpeedPanic(v any) {
+	m.mocked.GetTopSpeed.SetPanicResponse(v)
+}
+
+// enqueueGetTopSpeedPanic enqueues a response for GetTopSpeed that panics with v
+func (m *mockVehicle) enqueueGetTopSpeedPanic(v any) {
+	m.mocked.GetTopSpeed.EnqueuePanic(v)
+}
+
+// injectGetTopSpeedFaults applies a seeded fault policy to GetTopSpeed. GetTopSpeed has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectGetTopSpeedFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetTopSpeedFaults: GetTopSpeed has no error output to inject errors into")
+	}
+	m.mocked.GetTopSpeed.SetFaults(policy)
 }
 
-type mockVehicleGetTopSpeedResult struct {
+// clearGetTopSpeedFaults removes the fault policy from GetTopSpeed
+func (m *mockVehicle) clearGetTopSpeedFaults() {
+	m.mocked.GetTopSpeed.ClearFaults()
+}
+
+// mockVehicleGetTopSpeedArgs holds the arguments of a call to GetTopSpeed
+type mockVehicleGetTopSpeedArgs struct {
+}
+
+// mockVehicleGetTopSpeedEvent is published to subscribers on every call to GetTopSpeed
+type mockVehicleGetTopSpeedEvent struct {
+	Args   mockVehicleGetTopSpeedArgs
+	Result int
+}
+
+// mockVehicleGetTopSpeedCall is a typed record of a call to GetTopSpeed. Outputs are zero until the call returns.
+type mockVehicleGetTopSpeedCall struct {
 	Output0 int
 }
 
+// getGetTopSpeedTypedCalls returns the spied calls to GetTopSpeed with typed arguments and results
+func (m *mockVehicle) getGetTopSpeedTypedCalls() []mockVehicleGetTopSpeedCall {
+	calls := m.mocked.GetTopSpeed.Calls()
+	typed := make([]mockVehicleGetTopSpeedCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Output0, _ = call.Results[0].(int)
+		}
+	}
+	return typed
+}
+
 // setGetTopSpeedResponse sets the response for GetTopSpeed
 func (m *mockVehicle) setGetTopSpeedResponse(output0 int) {
 	m.setGetTopSpeedFunc(func() int {
@@ -147,16 +872,15 @@ func (m *mockVehicle) enqueueGetTopSpeedResponse(output0 int) {
 // enqueueGetTopSpeedResponseWithDelay enqueues a static response with delay for GetTopSpeed
 func (m *mockVehicle) enqueueGetTopSpeedResponseWithDelay(output0 int, d time.Duration) {
 	m.mocked.GetTopSpeed.EnqueueWithDelay(func() int {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Turn Mock Helpers --------------------------- */
 
-// enableTurnMock turns the spy on
+// enableTurnSpy turns the spy on
 func (m *mockVehicle) enableTurnSpy() {
-	m.mocked.Turn.SpyEnabled = true
+	m.mocked.Turn.EnableSpy()
 }
 
 // getTurnCalls returns recorded calls to Turn
@@ -164,49 +888,115 @@ func (m *mockVehicle) getTurnCalls() []stubs.MethodCall {
 	return m.mocked.Turn.Calls()
 }
 
-// enableTurnMock turns the spy off
+// enableTurnSpy turns the spy off
 func (m *mockVehicle) disableTurnSpy() {
-	m.mocked.Turn.SpyEnabled = false
+	m.mocked.Turn.DisableSpy()
+}
+
+// disableTurnArgCloning records arguments and results of Turn by reference instead of deep copying them
+func (m *mockVehicle) disableTurnArgCloning() {
+	m.mocked.Turn.DisableArgCloning()
+}
+
+// enableTurnArgCloning deep copies arguments and results of Turn as they are recorded
+func (m *mockVehicle) enableTurnArgCloning() {
+	m.mocked.Turn.EnableArgCloning()
 }
 
 // Turn overrides the method to return the mock response
 func (m *mockVehicle) Turn(dir string) string {
-	m.mocked.Turn.RecordCall(dir)
+	callID := m.mocked.Turn.RecordCall(dir)
+	defer m.mocked.Turn.EndCall(callID)
+	callArgs := mockVehicleTurnArgs{Dir: dir}
+	m.hooks.Turn.RunBefore(callArgs)
 	var (
 		out0 string
 	)
 
-	if m.mocked.Turn.Enabled {
+	gatePassed := m.mocked.Turn.PassGateOrReport()
+	if gatePassed {
+		m.mocked.Turn.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.Turn.IsEnabled() {
 		out0 = m.mocked.Turn.NextResponse(func(dir string) string {
 			return m.real.Turn(dir)
 		})(dir)
-
 	} else {
 		out0 = m.real.Turn(dir)
-
 	}
 
-	if ch, ok := m.responseChans["Turn"]; ok {
-		chTyped := ch.(chan string)
-		chTyped <- out0
-	}
+	m.mocked.Turn.RecordResults(callID, out0)
+	m.hooks.Turn.RunAfter(callArgs, out0)
+	m.events.Turn.Publish(mockVehicleTurnEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onTurnBefore runs fn on every call to Turn before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onTurnBefore(fn func(args mockVehicleTurnArgs)) func() {
+	return m.hooks.Turn.Before(fn)
+}
+
+// onTurnAfter runs fn once each call to Turn has its result. The returned func removes the hook.
+func (m *mockVehicle) onTurnAfter(fn func(args mockVehicleTurnArgs, result string)) func() {
+	return m.hooks.Turn.After(fn)
+}
 
+// notifyTurnCalled returns a channel that is closed when Turn is next called, before it responds
+func (m *mockVehicle) notifyTurnCalled() <-chan struct{} {
+	return m.hooks.Turn.Notify()
+}
+
+// blockTurn holds every later call to Turn mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockTurn() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Turn.SetGate(g)
+	return g
+}
+
+// unblockTurn removes the gate set by blockTurn, releasing any held calls
+func (m *mockVehicle) unblockTurn() {
+	m.mocked.Turn.ClearGate()
+}
+
+// assertTurnMaxConcurrency fails the test if more than n calls to Turn were ever in flight at once
+func (m *mockVehicle) assertTurnMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Turn, n)
+}
+
+// assertTurnNeverConcurrent fails the test if any two calls to Turn were in flight at the same time
+func (m *mockVehicle) assertTurnNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Turn)
+}
+
+// getTurnConcurrencyStats returns the in-flight counts and overlapping calls to Turn
+func (m *mockVehicle) getTurnConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Turn.ConcurrencyStats()
 }
 
 // setTurnFunc sets the function for Turn
 func (m *mockVehicle) setTurnFunc(f func(string) string) {
-	m.mocked.Turn.Fallback = f
+	m.mocked.Turn.SetResponseFunc(f)
 }
 
 // enableTurnMock turns the mock on
 func (m *mockVehicle) enableTurnMock() {
-	m.mocked.Turn.Enabled = true
+	m.mocked.Turn.Enable()
 }
 
 // disableTurnMock turns the mock off
 func (m *mockVehicle) disableTurnMock() {
-	m.mocked.Turn.Enabled = false
+	m.mocked.Turn.Disable()
 }
 
 // enqueueTurnResponseFunc enqueues a function response for Turn
@@ -219,27 +1009,113 @@ func (m *mockVehicle) enqueueTurnResponseFuncWithDelay(f func(string) string, d
 	m.mocked.Turn.EnqueueWithDelay(f, d)
 }
 
-// captureTurnResult sets up a channel to capture Turn results.
-func (m *mockVehicle) captureTurnResult() <-chan string {
-	ch := make(chan string, 1)
-	m.responseChans["Turn"] = ch
-	return ch
+// subscribeTurn returns a subscription that receives an event for every call to Turn. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeTurn(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleTurnEvent] {
+	return m.events.Turn.Subscribe(opts)
+}
+
+// captureTurnResult returns a channel that receives the result of every call to Turn.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureTurnResult(t stubs.TB) <-chan string {
+	sub := stubs.SubscribeMapped(&m.events.Turn, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleTurnEvent) string {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureTurnCallSpy starts watching for Turn spy calls and sends them into a channel.
-func (m *mockVehicle) captureTurnCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getTurnCalls, timeout)
-		ch <- m.getTurnCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureTurnCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Turn, "Turn spy call", timeout)
+}
+
+// waitForTurnCalls waits until at least n Turn spy calls are recorded and returns them
+func (m *mockVehicle) waitForTurnCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Turn, n, timeout)
+}
+
+// waitForTurnCallWithin waits until a Turn spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForTurnCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Turn, match, timeout)
+}
+
+// eventuallyTurnCalls waits until cond holds for the recorded Turn spy calls
+func (m *mockVehicle) eventuallyTurnCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Turn, cond, timeout)
+}
+
+// waitForTurnCallWithArgs waits until Turn is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForTurnCallWithArgs(t stubs.TB, timeout time.Duration, wantDir string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.Turn, timeout, wantDir)
+}
+
+// consistentlyTurnNotCalled fails the test if Turn is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyTurnNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Turn, window)
+}
+
+// setTurnPanic makes every mocked call to Turn panic with v
+func (m *mockVehicle) setTurnPanic(v any) {
+	m.mocked.Turn.SetPanicResponse(v)
+}
+
+// enqueueTurnPanic enqueues a response for Turn that panics with v
+func (m *mockVehicle) enqueueTurnPanic(v any) {
+	m.mocked.Turn.EnqueuePanic(v)
+}
+
+// injectTurnFaults applies a seeded fault policy to Turn. Turn has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectTurnFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectTurnFaults: Turn has no error output to inject errors into")
+	}
+	m.mocked.Turn.SetFaults(policy)
+}
+
+// clearTurnFaults removes the fault policy from Turn
+func (m *mockVehicle) clearTurnFaults() {
+	m.mocked.Turn.ClearFaults()
 }
 
-type mockVehicleTurnResult struct {
+// mockVehicleTurnArgs holds the arguments of a call to Turn
+type mockVehicleTurnArgs struct {
+	Dir string
+}
+
+// mockVehicleTurnEvent is published to subscribers on every call to Turn
+type mockVehicleTurnEvent struct {
+	Args   mockVehicleTurnArgs
+	Result string
+}
+
+// mockVehicleTurnCall is a typed record of a call to Turn. Outputs are zero until the call returns.
+type mockVehicleTurnCall struct {
+	Dir     string
 	Output0 string
 }
 
+// getTurnTypedCalls returns the spied calls to Turn with typed arguments and results
+func (m *mockVehicle) getTurnTypedCalls() []mockVehicleTurnCall {
+	calls := m.mocked.Turn.Calls()
+	typed := make([]mockVehicleTurnCall, len(calls))
+	for i, call := range calls {
+		typed[i].Dir, _ = call.Args[0].(string)
+		if call.Returned {
+			typed[i].Output0, _ = call.Results[0].(string)
+		}
+	}
+	return typed
+}
+
 // setTurnResponse sets the response for Turn
 func (m *mockVehicle) setTurnResponse(output0 string) {
 	m.setTurnFunc(func(string) string {
@@ -257,16 +1133,15 @@ func (m *mockVehicle) enqueueTurnResponse(output0 string) {
 // enqueueTurnResponseWithDelay enqueues a static response with delay for Turn
 func (m *mockVehicle) enqueueTurnResponseWithDelay(output0 string, d time.Duration) {
 	m.mocked.Turn.EnqueueWithDelay(func(string) string {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Reverse Mock Helpers --------------------------- */
 
-// enableReverseMock turns the spy on
+// enableReverseSpy turns the spy on
 func (m *mockVehicle) enableReverseSpy() {
-	m.mocked.Reverse.SpyEnabled = true
+	m.mocked.Reverse.EnableSpy()
 }
 
 // getReverseCalls returns recorded calls to Reverse
@@ -274,55 +1149,114 @@ func (m *mockVehicle) getReverseCalls() []stubs.MethodCall {
 	return m.mocked.Reverse.Calls()
 }
 
-// enableReverseMock turns the spy off
+// enableReverseSpy turns the spy off
 func (m *mockVehicle) disableReverseSpy() {
-	m.mocked.Reverse.SpyEnabled = false
+	m.mocked.Reverse.DisableSpy()
+}
+
+// disableReverseArgCloning records arguments and results of Reverse by reference instead of deep copying them
+func (m *mockVehicle) disableReverseArgCloning() {
+	m.mocked.Reverse.DisableArgCloning()
+}
+
+// enableReverseArgCloning deep copies arguments and results of Reverse as they are recorded
+func (m *mockVehicle) enableReverseArgCloning() {
+	m.mocked.Reverse.EnableArgCloning()
 }
 
 // Reverse overrides the method to return the mock response
 func (m *mockVehicle) Reverse() (string, error) {
-	m.mocked.Reverse.RecordCall()
+	callID := m.mocked.Reverse.RecordCall()
+	defer m.mocked.Reverse.EndCall(callID)
+	callArgs := mockVehicleReverseArgs{}
+	m.hooks.Reverse.RunBefore(callArgs)
 	var (
 		out0 string
 		out1 error
-
-		result mockVehicleReverseResult
 	)
 
-	if m.mocked.Reverse.Enabled {
+	if gateErr := m.mocked.Reverse.PassGate(); gateErr != nil {
+		out1 = gateErr
+	} else if faultErr := m.mocked.Reverse.ApplyFault(); faultErr != nil {
+		out1 = faultErr
+	} else if m.mocked.Reverse.IsEnabled() {
 		out0, out1 = m.mocked.Reverse.NextResponse(func() (string, error) {
 			return m.real.Reverse()
 		})()
-
 	} else {
 		out0, out1 = m.real.Reverse()
-
 	}
 
-	result = mockVehicleReverseResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["Reverse"]; ok {
-		chTyped := ch.(chan mockVehicleReverseResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.Reverse.RecordResults(callID, out0, out1)
+	callResult := mockVehicleReverseResult{Location: out0, Err: out1}
+	m.hooks.Reverse.RunAfter(callArgs, callResult)
+	m.events.Reverse.Publish(mockVehicleReverseEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onReverseBefore runs fn on every call to Reverse before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onReverseBefore(fn func(args mockVehicleReverseArgs)) func() {
+	return m.hooks.Reverse.Before(fn)
+}
+
+// onReverseAfter runs fn once each call to Reverse has its result. The returned func removes the hook.
+func (m *mockVehicle) onReverseAfter(fn func(args mockVehicleReverseArgs, result mockVehicleReverseResult)) func() {
+	return m.hooks.Reverse.After(fn)
+}
+
+// notifyReverseCalled returns a channel that is closed when Reverse is next called, before it responds
+func (m *mockVehicle) notifyReverseCalled() <-chan struct{} {
+	return m.hooks.Reverse.Notify()
+}
+
+// blockReverse holds every later call to Reverse mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockReverse() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Reverse.SetGate(g)
+	return g
+}
+
+// unblockReverse removes the gate set by blockReverse, releasing any held calls
+func (m *mockVehicle) unblockReverse() {
+	m.mocked.Reverse.ClearGate()
+}
+
+// assertReverseMaxConcurrency fails the test if more than n calls to Reverse were ever in flight at once
+func (m *mockVehicle) assertReverseMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Reverse, n)
+}
+
+// assertReverseNeverConcurrent fails the test if any two calls to Reverse were in flight at the same time
+func (m *mockVehicle) assertReverseNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Reverse)
+}
 
+// getReverseConcurrencyStats returns the in-flight counts and overlapping calls to Reverse
+func (m *mockVehicle) getReverseConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Reverse.ConcurrencyStats()
 }
 
 // setReverseFunc sets the function for Reverse
 func (m *mockVehicle) setReverseFunc(f func() (string, error)) {
-	m.mocked.Reverse.Fallback = f
+	m.mocked.Reverse.SetResponseFunc(f)
 }
 
 // enableReverseMock turns the mock on
 func (m *mockVehicle) enableReverseMock() {
-	m.mocked.Reverse.Enabled = true
+	m.mocked.Reverse.Enable()
 }
 
 // disableReverseMock turns the mock off
 func (m *mockVehicle) disableReverseMock() {
-	m.mocked.Reverse.Enabled = false
+	m.mocked.Reverse.Disable()
 }
 
 // enqueueReverseResponseFunc enqueues a function response for Reverse
@@ -335,26 +1269,116 @@ func (m *mockVehicle) enqueueReverseResponseFuncWithDelay(f func() (string, erro
 	m.mocked.Reverse.EnqueueWithDelay(f, d)
 }
 
-// captureReverseResult sets up a channel to capture Reverse results.
-func (m *mockVehicle) captureReverseResult() <-chan mockVehicleReverseResult {
-	ch := make(chan mockVehicleReverseResult, 1)
-	m.responseChans["Reverse"] = ch
-	return ch
+// subscribeReverse returns a subscription that receives an event for every call to Reverse. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeReverse(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleReverseEvent] {
+	return m.events.Reverse.Subscribe(opts)
+}
+
+// captureReverseResult returns a channel that receives the result of every call to Reverse.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureReverseResult(t stubs.TB) <-chan mockVehicleReverseResult {
+	sub := stubs.SubscribeMapped(&m.events.Reverse, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleReverseEvent) mockVehicleReverseResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureReverseCallSpy starts watching for Reverse spy calls and sends them into a channel.
-func (m *mockVehicle) captureReverseCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getReverseCalls, timeout)
-		ch <- m.getReverseCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureReverseCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Reverse, "Reverse spy call", timeout)
+}
+
+// waitForReverseCalls waits until at least n Reverse spy calls are recorded and returns them
+func (m *mockVehicle) waitForReverseCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Reverse, n, timeout)
+}
+
+// waitForReverseCallWithin waits until a Reverse spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForReverseCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Reverse, match, timeout)
+}
+
+// eventuallyReverseCalls waits until cond holds for the recorded Reverse spy calls
+func (m *mockVehicle) eventuallyReverseCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Reverse, cond, timeout)
+}
+
+// consistentlyReverseNotCalled fails the test if Reverse is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyReverseNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Reverse, window)
+}
+
+// setReversePanic makes every mocked call to Reverse panic with v
+func (m *mockVehicle) setReversePanic(v any) {
+	m.mocked.Reverse.SetPanicResponse(v)
+}
+
+// enqueueReversePanic enqueues a response for Reverse that panics with v
+func (m *mockVehicle) enqueueReversePanic(v any) {
+	m.mocked.Reverse.EnqueuePanic(v)
+}
+
+// injectReverseFaults applies a seeded fault policy to Reverse. Injected errors are returned in output 1.
+func (m *mockVehicle) injectReverseFaults(policy stubs.FaultPolicy) {
+	m.mocked.Reverse.SetFaults(policy)
+}
+
+// clearReverseFaults removes the fault policy from Reverse
+func (m *mockVehicle) clearReverseFaults() {
+	m.mocked.Reverse.ClearFaults()
 }
 
+// mockVehicleReverseResult holds the outputs of a call to Reverse
 type mockVehicleReverseResult struct {
-	Output0 string
-	Output1 error
+	Location string
+	Err      error
+}
+
+// Values returns the outputs in the order Reverse returns them
+func (r mockVehicleReverseResult) Values() (string, error) {
+	return r.Location, r.Err
+}
+
+// ReturnedErr returns the error output
+func (r mockVehicleReverseResult) ReturnedErr() error {
+	return r.Err
+}
+
+// mockVehicleReverseArgs holds the arguments of a call to Reverse
+type mockVehicleReverseArgs struct {
+}
+
+// mockVehicleReverseEvent is published to subscribers on every call to Reverse
+type mockVehicleReverseEvent struct {
+	Args   mockVehicleReverseArgs
+	Result mockVehicleReverseResult
+}
+
+// mockVehicleReverseCall is a typed record of a call to Reverse. Outputs are zero until the call returns.
+type mockVehicleReverseCall struct {
+	Location string
+	Err      error
+}
+
+// getReverseTypedCalls returns the spied calls to Reverse with typed arguments and results
+func (m *mockVehicle) getReverseTypedCalls() []mockVehicleReverseCall {
+	calls := m.mocked.Reverse.Calls()
+	typed := make([]mockVehicleReverseCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Location, _ = call.Results[0].(string)
+			typed[i].Err, _ = call.Results[1].(error)
+		}
+	}
+	return typed
 }
 
 // setReverseResponse sets the response for Reverse
@@ -374,16 +1398,15 @@ func (m *mockVehicle) enqueueReverseResponse(output0 string, output1 error) {
 // enqueueReverseResponseWithDelay enqueues a static response with delay for Reverse
 func (m *mockVehicle) enqueueReverseResponseWithDelay(output0 string, output1 error, d time.Duration) {
 	m.mocked.Reverse.EnqueueWithDelay(func() (string, error) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- IsMoving Mock Helpers --------------------------- */
 
-// enableIsMovingMock turns the spy on
+// enableIsMovingSpy turns the spy on
 func (m *mockVehicle) enableIsMovingSpy() {
-	m.mocked.IsMoving.SpyEnabled = true
+	m.mocked.IsMoving.EnableSpy()
 }
 
 // getIsMovingCalls returns recorded calls to IsMoving
@@ -391,49 +1414,115 @@ func (m *mockVehicle) getIsMovingCalls() []stubs.MethodCall {
 	return m.mocked.IsMoving.Calls()
 }
 
-// enableIsMovingMock turns the spy off
+// enableIsMovingSpy turns the spy off
 func (m *mockVehicle) disableIsMovingSpy() {
-	m.mocked.IsMoving.SpyEnabled = false
+	m.mocked.IsMoving.DisableSpy()
+}
+
+// disableIsMovingArgCloning records arguments and results of IsMoving by reference instead of deep copying them
+func (m *mockVehicle) disableIsMovingArgCloning() {
+	m.mocked.IsMoving.DisableArgCloning()
+}
+
+// enableIsMovingArgCloning deep copies arguments and results of IsMoving as they are recorded
+func (m *mockVehicle) enableIsMovingArgCloning() {
+	m.mocked.IsMoving.EnableArgCloning()
 }
 
 // IsMoving overrides the method to return the mock response
 func (m *mockVehicle) IsMoving() bool {
-	m.mocked.IsMoving.RecordCall()
+	callID := m.mocked.IsMoving.RecordCall()
+	defer m.mocked.IsMoving.EndCall(callID)
+	callArgs := mockVehicleIsMovingArgs{}
+	m.hooks.IsMoving.RunBefore(callArgs)
 	var (
 		out0 bool
 	)
 
-	if m.mocked.IsMoving.Enabled {
+	gatePassed := m.mocked.IsMoving.PassGateOrReport()
+	if gatePassed {
+		m.mocked.IsMoving.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.IsMoving.IsEnabled() {
 		out0 = m.mocked.IsMoving.NextResponse(func() bool {
 			return m.real.IsMoving()
 		})()
-
 	} else {
 		out0 = m.real.IsMoving()
-
 	}
 
-	if ch, ok := m.responseChans["IsMoving"]; ok {
-		chTyped := ch.(chan bool)
-		chTyped <- out0
-	}
+	m.mocked.IsMoving.RecordResults(callID, out0)
+	m.hooks.IsMoving.RunAfter(callArgs, out0)
+	m.events.IsMoving.Publish(mockVehicleIsMovingEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onIsMovingBefore runs fn on every call to IsMoving before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onIsMovingBefore(fn func(args mockVehicleIsMovingArgs)) func() {
+	return m.hooks.IsMoving.Before(fn)
+}
+
+// onIsMovingAfter runs fn once each call to IsMoving has its result. The returned func removes the hook.
+func (m *mockVehicle) onIsMovingAfter(fn func(args mockVehicleIsMovingArgs, result bool)) func() {
+	return m.hooks.IsMoving.After(fn)
+}
 
+// notifyIsMovingCalled returns a channel that is closed when IsMoving is next called, before it responds
+func (m *mockVehicle) notifyIsMovingCalled() <-chan struct{} {
+	return m.hooks.IsMoving.Notify()
+}
+
+// blockIsMoving holds every later call to IsMoving mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockIsMoving() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.IsMoving.SetGate(g)
+	return g
+}
+
+// unblockIsMoving removes the gate set by blockIsMoving, releasing any held calls
+func (m *mockVehicle) unblockIsMoving() {
+	m.mocked.IsMoving.ClearGate()
+}
+
+// assertIsMovingMaxConcurrency fails the test if more than n calls to IsMoving were ever in flight at once
+func (m *mockVehicle) assertIsMovingMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.IsMoving, n)
+}
+
+// assertIsMovingNeverConcurrent fails the test if any two calls to IsMoving were in flight at the same time
+func (m *mockVehicle) assertIsMovingNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.IsMoving)
+}
+
+// getIsMovingConcurrencyStats returns the in-flight counts and overlapping calls to IsMoving
+func (m *mockVehicle) getIsMovingConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.IsMoving.ConcurrencyStats()
 }
 
 // setIsMovingFunc sets the function for IsMoving
 func (m *mockVehicle) setIsMovingFunc(f func() bool) {
-	m.mocked.IsMoving.Fallback = f
+	m.mocked.IsMoving.SetResponseFunc(f)
 }
 
 // enableIsMovingMock turns the mock on
 func (m *mockVehicle) enableIsMovingMock() {
-	m.mocked.IsMoving.Enabled = true
+	m.mocked.IsMoving.Enable()
 }
 
 // disableIsMovingMock turns the mock off
 func (m *mockVehicle) disableIsMovingMock() {
-	m.mocked.IsMoving.Enabled = false
+	m.mocked.IsMoving.Disable()
 }
 
 // enqueueIsMovingResponseFunc enqueues a function response for IsMoving
@@ -446,25 +1535,101 @@ func (m *mockVehicle) enqueueIsMovingResponseFuncWithDelay(f func() bool, d time
 	m.mocked.IsMoving.EnqueueWithDelay(f, d)
 }
 
-// captureIsMovingResult sets up a channel to capture IsMoving results.
-func (m *mockVehicle) captureIsMovingResult() <-chan bool {
-	ch := make(chan bool, 1)
-	m.responseChans["IsMoving"] = ch
-	return ch
+// subscribeIsMoving returns a subscription that receives an event for every call to IsMoving. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeIsMoving(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleIsMovingEvent] {
+	return m.events.IsMoving.Subscribe(opts)
+}
+
+// captureIsMovingResult returns a channel that receives the result of every call to IsMoving.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureIsMovingResult(t stubs.TB) <-chan bool {
+	sub := stubs.SubscribeMapped(&m.events.IsMoving, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleIsMovingEvent) bool {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureIsMovingCallSpy starts watching for IsMoving spy calls and sends them into a channel.
-func (m *mockVehicle) captureIsMovingCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getIsMovingCalls, timeout)
-		ch <- m.getIsMovingCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureIsMovingCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.IsMoving, "IsMoving spy call", timeout)
+}
+
+// waitForIsMovingCalls waits until at least n IsMoving spy calls are recorded and returns them
+func (m *mockVehicle) waitForIsMovingCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.IsMoving, n, timeout)
+}
+
+// waitForIsMovingCallWithin waits until a IsMoving spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForIsMovingCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.IsMoving, match, timeout)
+}
+
+// eventuallyIsMovingCalls waits until cond holds for the recorded IsMoving spy calls
+func (m *mockVehicle) eventuallyIsMovingCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.IsMoving, cond, timeout)
+}
+
+// consistentlyIsMovingNotCalled fails the test if IsMoving is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyIsMovingNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.IsMoving, window)
+}
+
+// setIsMovingPanic makes every mocked call to IsMoving panic with v
+func (m *mockVehicle) setIsMovingPanic(v any) {
+	m.mocked.IsMoving.SetPanicResponse(v)
+}
+
+// enqueueIsMovingPanic enqueues a response for IsMoving that panics with v
+func (m *mockVehicle) enqueueIsMovingPanic(v any) {
+	m.mocked.IsMoving.EnqueuePanic(v)
+}
+
+// injectIsMovingFaults applies a seeded fault policy to IsMoving. IsMoving has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectIsMovingFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectIsMovingFaults: IsMoving has no error output to inject errors into")
+	}
+	m.mocked.IsMoving.SetFaults(policy)
+}
+
+// clearIsMovingFaults removes the fault policy from IsMoving
+func (m *mockVehicle) clearIsMovingFaults() {
+	m.mocked.IsMoving.ClearFaults()
+}
+
+// mockVehicleIsMovingArgs holds the arguments of a call to IsMoving
+type mockVehicleIsMovingArgs struct {
 }
 
-type mockVehicleIsMovingResult struct {
-	Output0 bool
+// mockVehicleIsMovingEvent is published to subscribers on every call to IsMoving
+type mockVehicleIsMovingEvent struct {
+	Args   mockVehicleIsMovingArgs
+	Result bool
+}
+
+// mockVehicleIsMovingCall is a typed record of a call to IsMoving. Outputs are zero until the call returns.
+type mockVehicleIsMovingCall struct {
+	IsMoving bool
+}
+
+// getIsMovingTypedCalls returns the spied calls to IsMoving with typed arguments and results
+func (m *mockVehicle) getIsMovingTypedCalls() []mockVehicleIsMovingCall {
+	calls := m.mocked.IsMoving.Calls()
+	typed := make([]mockVehicleIsMovingCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].IsMoving, _ = call.Results[0].(bool)
+		}
+	}
+	return typed
 }
 
 // setIsMovingResponse sets the response for IsMoving
@@ -484,16 +1649,15 @@ func (m *mockVehicle) enqueueIsMovingResponse(output0 bool) {
 // enqueueIsMovingResponseWithDelay enqueues a static response with delay for IsMoving
 func (m *mockVehicle) enqueueIsMovingResponseWithDelay(output0 bool, d time.Duration) {
 	m.mocked.IsMoving.EnqueueWithDelay(func() bool {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- GetEngineSpecs Mock Helpers --------------------------- */
 
-// enableGetEngineSpecsMock turns the spy on
+// enableGetEngineSpecsSpy turns the spy on
 func (m *mockVehicle) enableGetEngineSpecsSpy() {
-	m.mocked.GetEngineSpecs.SpyEnabled = true
+	m.mocked.GetEngineSpecs.EnableSpy()
 }
 
 // getGetEngineSpecsCalls returns recorded calls to GetEngineSpecs
@@ -501,55 +1665,117 @@ func (m *mockVehicle) getGetEngineSpecsCalls() []stubs.MethodCall {
 	return m.mocked.GetEngineSpecs.Calls()
 }
 
-// enableGetEngineSpecsMock turns the spy off
+// enableGetEngineSpecsSpy turns the spy off
 func (m *mockVehicle) disableGetEngineSpecsSpy() {
-	m.mocked.GetEngineSpecs.SpyEnabled = false
+	m.mocked.GetEngineSpecs.DisableSpy()
+}
+
+// disableGetEngineSpecsArgCloning records arguments and results of GetEngineSpecs by reference instead of deep copying them
+func (m *mockVehicle) disableGetEngineSpecsArgCloning() {
+	m.mocked.GetEngineSpecs.DisableArgCloning()
+}
+
+// enableGetEngineSpecsArgCloning deep copies arguments and results of GetEngineSpecs as they are recorded
+func (m *mockVehicle) enableGetEngineSpecsArgCloning() {
+	m.mocked.GetEngineSpecs.EnableArgCloning()
 }
 
 // GetEngineSpecs overrides the method to return the mock response
 func (m *mockVehicle) GetEngineSpecs() (int, string) {
-	m.mocked.GetEngineSpecs.RecordCall()
+	callID := m.mocked.GetEngineSpecs.RecordCall()
+	defer m.mocked.GetEngineSpecs.EndCall(callID)
+	callArgs := mockVehicleGetEngineSpecsArgs{}
+	m.hooks.GetEngineSpecs.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 string
-
-		result mockVehicleGetEngineSpecsResult
 	)
 
-	if m.mocked.GetEngineSpecs.Enabled {
+	gatePassed := m.mocked.GetEngineSpecs.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetEngineSpecs.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetEngineSpecs.IsEnabled() {
 		out0, out1 = m.mocked.GetEngineSpecs.NextResponse(func() (int, string) {
 			return m.real.GetEngineSpecs()
 		})()
-
 	} else {
 		out0, out1 = m.real.GetEngineSpecs()
-
 	}
 
-	result = mockVehicleGetEngineSpecsResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["GetEngineSpecs"]; ok {
-		chTyped := ch.(chan mockVehicleGetEngineSpecsResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.GetEngineSpecs.RecordResults(callID, out0, out1)
+	callResult := mockVehicleGetEngineSpecsResult{Power: out0, FuelType: out1}
+	m.hooks.GetEngineSpecs.RunAfter(callArgs, callResult)
+	m.events.GetEngineSpecs.Publish(mockVehicleGetEngineSpecsEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onGetEngineSpecsBefore runs fn on every call to GetEngineSpecs before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onGetEngineSpecsBefore(fn func(args mockVehicleGetEngineSpecsArgs)) func() {
+	return m.hooks.GetEngineSpecs.Before(fn)
+}
+
+// onGetEngineSpecsAfter runs fn once each call to GetEngineSpecs has its result. The returned func removes the hook.
+func (m *mockVehicle) onGetEngineSpecsAfter(fn func(args mockVehicleGetEngineSpecsArgs, result mockVehicleGetEngineSpecsResult)) func() {
+	return m.hooks.GetEngineSpecs.After(fn)
+}
+
+// notifyGetEngineSpecsCalled returns a channel that is closed when GetEngineSpecs is next called, before it responds
+func (m *mockVehicle) notifyGetEngineSpecsCalled() <-chan struct{} {
+	return m.hooks.GetEngineSpecs.Notify()
+}
+
+// blockGetEngineSpecs holds every later call to GetEngineSpecs mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockGetEngineSpecs() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetEngineSpecs.SetGate(g)
+	return g
+}
+
+// unblockGetEngineSpecs removes the gate set by blockGetEngineSpecs, releasing any held calls
+func (m *mockVehicle) unblockGetEngineSpecs() {
+	m.mocked.GetEngineSpecs.ClearGate()
+}
+
+// assertGetEngineSpecsMaxConcurrency fails the test if more than n calls to GetEngineSpecs were ever in flight at once
+func (m *mockVehicle) assertGetEngineSpecsMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetEngineSpecs, n)
+}
+
+// assertGetEngineSpecsNeverConcurrent fails the test if any two calls to GetEngineSpecs were in flight at the same time
+func (m *mockVehicle) assertGetEngineSpecsNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetEngineSpecs)
+}
 
+// getGetEngineSpecsConcurrencyStats returns the in-flight counts and overlapping calls to GetEngineSpecs
+func (m *mockVehicle) getGetEngineSpecsConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetEngineSpecs.ConcurrencyStats()
 }
 
 // setGetEngineSpecsFunc sets the function for GetEngineSpecs
 func (m *mockVehicle) setGetEngineSpecsFunc(f func() (int, string)) {
-	m.mocked.GetEngineSpecs.Fallback = f
+	m.mocked.GetEngineSpecs.SetResponseFunc(f)
 }
 
 // enableGetEngineSpecsMock turns the mock on
 func (m *mockVehicle) enableGetEngineSpecsMock() {
-	m.mocked.GetEngineSpecs.Enabled = true
+	m.mocked.GetEngineSpecs.Enable()
 }
 
 // disableGetEngineSpecsMock turns the mock off
 func (m *mockVehicle) disableGetEngineSpecsMock() {
-	m.mocked.GetEngineSpecs.Enabled = false
+	m.mocked.GetEngineSpecs.Disable()
 }
 
 // enqueueGetEngineSpecsResponseFunc enqueues a function response for GetEngineSpecs
@@ -562,26 +1788,114 @@ func (m *mockVehicle) enqueueGetEngineSpecsResponseFuncWithDelay(f func() (int,
 	m.mocked.GetEngineSpecs.EnqueueWithDelay(f, d)
 }
 
-// captureGetEngineSpecsResult sets up a channel to capture GetEngineSpecs results.
-func (m *mockVehicle) captureGetEngineSpecsResult() <-chan mockVehicleGetEngineSpecsResult {
-	ch := make(chan mockVehicleGetEngineSpecsResult, 1)
-	m.responseChans["GetEngineSpecs"] = ch
-	return ch
+// subscribeGetEngineSpecs returns a subscription that receives an event for every call to GetEngineSpecs. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeGetEngineSpecs(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleGetEngineSpecsEvent] {
+	return m.events.GetEngineSpecs.Subscribe(opts)
+}
+
+// captureGetEngineSpecsResult returns a channel that receives the result of every call to GetEngineSpecs.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureGetEngineSpecsResult(t stubs.TB) <-chan mockVehicleGetEngineSpecsResult {
+	sub := stubs.SubscribeMapped(&m.events.GetEngineSpecs, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleGetEngineSpecsEvent) mockVehicleGetEngineSpecsResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetEngineSpecsCallSpy starts watching for GetEngineSpecs spy calls and sends them into a channel.
-func (m *mockVehicle) captureGetEngineSpecsCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetEngineSpecsCalls, timeout)
-		ch <- m.getGetEngineSpecsCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureGetEngineSpecsCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetEngineSpecs, "GetEngineSpecs spy call", timeout)
+}
+
+// waitForGetEngineSpecsCalls waits until at least n GetEngineSpecs spy calls are recorded and returns them
+func (m *mockVehicle) waitForGetEngineSpecsCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetEngineSpecs, n, timeout)
+}
+
+// waitForGetEngineSpecsCallWithin waits until a GetEngineSpecs spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForGetEngineSpecsCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetEngineSpecs, match, timeout)
+}
+
+// eventuallyGetEngineSpecsCalls waits until cond holds for the recorded GetEngineSpecs spy calls
+func (m *mockVehicle) eventuallyGetEngineSpecsCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetEngineSpecs, cond, timeout)
+}
+
+// consistentlyGetEngineSpecsNotCalled fails the test if GetEngineSpecs is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyGetEngineSpecsNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetEngineSpecs, window)
+}
+
+// setGetEngineSpecsPanic makes every mocked call to GetEngineSpecs panic with v
+func (m *mockVehicle) setGetEngineSpecsPanic(v any) {
+	m.mocked.GetEngineSpecs.SetPanicResponse(v)
+}
+
+// enqueueGetEngineSpecsPanic enqueues a response for GetEngineSpecs that panics with v
+func (m *mockVehicle) enqueueGetEngineSpecsPanic(v any) {
+	m.mocked.GetEngineSpecs.EnqueuePanic(v)
+}
+
+// injectGetEngineSpecsFaults applies a seeded fault policy to GetEngineSpecs. GetEngineSpecs has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectGetEngineSpecsFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetEngineSpecsFaults: GetEngineSpecs has no error output to inject errors into")
+	}
+	m.mocked.GetEngineSpecs.SetFaults(policy)
+}
+
+// clearGetEngineSpecsFaults removes the fault policy from GetEngineSpecs
+func (m *mockVehicle) clearGetEngineSpecsFaults() {
+	m.mocked.GetEngineSpecs.ClearFaults()
 }
 
+// mockVehicleGetEngineSpecsResult holds the outputs of a call to GetEngineSpecs
 type mockVehicleGetEngineSpecsResult struct {
-	Output0 int
-	Output1 string
+	Power    int
+	FuelType string
+}
+
+// Values returns the outputs in the order GetEngineSpecs returns them
+func (r mockVehicleGetEngineSpecsResult) Values() (int, string) {
+	return r.Power, r.FuelType
+}
+
+// mockVehicleGetEngineSpecsArgs holds the arguments of a call to GetEngineSpecs
+type mockVehicleGetEngineSpecsArgs struct {
+}
+
+// mockVehicleGetEngineSpecsEvent is published to subscribers on every call to GetEngineSpecs
+type mockVehicleGetEngineSpecsEvent struct {
+	Args   mockVehicleGetEngineSpecsArgs
+	Result mockVehicleGetEngineSpecsResult
+}
+
+// mockVehicleGetEngineSpecsCall is a typed record of a call to GetEngineSpecs. Outputs are zero until the call returns.
+type mockVehicleGetEngineSpecsCall struct {
+	Power    int
+	FuelType string
+}
+
+// getGetEngineSpecsTypedCalls returns the spied calls to GetEngineSpecs with typed arguments and results
+func (m *mockVehicle) getGetEngineSpecsTypedCalls() []mockVehicleGetEngineSpecsCall {
+	calls := m.mocked.GetEngineSpecs.Calls()
+	typed := make([]mockVehicleGetEngineSpecsCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Power, _ = call.Results[0].(int)
+			typed[i].FuelType, _ = call.Results[1].(string)
+		}
+	}
+	return typed
 }
 
 // setGetEngineSpecsResponse sets the response for GetEngineSpecs
@@ -601,16 +1915,15 @@ func (m *mockVehicle) enqueueGetEngineSpecsResponse(output0 int, output1 string)
 // enqueueGetEngineSpecsResponseWithDelay enqueues a static response with delay for GetEngineSpecs
 func (m *mockVehicle) enqueueGetEngineSpecsResponseWithDelay(output0 int, output1 string, d time.Duration) {
 	m.mocked.GetEngineSpecs.EnqueueWithDelay(func() (int, string) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- ApplyBrakes Mock Helpers --------------------------- */
 
-// enableApplyBrakesMock turns the spy on
+// enableApplyBrakesSpy turns the spy on
 func (m *mockVehicle) enableApplyBrakesSpy() {
-	m.mocked.ApplyBrakes.SpyEnabled = true
+	m.mocked.ApplyBrakes.EnableSpy()
 }
 
 // getApplyBrakesCalls returns recorded calls to ApplyBrakes
@@ -618,49 +1931,115 @@ func (m *mockVehicle) getApplyBrakesCalls() []stubs.MethodCall {
 	return m.mocked.ApplyBrakes.Calls()
 }
 
-// enableApplyBrakesMock turns the spy off
+// enableApplyBrakesSpy turns the spy off
 func (m *mockVehicle) disableApplyBrakesSpy() {
-	m.mocked.ApplyBrakes.SpyEnabled = false
+	m.mocked.ApplyBrakes.DisableSpy()
+}
+
+// disableApplyBrakesArgCloning records arguments and results of ApplyBrakes by reference instead of deep copying them
+func (m *mockVehicle) disableApplyBrakesArgCloning() {
+	m.mocked.ApplyBrakes.DisableArgCloning()
+}
+
+// enableApplyBrakesArgCloning deep copies arguments and results of ApplyBrakes as they are recorded
+func (m *mockVehicle) enableApplyBrakesArgCloning() {
+	m.mocked.ApplyBrakes.EnableArgCloning()
 }
 
 // ApplyBrakes overrides the method to return the mock response
 func (m *mockVehicle) ApplyBrakes(force float64) bool {
-	m.mocked.ApplyBrakes.RecordCall(force)
+	callID := m.mocked.ApplyBrakes.RecordCall(force)
+	defer m.mocked.ApplyBrakes.EndCall(callID)
+	callArgs := mockVehicleApplyBrakesArgs{Force: force}
+	m.hooks.ApplyBrakes.RunBefore(callArgs)
 	var (
 		out0 bool
 	)
 
-	if m.mocked.ApplyBrakes.Enabled {
+	gatePassed := m.mocked.ApplyBrakes.PassGateOrReport()
+	if gatePassed {
+		m.mocked.ApplyBrakes.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.ApplyBrakes.IsEnabled() {
 		out0 = m.mocked.ApplyBrakes.NextResponse(func(force float64) bool {
 			return m.real.ApplyBrakes(force)
 		})(force)
-
 	} else {
 		out0 = m.real.ApplyBrakes(force)
-
 	}
 
-	if ch, ok := m.responseChans["ApplyBrakes"]; ok {
-		chTyped := ch.(chan bool)
-		chTyped <- out0
-	}
+	m.mocked.ApplyBrakes.RecordResults(callID, out0)
+	m.hooks.ApplyBrakes.RunAfter(callArgs, out0)
+	m.events.ApplyBrakes.Publish(mockVehicleApplyBrakesEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onApplyBrakesBefore runs fn on every call to ApplyBrakes before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onApplyBrakesBefore(fn func(args mockVehicleApplyBrakesArgs)) func() {
+	return m.hooks.ApplyBrakes.Before(fn)
+}
+
+// onApplyBrakesAfter runs fn once each call to ApplyBrakes has its result. The returned func removes the hook.
+func (m *mockVehicle) onApplyBrakesAfter(fn func(args mockVehicleApplyBrakesArgs, result bool)) func() {
+	return m.hooks.ApplyBrakes.After(fn)
+}
+
+// notifyApplyBrakesCalled returns a channel that is closed when ApplyBrakes is next called, before it responds
+func (m *mockVehicle) notifyApplyBrakesCalled() <-chan struct{} {
+	return m.hooks.ApplyBrakes.Notify()
+}
+
+// blockApplyBrakes holds every later call to ApplyBrakes mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockApplyBrakes() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.ApplyBrakes.SetGate(g)
+	return g
+}
+
+// unblockApplyBrakes removes the gate set by blockApplyBrakes, releasing any held calls
+func (m *mockVehicle) unblockApplyBrakes() {
+	m.mocked.ApplyBrakes.ClearGate()
+}
+
+// assertApplyBrakesMaxConcurrency fails the test if more than n calls to ApplyBrakes were ever in flight at once
+func (m *mockVehicle) assertApplyBrakesMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.ApplyBrakes, n)
+}
+
+// assertApplyBrakesNeverConcurrent fails the test if any two calls to ApplyBrakes were in flight at the same time
+func (m *mockVehicle) assertApplyBrakesNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.ApplyBrakes)
+}
 
+// getApplyBrakesConcurrencyStats returns the in-flight counts and overlapping calls to ApplyBrakes
+func (m *mockVehicle) getApplyBrakesConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.ApplyBrakes.ConcurrencyStats()
 }
 
 // setApplyBrakesFunc sets the function for ApplyBrakes
 func (m *mockVehicle) setApplyBrakesFunc(f func(float64) bool) {
-	m.mocked.ApplyBrakes.Fallback = f
+	m.mocked.ApplyBrakes.SetResponseFunc(f)
 }
 
 // enableApplyBrakesMock turns the mock on
 func (m *mockVehicle) enableApplyBrakesMock() {
-	m.mocked.ApplyBrakes.Enabled = true
+	m.mocked.ApplyBrakes.Enable()
 }
 
 // disableApplyBrakesMock turns the mock off
 func (m *mockVehicle) disableApplyBrakesMock() {
-	m.mocked.ApplyBrakes.Enabled = false
+	m.mocked.ApplyBrakes.Disable()
 }
 
 // enqueueApplyBrakesResponseFunc enqueues a function response for ApplyBrakes
@@ -673,25 +2052,111 @@ func (m *mockVehicle) enqueueApplyBrakesResponseFuncWithDelay(f func(float64) bo
 	m.mocked.ApplyBrakes.EnqueueWithDelay(f, d)
 }
 
-// captureApplyBrakesResult sets up a channel to capture ApplyBrakes results.
-func (m *mockVehicle) captureApplyBrakesResult() <-chan bool {
-	ch := make(chan bool, 1)
-	m.responseChans["ApplyBrakes"] = ch
-	return ch
+// subscribeApplyBrakes returns a subscription that receives an event for every call to ApplyBrakes. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeApplyBrakes(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleApplyBrakesEvent] {
+	return m.events.ApplyBrakes.Subscribe(opts)
+}
+
+// captureApplyBrakesResult returns a channel that receives the result of every call to ApplyBrakes.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureApplyBrakesResult(t stubs.TB) <-chan bool {
+	sub := stubs.SubscribeMapped(&m.events.ApplyBrakes, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleApplyBrakesEvent) bool {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureApplyBrakesCallSpy starts watching for ApplyBrakes spy calls and sends them into a channel.
-func (m *mockVehicle) captureApplyBrakesCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getApplyBrakesCalls, timeout)
-		ch <- m.getApplyBrakesCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureApplyBrakesCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.ApplyBrakes, "ApplyBrakes spy call", timeout)
+}
+
+// waitForApplyBrakesCalls waits until at least n ApplyBrakes spy calls are recorded and returns them
+func (m *mockVehicle) waitForApplyBrakesCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.ApplyBrakes, n, timeout)
+}
+
+// waitForApplyBrakesCallWithin waits until a ApplyBrakes spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForApplyBrakesCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.ApplyBrakes, match, timeout)
 }
 
-type mockVehicleApplyBrakesResult struct {
-	Output0 bool
+// eventuallyApplyBrakesCalls waits until cond holds for the recorded ApplyBrakes spy calls
+func (m *mockVehicle) eventuallyApplyBrakesCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.ApplyBrakes, cond, timeout)
+}
+
+// waitForApplyBrakesCallWithArgs waits until ApplyBrakes is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForApplyBrakesCallWithArgs(t stubs.TB, timeout time.Duration, wantForce float64) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.ApplyBrakes, timeout, wantForce)
+}
+
+// consistentlyApplyBrakesNotCalled fails the test if ApplyBrakes is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyApplyBrakesNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.ApplyBrakes, window)
+}
+
+// setApplyBrakesPanic makes every mocked call to ApplyBrakes panic with v
+func (m *mockVehicle) setApplyBrakesPanic(v any) {
+	m.mocked.ApplyBrakes.SetPanicResponse(v)
+}
+
+// enqueueApplyBrakesPanic enqueues a response for ApplyBrakes that panics with v
+func (m *mockVehicle) enqueueApplyBrakesPanic(v any) {
+	m.mocked.ApplyBrakes.EnqueuePanic(v)
+}
+
+// injectApplyBrakesFaults applies a seeded fault policy to ApplyBrakes. ApplyBrakes has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectApplyBrakesFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectApplyBrakesFaults: ApplyBrakes has no error output to inject errors into")
+	}
+	m.mocked.ApplyBrakes.SetFaults(policy)
+}
+
+// clearApplyBrakesFaults removes the fault policy from ApplyBrakes
+func (m *mockVehicle) clearApplyBrakesFaults() {
+	m.mocked.ApplyBrakes.ClearFaults()
+}
+
+// mockVehicleApplyBrakesArgs holds the arguments of a call to ApplyBrakes
+type mockVehicleApplyBrakesArgs struct {
+	Force float64
+}
+
+// mockVehicleApplyBrakesEvent is published to subscribers on every call to ApplyBrakes
+type mockVehicleApplyBrakesEvent struct {
+	Args   mockVehicleApplyBrakesArgs
+	Result bool
+}
+
+// mockVehicleApplyBrakesCall is a typed record of a call to ApplyBrakes. Outputs are zero until the call returns.
+type mockVehicleApplyBrakesCall struct {
+	Force   float64
+	Applied bool
+}
+
+// getApplyBrakesTypedCalls returns the spied calls to ApplyBrakes with typed arguments and results
+func (m *mockVehicle) getApplyBrakesTypedCalls() []mockVehicleApplyBrakesCall {
+	calls := m.mocked.ApplyBrakes.Calls()
+	typed := make([]mockVehicleApplyBrakesCall, len(calls))
+	for i, call := range calls {
+		typed[i].Force, _ = call.Args[0].(float64)
+		if call.Returned {
+			typed[i].Applied, _ = call.Results[0].(bool)
+		}
+	}
+	return typed
 }
 
 // setApplyBrakesResponse sets the response for ApplyBrakes
@@ -711,16 +2176,15 @@ func (m *mockVehicle) enqueueApplyBrakesResponse(output0 bool) {
 // enqueueApplyBrakesResponseWithDelay enqueues a static response with delay for ApplyBrakes
 func (m *mockVehicle) enqueueApplyBrakesResponseWithDelay(output0 bool, d time.Duration) {
 	m.mocked.ApplyBrakes.EnqueueWithDelay(func(float64) bool {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- ChangeGears Mock Helpers --------------------------- */
 
-// enableChangeGearsMock turns the spy on
+// enableChangeGearsSpy turns the spy on
 func (m *mockVehicle) enableChangeGearsSpy() {
-	m.mocked.ChangeGears.SpyEnabled = true
+	m.mocked.ChangeGears.EnableSpy()
 }
 
 // getChangeGearsCalls returns recorded calls to ChangeGears
@@ -728,55 +2192,117 @@ func (m *mockVehicle) getChangeGearsCalls() []stubs.MethodCall {
 	return m.mocked.ChangeGears.Calls()
 }
 
-// enableChangeGearsMock turns the spy off
+// enableChangeGearsSpy turns the spy off
 func (m *mockVehicle) disableChangeGearsSpy() {
-	m.mocked.ChangeGears.SpyEnabled = false
+	m.mocked.ChangeGears.DisableSpy()
+}
+
+// disableChangeGearsArgCloning records arguments and results of ChangeGears by reference instead of deep copying them
+func (m *mockVehicle) disableChangeGearsArgCloning() {
+	m.mocked.ChangeGears.DisableArgCloning()
+}
+
+// enableChangeGearsArgCloning deep copies arguments and results of ChangeGears as they are recorded
+func (m *mockVehicle) enableChangeGearsArgCloning() {
+	m.mocked.ChangeGears.EnableArgCloning()
 }
 
 // ChangeGears overrides the method to return the mock response
 func (m *mockVehicle) ChangeGears(gear int) (int, int) {
-	m.mocked.ChangeGears.RecordCall(gear)
+	callID := m.mocked.ChangeGears.RecordCall(gear)
+	defer m.mocked.ChangeGears.EndCall(callID)
+	callArgs := mockVehicleChangeGearsArgs{Gear: gear}
+	m.hooks.ChangeGears.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 int
-
-		result mockVehicleChangeGearsResult
 	)
 
-	if m.mocked.ChangeGears.Enabled {
+	gatePassed := m.mocked.ChangeGears.PassGateOrReport()
+	if gatePassed {
+		m.mocked.ChangeGears.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.ChangeGears.IsEnabled() {
 		out0, out1 = m.mocked.ChangeGears.NextResponse(func(gear int) (int, int) {
 			return m.real.ChangeGears(gear)
 		})(gear)
-
 	} else {
 		out0, out1 = m.real.ChangeGears(gear)
-
 	}
 
-	result = mockVehicleChangeGearsResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["ChangeGears"]; ok {
-		chTyped := ch.(chan mockVehicleChangeGearsResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.ChangeGears.RecordResults(callID, out0, out1)
+	callResult := mockVehicleChangeGearsResult{Before: out0, After: out1}
+	m.hooks.ChangeGears.RunAfter(callArgs, callResult)
+	m.events.ChangeGears.Publish(mockVehicleChangeGearsEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onChangeGearsBefore runs fn on every call to ChangeGears before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onChangeGearsBefore(fn func(args mockVehicleChangeGearsArgs)) func() {
+	return m.hooks.ChangeGears.Before(fn)
+}
+
+// onChangeGearsAfter runs fn once each call to ChangeGears has its result. The returned func removes the hook.
+func (m *mockVehicle) onChangeGearsAfter(fn func(args mockVehicleChangeGearsArgs, result mockVehicleChangeGearsResult)) func() {
+	return m.hooks.ChangeGears.After(fn)
+}
+
+// notifyChangeGearsCalled returns a channel that is closed when ChangeGears is next called, before it responds
+func (m *mockVehicle) notifyChangeGearsCalled() <-chan struct{} {
+	return m.hooks.ChangeGears.Notify()
+}
+
+// blockChangeGears holds every later call to ChangeGears mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockChangeGears() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.ChangeGears.SetGate(g)
+	return g
+}
+
+// unblockChangeGears removes the gate set by blockChangeGears, releasing any held calls
+func (m *mockVehicle) unblockChangeGears() {
+	m.mocked.ChangeGears.ClearGate()
+}
+
+// assertChangeGearsMaxConcurrency fails the test if more than n calls to ChangeGears were ever in flight at once
+func (m *mockVehicle) assertChangeGearsMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.ChangeGears, n)
+}
+
+// assertChangeGearsNeverConcurrent fails the test if any two calls to ChangeGears were in flight at the same time
+func (m *mockVehicle) assertChangeGearsNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.ChangeGears)
+}
 
+// getChangeGearsConcurrencyStats returns the in-flight counts and overlapping calls to ChangeGears
+func (m *mockVehicle) getChangeGearsConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.ChangeGears.ConcurrencyStats()
 }
 
 // setChangeGearsFunc sets the function for ChangeGears
 func (m *mockVehicle) setChangeGearsFunc(f func(int) (int, int)) {
-	m.mocked.ChangeGears.Fallback = f
+	m.mocked.ChangeGears.SetResponseFunc(f)
 }
 
 // enableChangeGearsMock turns the mock on
 func (m *mockVehicle) enableChangeGearsMock() {
-	m.mocked.ChangeGears.Enabled = true
+	m.mocked.ChangeGears.Enable()
 }
 
 // disableChangeGearsMock turns the mock off
 func (m *mockVehicle) disableChangeGearsMock() {
-	m.mocked.ChangeGears.Enabled = false
+	m.mocked.ChangeGears.Disable()
 }
 
 // enqueueChangeGearsResponseFunc enqueues a function response for ChangeGears
@@ -789,26 +2315,124 @@ func (m *mockVehicle) enqueueChangeGearsResponseFuncWithDelay(f func(int) (int,
 	m.mocked.ChangeGears.EnqueueWithDelay(f, d)
 }
 
-// captureChangeGearsResult sets up a channel to capture ChangeGears results.
-func (m *mockVehicle) captureChangeGearsResult() <-chan mockVehicleChangeGearsResult {
-	ch := make(chan mockVehicleChangeGearsResult, 1)
-	m.responseChans["ChangeGears"] = ch
-	return ch
+// subscribeChangeGears returns a subscription that receives an event for every call to ChangeGears. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeChangeGears(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleChangeGearsEvent] {
+	return m.events.ChangeGears.Subscribe(opts)
+}
+
+// captureChangeGearsResult returns a channel that receives the result of every call to ChangeGears.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureChangeGearsResult(t stubs.TB) <-chan mockVehicleChangeGearsResult {
+	sub := stubs.SubscribeMapped(&m.events.ChangeGears, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleChangeGearsEvent) mockVehicleChangeGearsResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureChangeGearsCallSpy starts watching for ChangeGears spy calls and sends them into a channel.
-func (m *mockVehicle) captureChangeGearsCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getChangeGearsCalls, timeout)
-		ch <- m.getChangeGearsCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureChangeGearsCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.ChangeGears, "ChangeGears spy call", timeout)
+}
+
+// waitForChangeGearsCalls waits until at least n ChangeGears spy calls are recorded and returns them
+func (m *mockVehicle) waitForChangeGearsCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.ChangeGears, n, timeout)
+}
+
+// waitForChangeGearsCallWithin waits until a ChangeGears spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForChangeGearsCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.ChangeGears, match, timeout)
+}
+
+// eventuallyChangeGearsCalls waits until cond holds for the recorded ChangeGears spy calls
+func (m *mockVehicle) eventuallyChangeGearsCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.ChangeGears, cond, timeout)
+}
+
+// waitForChangeGearsCallWithArgs waits until ChangeGears is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForChangeGearsCallWithArgs(t stubs.TB, timeout time.Duration, wantGear int) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.ChangeGears, timeout, wantGear)
+}
+
+// consistentlyChangeGearsNotCalled fails the test if ChangeGears is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyChangeGearsNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.ChangeGears, window)
+}
+
+// setChangeGearsPanic makes every mocked call to ChangeGears panic with v
+func (m *mockVehicle) setChangeGearsPanic(v any) {
+	m.mocked.ChangeGears.SetPanicResponse(v)
 }
 
+// enqueueChangeGearsPanic enqueues a response for ChangeGears that panics with v
+func (m *mockVehicle) enqueueChangeGearsPanic(v any) {
+	m.mocked.ChangeGears.EnqueuePanic(v)
+}
+
+// injectChangeGearsFaults applies a seeded fault policy to ChangeGears. ChangeGears has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectChangeGearsFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectChangeGearsFaults: ChangeGears has no error output to inject errors into")
+	}
+	m.mocked.ChangeGears.SetFaults(policy)
+}
+
+// clearChangeGearsFaults removes the fault policy from ChangeGears
+func (m *mockVehicle) clearChangeGearsFaults() {
+	m.mocked.ChangeGears.ClearFaults()
+}
+
+// mockVehicleChangeGearsResult holds the outputs of a call to ChangeGears
 type mockVehicleChangeGearsResult struct {
-	Output0 int
-	Output1 int
+	Before int
+	After  int
+}
+
+// Values returns the outputs in the order ChangeGears returns them
+func (r mockVehicleChangeGearsResult) Values() (int, int) {
+	return r.Before, r.After
+}
+
+// mockVehicleChangeGearsArgs holds the arguments of a call to ChangeGears
+type mockVehicleChangeGearsArgs struct {
+	Gear int
+}
+
+// mockVehicleChangeGearsEvent is published to subscribers on every call to ChangeGears
+type mockVehicleChangeGearsEvent struct {
+	Args   mockVehicleChangeGearsArgs
+	Result mockVehicleChangeGearsResult
+}
+
+// mockVehicleChangeGearsCall is a typed record of a call to ChangeGears. Outputs are zero until the call returns.
+type mockVehicleChangeGearsCall struct {
+	Gear   int
+	Before int
+	After  int
+}
+
+// getChangeGearsTypedCalls returns the spied calls to ChangeGears with typed arguments and results
+func (m *mockVehicle) getChangeGearsTypedCalls() []mockVehicleChangeGearsCall {
+	calls := m.mocked.ChangeGears.Calls()
+	typed := make([]mockVehicleChangeGearsCall, len(calls))
+	for i, call := range calls {
+		typed[i].Gear, _ = call.Args[0].(int)
+		if call.Returned {
+			typed[i].Before, _ = call.Results[0].(int)
+			typed[i].After, _ = call.Results[1].(int)
+		}
+	}
+	return typed
 }
 
 // setChangeGearsResponse sets the response for ChangeGears
@@ -828,16 +2452,15 @@ func (m *mockVehicle) enqueueChangeGearsResponse(output0 int, output1 int) {
 // enqueueChangeGearsResponseWithDelay enqueues a static response with delay for ChangeGears
 func (m *mockVehicle) enqueueChangeGearsResponseWithDelay(output0 int, output1 int, d time.Duration) {
 	m.mocked.ChangeGears.EnqueueWithDelay(func(int) (int, int) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- Telemetry Mock Helpers --------------------------- */
 
-// enableTelemetryMock turns the spy on
+// enableTelemetrySpy turns the spy on
 func (m *mockVehicle) enableTelemetrySpy() {
-	m.mocked.Telemetry.SpyEnabled = true
+	m.mocked.Telemetry.EnableSpy()
 }
 
 // getTelemetryCalls returns recorded calls to Telemetry
@@ -845,49 +2468,115 @@ func (m *mockVehicle) getTelemetryCalls() []stubs.MethodCall {
 	return m.mocked.Telemetry.Calls()
 }
 
-// enableTelemetryMock turns the spy off
+// enableTelemetrySpy turns the spy off
 func (m *mockVehicle) disableTelemetrySpy() {
-	m.mocked.Telemetry.SpyEnabled = false
+	m.mocked.Telemetry.DisableSpy()
+}
+
+// disableTelemetryArgCloning records arguments and results of Telemetry by reference instead of deep copying them
+func (m *mockVehicle) disableTelemetryArgCloning() {
+	m.mocked.Telemetry.DisableArgCloning()
+}
+
+// enableTelemetryArgCloning deep copies arguments and results of Telemetry as they are recorded
+func (m *mockVehicle) enableTelemetryArgCloning() {
+	m.mocked.Telemetry.EnableArgCloning()
 }
 
 // Telemetry overrides the method to return the mock response
 func (m *mockVehicle) Telemetry() map[string]float64 {
-	m.mocked.Telemetry.RecordCall()
+	callID := m.mocked.Telemetry.RecordCall()
+	defer m.mocked.Telemetry.EndCall(callID)
+	callArgs := mockVehicleTelemetryArgs{}
+	m.hooks.Telemetry.RunBefore(callArgs)
 	var (
 		out0 map[string]float64
 	)
 
-	if m.mocked.Telemetry.Enabled {
+	gatePassed := m.mocked.Telemetry.PassGateOrReport()
+	if gatePassed {
+		m.mocked.Telemetry.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.Telemetry.IsEnabled() {
 		out0 = m.mocked.Telemetry.NextResponse(func() map[string]float64 {
 			return m.real.Telemetry()
 		})()
-
 	} else {
 		out0 = m.real.Telemetry()
-
 	}
 
-	if ch, ok := m.responseChans["Telemetry"]; ok {
-		chTyped := ch.(chan map[string]float64)
-		chTyped <- out0
-	}
+	m.mocked.Telemetry.RecordResults(callID, out0)
+	m.hooks.Telemetry.RunAfter(callArgs, out0)
+	m.events.Telemetry.Publish(mockVehicleTelemetryEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onTelemetryBefore runs fn on every call to Telemetry before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onTelemetryBefore(fn func(args mockVehicleTelemetryArgs)) func() {
+	return m.hooks.Telemetry.Before(fn)
+}
 
+// onTelemetryAfter runs fn once each call to Telemetry has its result. The returned func removes the hook.
+func (m *mockVehicle) onTelemetryAfter(fn func(args mockVehicleTelemetryArgs, result map[string]float64)) func() {
+	return m.hooks.Telemetry.After(fn)
+}
+
+// notifyTelemetryCalled returns a channel that is closed when Telemetry is next called, before it responds
+func (m *mockVehicle) notifyTelemetryCalled() <-chan struct{} {
+	return m.hooks.Telemetry.Notify()
+}
+
+// blockTelemetry holds every later call to Telemetry mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockTelemetry() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Telemetry.SetGate(g)
+	return g
+}
+
+// unblockTelemetry removes the gate set by blockTelemetry, releasing any held calls
+func (m *mockVehicle) unblockTelemetry() {
+	m.mocked.Telemetry.ClearGate()
+}
+
+// assertTelemetryMaxConcurrency fails the test if more than n calls to Telemetry were ever in flight at once
+func (m *mockVehicle) assertTelemetryMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Telemetry, n)
+}
+
+// assertTelemetryNeverConcurrent fails the test if any two calls to Telemetry were in flight at the same time
+func (m *mockVehicle) assertTelemetryNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Telemetry)
+}
+
+// getTelemetryConcurrencyStats returns the in-flight counts and overlapping calls to Telemetry
+func (m *mockVehicle) getTelemetryConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Telemetry.ConcurrencyStats()
 }
 
 // setTelemetryFunc sets the function for Telemetry
 func (m *mockVehicle) setTelemetryFunc(f func() map[string]float64) {
-	m.mocked.Telemetry.Fallback = f
+	m.mocked.Telemetry.SetResponseFunc(f)
 }
 
 // enableTelemetryMock turns the mock on
 func (m *mockVehicle) enableTelemetryMock() {
-	m.mocked.Telemetry.Enabled = true
+	m.mocked.Telemetry.Enable()
 }
 
 // disableTelemetryMock turns the mock off
 func (m *mockVehicle) disableTelemetryMock() {
-	m.mocked.Telemetry.Enabled = false
+	m.mocked.Telemetry.Disable()
 }
 
 // enqueueTelemetryResponseFunc enqueues a function response for Telemetry
@@ -900,25 +2589,101 @@ func (m *mockVehicle) enqueueTelemetryResponseFuncWithDelay(f func() map[string]
 	m.mocked.Telemetry.EnqueueWithDelay(f, d)
 }
 
-// captureTelemetryResult sets up a channel to capture Telemetry results.
-func (m *mockVehicle) captureTelemetryResult() <-chan map[string]float64 {
-	ch := make(chan map[string]float64, 1)
-	m.responseChans["Telemetry"] = ch
-	return ch
+// subscribeTelemetry returns a subscription that receives an event for every call to Telemetry. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeTelemetry(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleTelemetryEvent] {
+	return m.events.Telemetry.Subscribe(opts)
+}
+
+// captureTelemetryResult returns a channel that receives the result of every call to Telemetry.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureTelemetryResult(t stubs.TB) <-chan map[string]float64 {
+	sub := stubs.SubscribeMapped(&m.events.Telemetry, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleTelemetryEvent) map[string]float64 {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureTelemetryCallSpy starts watching for Telemetry spy calls and sends them into a channel.
-func (m *mockVehicle) captureTelemetryCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getTelemetryCalls, timeout)
-		ch <- m.getTelemetryCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureTelemetryCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Telemetry, "Telemetry spy call", timeout)
+}
+
+// waitForTelemetryCalls waits until at least n Telemetry spy calls are recorded and returns them
+func (m *mockVehicle) waitForTelemetryCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Telemetry, n, timeout)
+}
+
+// waitForTelemetryCallWithin waits until a Telemetry spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForTelemetryCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Telemetry, match, timeout)
+}
+
+// eventuallyTelemetryCalls waits until cond holds for the recorded Telemetry spy calls
+func (m *mockVehicle) eventuallyTelemetryCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Telemetry, cond, timeout)
+}
+
+// consistentlyTelemetryNotCalled fails the test if Telemetry is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyTelemetryNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Telemetry, window)
+}
+
+// setTelemetryPanic makes every mocked call to Telemetry panic with v
+func (m *mockVehicle) setTelemetryPanic(v any) {
+	m.mocked.Telemetry.SetPanicResponse(v)
+}
+
+// enqueueTelemetryPanic enqueues a response for Telemetry that panics with v
+func (m *mockVehicle) enqueueTelemetryPanic(v any) {
+	m.mocked.Telemetry.EnqueuePanic(v)
+}
+
+// injectTelemetryFaults applies a seeded fault policy to Telemetry. Telemetry has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectTelemetryFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectTelemetryFaults: Telemetry has no error output to inject errors into")
+	}
+	m.mocked.Telemetry.SetFaults(policy)
+}
+
+// clearTelemetryFaults removes the fault policy from Telemetry
+func (m *mockVehicle) clearTelemetryFaults() {
+	m.mocked.Telemetry.ClearFaults()
 }
 
-type mockVehicleTelemetryResult struct {
-	Output0 map[string]float64
+// mockVehicleTelemetryArgs holds the arguments of a call to Telemetry
+type mockVehicleTelemetryArgs struct {
+}
+
+// mockVehicleTelemetryEvent is published to subscribers on every call to Telemetry
+type mockVehicleTelemetryEvent struct {
+	Args   mockVehicleTelemetryArgs
+	Result map[string]float64
+}
+
+// mockVehicleTelemetryCall is a typed record of a call to Telemetry. Outputs are zero until the call returns.
+type mockVehicleTelemetryCall struct {
+	TelemetryData map[string]float64
+}
+
+// getTelemetryTypedCalls returns the spied calls to Telemetry with typed arguments and results
+func (m *mockVehicle) getTelemetryTypedCalls() []mockVehicleTelemetryCall {
+	calls := m.mocked.Telemetry.Calls()
+	typed := make([]mockVehicleTelemetryCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].TelemetryData, _ = call.Results[0].(map[string]float64)
+		}
+	}
+	return typed
 }
 
 // setTelemetryResponse sets the response for Telemetry
@@ -938,16 +2703,15 @@ func (m *mockVehicle) enqueueTelemetryResponse(output0 map[string]float64) {
 // enqueueTelemetryResponseWithDelay enqueues a static response with delay for Telemetry
 func (m *mockVehicle) enqueueTelemetryResponseWithDelay(output0 map[string]float64, d time.Duration) {
 	m.mocked.Telemetry.EnqueueWithDelay(func() map[string]float64 {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- Accelerate Mock Helpers --------------------------- */
 
-// enableAccelerateMock turns the spy on
+// enableAccelerateSpy turns the spy on
 func (m *mockVehicle) enableAccelerateSpy() {
-	m.mocked.Accelerate.SpyEnabled = true
+	m.mocked.Accelerate.EnableSpy()
 }
 
 // getAccelerateCalls returns recorded calls to Accelerate
@@ -955,55 +2719,114 @@ func (m *mockVehicle) getAccelerateCalls() []stubs.MethodCall {
 	return m.mocked.Accelerate.Calls()
 }
 
-// enableAccelerateMock turns the spy off
+// enableAccelerateSpy turns the spy off
 func (m *mockVehicle) disableAccelerateSpy() {
-	m.mocked.Accelerate.SpyEnabled = false
+	m.mocked.Accelerate.DisableSpy()
+}
+
+// disableAccelerateArgCloning records arguments and results of Accelerate by reference instead of deep copying them
+func (m *mockVehicle) disableAccelerateArgCloning() {
+	m.mocked.Accelerate.DisableArgCloning()
+}
+
+// enableAccelerateArgCloning deep copies arguments and results of Accelerate as they are recorded
+func (m *mockVehicle) enableAccelerateArgCloning() {
+	m.mocked.Accelerate.EnableArgCloning()
 }
 
 // Accelerate overrides the method to return the mock response
 func (m *mockVehicle) Accelerate(speed int, unit string) (int, error) {
-	m.mocked.Accelerate.RecordCall(speed, unit)
+	callID := m.mocked.Accelerate.RecordCall(speed, unit)
+	defer m.mocked.Accelerate.EndCall(callID)
+	callArgs := mockVehicleAccelerateArgs{Speed: speed, Unit: unit}
+	m.hooks.Accelerate.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 error
-
-		result mockVehicleAccelerateResult
 	)
 
-	if m.mocked.Accelerate.Enabled {
+	if gateErr := m.mocked.Accelerate.PassGate(); gateErr != nil {
+		out1 = gateErr
+	} else if faultErr := m.mocked.Accelerate.ApplyFault(); faultErr != nil {
+		out1 = faultErr
+	} else if m.mocked.Accelerate.IsEnabled() {
 		out0, out1 = m.mocked.Accelerate.NextResponse(func(speed int, unit string) (int, error) {
 			return m.real.Accelerate(speed, unit)
 		})(speed, unit)
-
 	} else {
 		out0, out1 = m.real.Accelerate(speed, unit)
-
 	}
 
-	result = mockVehicleAccelerateResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["Accelerate"]; ok {
-		chTyped := ch.(chan mockVehicleAccelerateResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.Accelerate.RecordResults(callID, out0, out1)
+	callResult := mockVehicleAccelerateResult{NewSpeed: out0, Err: out1}
+	m.hooks.Accelerate.RunAfter(callArgs, callResult)
+	m.events.Accelerate.Publish(mockVehicleAccelerateEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onAccelerateBefore runs fn on every call to Accelerate before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onAccelerateBefore(fn func(args mockVehicleAccelerateArgs)) func() {
+	return m.hooks.Accelerate.Before(fn)
+}
+
+// onAccelerateAfter runs fn once each call to Accelerate has its result. The returned func removes the hook.
+func (m *mockVehicle) onAccelerateAfter(fn func(args mockVehicleAccelerateArgs, result mockVehicleAccelerateResult)) func() {
+	return m.hooks.Accelerate.After(fn)
+}
+
+// notifyAccelerateCalled returns a channel that is closed when Accelerate is next called, before it responds
+func (m *mockVehicle) notifyAccelerateCalled() <-chan struct{} {
+	return m.hooks.Accelerate.Notify()
+}
+
+// blockAccelerate holds every later call to Accelerate mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockAccelerate() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Accelerate.SetGate(g)
+	return g
+}
+
+// unblockAccelerate removes the gate set by blockAccelerate, releasing any held calls
+func (m *mockVehicle) unblockAccelerate() {
+	m.mocked.Accelerate.ClearGate()
+}
+
+// assertAccelerateMaxConcurrency fails the test if more than n calls to Accelerate were ever in flight at once
+func (m *mockVehicle) assertAccelerateMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Accelerate, n)
+}
+
+// assertAccelerateNeverConcurrent fails the test if any two calls to Accelerate were in flight at the same time
+func (m *mockVehicle) assertAccelerateNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Accelerate)
+}
 
+// getAccelerateConcurrencyStats returns the in-flight counts and overlapping calls to Accelerate
+func (m *mockVehicle) getAccelerateConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Accelerate.ConcurrencyStats()
 }
 
 // setAccelerateFunc sets the function for Accelerate
 func (m *mockVehicle) setAccelerateFunc(f func(int, string) (int, error)) {
-	m.mocked.Accelerate.Fallback = f
+	m.mocked.Accelerate.SetResponseFunc(f)
 }
 
 // enableAccelerateMock turns the mock on
 func (m *mockVehicle) enableAccelerateMock() {
-	m.mocked.Accelerate.Enabled = true
+	m.mocked.Accelerate.Enable()
 }
 
 // disableAccelerateMock turns the mock off
 func (m *mockVehicle) disableAccelerateMock() {
-	m.mocked.Accelerate.Enabled = false
+	m.mocked.Accelerate.Disable()
 }
 
 // enqueueAccelerateResponseFunc enqueues a function response for Accelerate
@@ -1016,95 +2839,268 @@ func (m *mockVehicle) enqueueAccelerateResponseFuncWithDelay(f func(int, string)
 	m.mocked.Accelerate.EnqueueWithDelay(f, d)
 }
 
-// captureAccelerateResult sets up a channel to capture Accelerate results.
-func (m *mockVehicle) captureAccelerateResult() <-chan mockVehicleAccelerateResult {
-	ch := make(chan mockVehicleAccelerateResult, 1)
-	m.responseChans["Accelerate"] = ch
-	return ch
+// subscribeAccelerate returns a subscription that receives an event for every call to Accelerate. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeAccelerate(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleAccelerateEvent] {
+	return m.events.Accelerate.Subscribe(opts)
+}
+
+// captureAccelerateResult returns a channel that receives the result of every call to Accelerate.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureAccelerateResult(t stubs.TB) <-chan mockVehicleAccelerateResult {
+	sub := stubs.SubscribeMapped(&m.events.Accelerate, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleAccelerateEvent) mockVehicleAccelerateResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureAccelerateCallSpy starts watching for Accelerate spy calls and sends them into a channel.
-func (m *mockVehicle) captureAccelerateCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getAccelerateCalls, timeout)
-		ch <- m.getAccelerateCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureAccelerateCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Accelerate, "Accelerate spy call", timeout)
 }
 
-type mockVehicleAccelerateResult struct {
-	Output0 int
-	Output1 error
+// waitForAccelerateCalls waits until at least n Accelerate spy calls are recorded and returns them
+func (m *mockVehicle) waitForAccelerateCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Accelerate, n, timeout)
 }
 
-// setAccelerateResponse sets the response for Accelerate
-func (m *mockVehicle) setAccelerateResponse(output0 int, output1 error) {
-	m.setAccelerateFunc(func(int, string) (int, error) {
-		return output0, output1
-	})
+// waitForAccelerateCallWithin waits until a Accelerate spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForAccelerateCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Accelerate, match, timeout)
 }
 
-// enqueueAccelerateResponse enqueues a static response for Accelerate
-func (m *mockVehicle) enqueueAccelerateResponse(output0 int, output1 error) {
-	m.mocked.Accelerate.EnqueueWithDelay(func(int, string) (int, error) {
-		return output0, output1
-	}, 0)
+// eventuallyAccelerateCalls waits until cond holds for the recorded Accelerate spy calls
+func (m *mockVehicle) eventuallyAccelerateCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Accelerate, cond, timeout)
 }
 
-// enqueueAccelerateResponseWithDelay enqueues a static response with delay for Accelerate
-func (m *mockVehicle) enqueueAccelerateResponseWithDelay(output0 int, output1 error, d time.Duration) {
-	m.mocked.Accelerate.EnqueueWithDelay(func(int, string) (int, error) {
-		time.Sleep(d)
-		return output0, output1
-	}, d)
+// waitForAccelerateCallWithArgs waits until Accelerate is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForAccelerateCallWithArgs(t stubs.TB, timeout time.Duration, wantSpeed int, wantUnit string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.Accelerate, timeout, wantSpeed, wantUnit)
 }
 
-/* -------------------------- Honk Mock Helpers --------------------------- */
+// consistentlyAccelerateNotCalled fails the test if Accelerate is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyAccelerateNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Accelerate, window)
+}
 
-// enableHonkMock turns the spy on
-func (m *mockVehicle) enableHonkSpy() {
-	m.mocked.Honk.SpyEnabled = true
+// setAcceleratePanic makes every mocked call to Accelerate panic with v
+func (m *mockVehicle) setAcceleratePanic(v any) {
+	m.mocked.Accelerate.SetPanicResponse(v)
 }
 
-// getHonkCalls returns recorded calls to Honk
-func (m *mockVehicle) getHonkCalls() []stubs.MethodCall {
-	return m.mocked.Honk.Calls()
+// enqueueAcceleratePanic enqueues a response for Accelerate that panics with v
+func (m *mockVehicle) enqueueAcceleratePanic(v any) {
+	m.mocked.Accelerate.EnqueuePanic(v)
 }
 
-// enableHonkMock turns the spy off
-func (m *mockVehicle) disableHonkSpy() {
-	m.mocked.Honk.SpyEnabled = false
+// injectAccelerateFaults applies a seeded fault policy to Accelerate. Injected errors are returned in output 1.
+func (m *mockVehicle) injectAccelerateFaults(policy stubs.FaultPolicy) {
+	m.mocked.Accelerate.SetFaults(policy)
 }
 
-// Honk overrides the method to return the mock response
-func (m *mockVehicle) Honk(times int) {
-	m.mocked.Honk.RecordCall(times)
-	var ()
+// clearAccelerateFaults removes the fault policy from Accelerate
+func (m *mockVehicle) clearAccelerateFaults() {
+	m.mocked.Accelerate.ClearFaults()
+}
 
-	if m.mocked.Honk.Enabled {
+// mockVehicleAccelerateResult holds the outputs of a call to Accelerate
+type mockVehicleAccelerateResult struct {
+	NewSpeed int
+	Err      error
+}
 
-	} else {
+// Values returns the outputs in the order Accelerate returns them
+func (r mockVehicleAccelerateResult) Values() (int, error) {
+	return r.NewSpeed, r.Err
+}
+
+// ReturnedErr returns the error output
+func (r mockVehicleAccelerateResult) ReturnedErr() error {
+	return r.Err
+}
+
+// mockVehicleAccelerateArgs holds the arguments of a call to Accelerate
+type mockVehicleAccelerateArgs struct {
+	Speed int
+	Unit  string
+}
+
+// mockVehicleAccelerateEvent is published to subscribers on every call to Accelerate
+type mockVehicleAccelerateEvent struct {
+	Args   mockVehicleAccelerateArgs
+	Result mockVehicleAccelerateResult
+}
+
+// mockVehicleAccelerateCall is a typed record of a call to Accelerate. Outputs are zero until the call returns.
+type mockVehicleAccelerateCall struct {
+	Speed    int
+	Unit     string
+	NewSpeed int
+	Err      error
+}
 
+// getAccelerateTypedCalls returns the spied calls to Accelerate with typed arguments and results
+func (m *mockVehicle) getAccelerateTypedCalls() []mockVehicleAccelerateCall {
+	calls := m.mocked.Accelerate.Calls()
+	typed := make([]mockVehicleAccelerateCall, len(calls))
+	for i, call := range calls {
+		typed[i].Speed, _ = call.Args[0].(int)
+		typed[i].Unit, _ = call.Args[1].(string)
+		if call.Returned {
+			typed[i].NewSpeed, _ = call.Results[0].(int)
+			typed[i].Err, _ = call.Results[1].(error)
+		}
 	}
+	return typed
+}
+
+// setAccelerateResponse sets the response for Accelerate
+func (m *mockVehicle) setAccelerateResponse(output0 int, output1 error) {
+	m.setAccelerateFunc(func(int, string) (int, error) {
+		return output0, output1
+	})
+}
+
+// enqueueAccelerateResponse enqueues a static response for Accelerate
+func (m *mockVehicle) enqueueAccelerateResponse(output0 int, output1 error) {
+	m.mocked.Accelerate.EnqueueWithDelay(func(int, string) (int, error) {
+		return output0, output1
+	}, 0)
+}
+
+// enqueueAccelerateResponseWithDelay enqueues a static response with delay for Accelerate
+func (m *mockVehicle) enqueueAccelerateResponseWithDelay(output0 int, output1 error, d time.Duration) {
+	m.mocked.Accelerate.EnqueueWithDelay(func(int, string) (int, error) {
+		return output0, output1
+	}, d)
+}
 
-	return
+/* -------------------------- Honk Mock Helpers --------------------------- */
+
+// enableHonkSpy turns the spy on
+func (m *mockVehicle) enableHonkSpy() {
+	m.mocked.Honk.EnableSpy()
+}
 
+// getHonkCalls returns recorded calls to Honk
+func (m *mockVehicle) getHonkCalls() []stubs.MethodCall {
+	return m.mocked.Honk.Calls()
+}
+
+// enableHonkSpy turns the spy off
+func (m *mockVehicle) disableHonkSpy() {
+	m.mocked.Honk.DisableSpy()
+}
+
+// disableHonkArgCloning records arguments and results of Honk by reference instead of deep copying them
+func (m *mockVehicle) disableHonkArgCloning() {
+	m.mocked.Honk.DisableArgCloning()
+}
+
+// enableHonkArgCloning deep copies arguments and results of Honk as they are recorded
+func (m *mockVehicle) enableHonkArgCloning() {
+	m.mocked.Honk.EnableArgCloning()
+}
+
+// Honk overrides the method to return the mock response
+func (m *mockVehicle) Honk(times int) {
+	callID := m.mocked.Honk.RecordCall(times)
+	defer m.mocked.Honk.EndCall(callID)
+	callArgs := mockVehicleHonkArgs{Times: times}
+	m.hooks.Honk.RunBefore(callArgs)
+
+	gatePassed := m.mocked.Honk.PassGateOrReport()
+	if gatePassed {
+		m.mocked.Honk.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.Honk.IsEnabled() {
+		m.mocked.Honk.NextResponse(func(times int) {
+			m.real.Honk(times)
+		})(times)
+	} else {
+		m.real.Honk(times)
+	}
+
+	m.mocked.Honk.RecordResults(callID)
+	m.hooks.Honk.RunAfter(callArgs, struct{}{})
+	m.events.Honk.Publish(mockVehicleHonkEvent{
+		Args: callArgs,
+	})
+}
+
+// onHonkBefore runs fn on every call to Honk before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onHonkBefore(fn func(args mockVehicleHonkArgs)) func() {
+	return m.hooks.Honk.Before(fn)
+}
+
+// onHonkAfter runs fn once each call to Honk has its result. The returned func removes the hook.
+func (m *mockVehicle) onHonkAfter(fn func(args mockVehicleHonkArgs)) func() {
+	return m.hooks.Honk.After(func(args mockVehicleHonkArgs, _ struct{}) { fn(args) })
+}
+
+// notifyHonkCalled returns a channel that is closed when Honk is next called, before it responds
+func (m *mockVehicle) notifyHonkCalled() <-chan struct{} {
+	return m.hooks.Honk.Notify()
+}
+
+// blockHonk holds every later call to Honk mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockHonk() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.Honk.SetGate(g)
+	return g
+}
+
+// unblockHonk removes the gate set by blockHonk, releasing any held calls
+func (m *mockVehicle) unblockHonk() {
+	m.mocked.Honk.ClearGate()
+}
+
+// assertHonkMaxConcurrency fails the test if more than n calls to Honk were ever in flight at once
+func (m *mockVehicle) assertHonkMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.Honk, n)
+}
+
+// assertHonkNeverConcurrent fails the test if any two calls to Honk were in flight at the same time
+func (m *mockVehicle) assertHonkNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.Honk)
+}
+
+// getHonkConcurrencyStats returns the in-flight counts and overlapping calls to Honk
+func (m *mockVehicle) getHonkConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.Honk.ConcurrencyStats()
 }
 
 // setHonkFunc sets the function for Honk
 func (m *mockVehicle) setHonkFunc(f func(int)) {
-	m.mocked.Honk.Fallback = f
+	m.mocked.Honk.SetResponseFunc(f)
 }
 
 // enableHonkMock turns the mock on
 func (m *mockVehicle) enableHonkMock() {
-	m.mocked.Honk.Enabled = true
+	m.mocked.Honk.Enable()
 }
 
 // disableHonkMock turns the mock off
 func (m *mockVehicle) disableHonkMock() {
-	m.mocked.Honk.Enabled = false
+	m.mocked.Honk.Disable()
 }
 
 // enqueueHonkResponseFunc enqueues a function response for Honk
@@ -1117,31 +3113,113 @@ func (m *mockVehicle) enqueueHonkResponseFuncWithDelay(f func(int), d time.Durat
 	m.mocked.Honk.EnqueueWithDelay(f, d)
 }
 
-// captureHonkResult sets up a channel to capture Honk results.
-func (m *mockVehicle) captureHonkResult() <-chan struct{} {
-	ch := make(chan struct{}, 1)
-	m.responseChans["Honk"] = ch
-	return ch
+// subscribeHonk returns a subscription that receives an event for every call to Honk. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeHonk(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleHonkEvent] {
+	return m.events.Honk.Subscribe(opts)
+}
+
+// captureHonkResult returns a channel that receives the result of every call to Honk.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureHonkResult(t stubs.TB) <-chan struct{} {
+	sub := stubs.SubscribeMapped(&m.events.Honk, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleHonkEvent) struct{} {
+		return struct{}{}
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureHonkCallSpy starts watching for Honk spy calls and sends them into a channel.
-func (m *mockVehicle) captureHonkCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getHonkCalls, timeout)
-		ch <- m.getHonkCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureHonkCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.Honk, "Honk spy call", timeout)
+}
+
+// waitForHonkCalls waits until at least n Honk spy calls are recorded and returns them
+func (m *mockVehicle) waitForHonkCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.Honk, n, timeout)
+}
+
+// waitForHonkCallWithin waits until a Honk spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForHonkCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.Honk, match, timeout)
 }
 
-type mockVehicleHonkResult struct {
+// eventuallyHonkCalls waits until cond holds for the recorded Honk spy calls
+func (m *mockVehicle) eventuallyHonkCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.Honk, cond, timeout)
+}
+
+// waitForHonkCallWithArgs waits until Honk is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForHonkCallWithArgs(t stubs.TB, timeout time.Duration, wantTimes int) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.Honk, timeout, wantTimes)
+}
+
+// consistentlyHonkNotCalled fails the test if Honk is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyHonkNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.Honk, window)
+}
+
+// setHonkPanic makes every mocked call to Honk panic with v
+func (m *mockVehicle) setHonkPanic(v any) {
+	m.mocked.Honk.SetPanicResponse(v)
+}
+
+// enqueueHonkPanic enqueues a response for Honk that panics with v
+func (m *mockVehicle) enqueueHonkPanic(v any) {
+	m.mocked.Honk.EnqueuePanic(v)
+}
+
+// injectHonkFaults applies a seeded fault policy to Honk. Honk has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectHonkFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectHonkFaults: Honk has no error output to inject errors into")
+	}
+	m.mocked.Honk.SetFaults(policy)
+}
+
+// clearHonkFaults removes the fault policy from Honk
+func (m *mockVehicle) clearHonkFaults() {
+	m.mocked.Honk.ClearFaults()
+}
+
+// mockVehicleHonkArgs holds the arguments of a call to Honk
+type mockVehicleHonkArgs struct {
+	Times int
+}
+
+// mockVehicleHonkEvent is published to subscribers on every call to Honk
+type mockVehicleHonkEvent struct {
+	Args mockVehicleHonkArgs
+}
+
+// mockVehicleHonkCall is a typed record of a call to Honk. Outputs are zero until the call returns.
+type mockVehicleHonkCall struct {
+	Times int
+}
+
+// getHonkTypedCalls returns the spied calls to Honk with typed arguments and results
+func (m *mockVehicle) getHonkTypedCalls() []mockVehicleHonkCall {
+	calls := m.mocked.Honk.Calls()
+	typed := make([]mockVehicleHonkCall, len(calls))
+	for i, call := range calls {
+		typed[i].Times, _ = call.Args[0].(int)
+	}
+	return typed
 }
 
 /* -------------------------- GetPassengers Mock Helpers --------------------------- */
 
-// enableGetPassengersMock turns the spy on
+// enableGetPassengersSpy turns the spy on
 func (m *mockVehicle) enableGetPassengersSpy() {
-	m.mocked.GetPassengers.SpyEnabled = true
+	m.mocked.GetPassengers.EnableSpy()
 }
 
 // getGetPassengersCalls returns recorded calls to GetPassengers
@@ -1149,49 +3227,115 @@ func (m *mockVehicle) getGetPassengersCalls() []stubs.MethodCall {
 	return m.mocked.GetPassengers.Calls()
 }
 
-// enableGetPassengersMock turns the spy off
+// enableGetPassengersSpy turns the spy off
 func (m *mockVehicle) disableGetPassengersSpy() {
-	m.mocked.GetPassengers.SpyEnabled = false
+	m.mocked.GetPassengers.DisableSpy()
+}
+
+// disableGetPassengersArgCloning records arguments and results of GetPassengers by reference instead of deep copying them
+func (m *mockVehicle) disableGetPassengersArgCloning() {
+	m.mocked.GetPassengers.DisableArgCloning()
+}
+
+// enableGetPassengersArgCloning deep copies arguments and results of GetPassengers as they are recorded
+func (m *mockVehicle) enableGetPassengersArgCloning() {
+	m.mocked.GetPassengers.EnableArgCloning()
 }
 
 // GetPassengers overrides the method to return the mock response
 func (m *mockVehicle) GetPassengers() []string {
-	m.mocked.GetPassengers.RecordCall()
+	callID := m.mocked.GetPassengers.RecordCall()
+	defer m.mocked.GetPassengers.EndCall(callID)
+	callArgs := mockVehicleGetPassengersArgs{}
+	m.hooks.GetPassengers.RunBefore(callArgs)
 	var (
 		out0 []string
 	)
 
-	if m.mocked.GetPassengers.Enabled {
+	gatePassed := m.mocked.GetPassengers.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetPassengers.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetPassengers.IsEnabled() {
 		out0 = m.mocked.GetPassengers.NextResponse(func() []string {
 			return m.real.GetPassengers()
 		})()
-
 	} else {
 		out0 = m.real.GetPassengers()
-
 	}
 
-	if ch, ok := m.responseChans["GetPassengers"]; ok {
-		chTyped := ch.(chan []string)
-		chTyped <- out0
-	}
+	m.mocked.GetPassengers.RecordResults(callID, out0)
+	m.hooks.GetPassengers.RunAfter(callArgs, out0)
+	m.events.GetPassengers.Publish(mockVehicleGetPassengersEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onGetPassengersBefore runs fn on every call to GetPassengers before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onGetPassengersBefore(fn func(args mockVehicleGetPassengersArgs)) func() {
+	return m.hooks.GetPassengers.Before(fn)
+}
 
+// onGetPassengersAfter runs fn once each call to GetPassengers has its result. The returned func removes the hook.
+func (m *mockVehicle) onGetPassengersAfter(fn func(args mockVehicleGetPassengersArgs, result []string)) func() {
+	return m.hooks.GetPassengers.After(fn)
+}
+
+// notifyGetPassengersCalled returns a channel that is closed when GetPassengers is next called, before it responds
+func (m *mockVehicle) notifyGetPassengersCalled() <-chan struct{} {
+	return m.hooks.GetPassengers.Notify()
+}
+
+// blockGetPassengers holds every later call to GetPassengers mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockGetPassengers() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetPassengers.SetGate(g)
+	return g
+}
+
+// unblockGetPassengers removes the gate set by blockGetPassengers, releasing any held calls
+func (m *mockVehicle) unblockGetPassengers() {
+	m.mocked.GetPassengers.ClearGate()
+}
+
+// assertGetPassengersMaxConcurrency fails the test if more than n calls to GetPassengers were ever in flight at once
+func (m *mockVehicle) assertGetPassengersMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetPassengers, n)
+}
+
+// assertGetPassengersNeverConcurrent fails the test if any two calls to GetPassengers were in flight at the same time
+func (m *mockVehicle) assertGetPassengersNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetPassengers)
+}
+
+// getGetPassengersConcurrencyStats returns the in-flight counts and overlapping calls to GetPassengers
+func (m *mockVehicle) getGetPassengersConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetPassengers.ConcurrencyStats()
 }
 
 // setGetPassengersFunc sets the function for GetPassengers
 func (m *mockVehicle) setGetPassengersFunc(f func() []string) {
-	m.mocked.GetPassengers.Fallback = f
+	m.mocked.GetPassengers.SetResponseFunc(f)
 }
 
 // enableGetPassengersMock turns the mock on
 func (m *mockVehicle) enableGetPassengersMock() {
-	m.mocked.GetPassengers.Enabled = true
+	m.mocked.GetPassengers.Enable()
 }
 
 // disableGetPassengersMock turns the mock off
 func (m *mockVehicle) disableGetPassengersMock() {
-	m.mocked.GetPassengers.Enabled = false
+	m.mocked.GetPassengers.Disable()
 }
 
 // enqueueGetPassengersResponseFunc enqueues a function response for GetPassengers
@@ -1204,25 +3348,101 @@ func (m *mockVehicle) enqueueGetPassengersResponseFuncWithDelay(f func() []strin
 	m.mocked.GetPassengers.EnqueueWithDelay(f, d)
 }
 
-// captureGetPassengersResult sets up a channel to capture GetPassengers results.
-func (m *mockVehicle) captureGetPassengersResult() <-chan []string {
-	ch := make(chan []string, 1)
-	m.responseChans["GetPassengers"] = ch
-	return ch
+// subscribeGetPassengers returns a subscription that receives an event for every call to GetPassengers. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeGetPassengers(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleGetPassengersEvent] {
+	return m.events.GetPassengers.Subscribe(opts)
+}
+
+// captureGetPassengersResult returns a channel that receives the result of every call to GetPassengers.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureGetPassengersResult(t stubs.TB) <-chan []string {
+	sub := stubs.SubscribeMapped(&m.events.GetPassengers, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleGetPassengersEvent) []string {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetPassengersCallSpy starts watching for GetPassengers spy calls and sends them into a channel.
-func (m *mockVehicle) captureGetPassengersCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetPassengersCalls, timeout)
-		ch <- m.getGetPassengersCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureGetPassengersCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetPassengers, "GetPassengers spy call", timeout)
+}
+
+// waitForGetPassengersCalls waits until at least n GetPassengers spy calls are recorded and returns them
+func (m *mockVehicle) waitForGetPassengersCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetPassengers, n, timeout)
+}
+
+// waitForGetPassengersCallWithin waits until a GetPassengers spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForGetPassengersCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetPassengers, match, timeout)
+}
+
+// eventuallyGetPassengersCalls waits until cond holds for the recorded GetPassengers spy calls
+func (m *mockVehicle) eventuallyGetPassengersCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetPassengers, cond, timeout)
+}
+
+// consistentlyGetPassengersNotCalled fails the test if GetPassengers is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyGetPassengersNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetPassengers, window)
+}
+
+// setGetPassengersPanic makes every mocked call to GetPassengers panic with v
+func (m *mockVehicle) setGetPassengersPanic(v any) {
+	m.mocked.GetPassengers.SetPanicResponse(v)
 }
 
-type mockVehicleGetPassengersResult struct {
-	Output0 []string
+// enqueueGetPassengersPanic enqueues a response for GetPassengers that panics with v
+func (m *mockVehicle) enqueueGetPassengersPanic(v any) {
+	m.mocked.GetPassengers.EnqueuePanic(v)
+}
+
+// injectGetPassengersFaults applies a seeded fault policy to GetPassengers. GetPassengers has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectGetPassengersFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetPassengersFaults: GetPassengers has no error output to inject errors into")
+	}
+	m.mocked.GetPassengers.SetFaults(policy)
+}
+
+// clearGetPassengersFaults removes the fault policy from GetPassengers
+func (m *mockVehicle) clearGetPassengersFaults() {
+	m.mocked.GetPassengers.ClearFaults()
+}
+
+// mockVehicleGetPassengersArgs holds the arguments of a call to GetPassengers
+type mockVehicleGetPassengersArgs struct {
+}
+
+// mockVehicleGetPassengersEvent is published to subscribers on every call to GetPassengers
+type mockVehicleGetPassengersEvent struct {
+	Args   mockVehicleGetPassengersArgs
+	Result []string
+}
+
+// mockVehicleGetPassengersCall is a typed record of a call to GetPassengers. Outputs are zero until the call returns.
+type mockVehicleGetPassengersCall struct {
+	Passengers []string
+}
+
+// getGetPassengersTypedCalls returns the spied calls to GetPassengers with typed arguments and results
+func (m *mockVehicle) getGetPassengersTypedCalls() []mockVehicleGetPassengersCall {
+	calls := m.mocked.GetPassengers.Calls()
+	typed := make([]mockVehicleGetPassengersCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].Passengers, _ = call.Results[0].([]string)
+		}
+	}
+	return typed
 }
 
 // setGetPassengersResponse sets the response for GetPassengers
@@ -1242,16 +3462,15 @@ func (m *mockVehicle) enqueueGetPassengersResponse(output0 []string) {
 // enqueueGetPassengersResponseWithDelay enqueues a static response with delay for GetPassengers
 func (m *mockVehicle) enqueueGetPassengersResponseWithDelay(output0 []string, d time.Duration) {
 	m.mocked.GetPassengers.EnqueueWithDelay(func() []string {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- LoadCargo Mock Helpers --------------------------- */
 
-// enableLoadCargoMock turns the spy on
+// enableLoadCargoSpy turns the spy on
 func (m *mockVehicle) enableLoadCargoSpy() {
-	m.mocked.LoadCargo.SpyEnabled = true
+	m.mocked.LoadCargo.EnableSpy()
 }
 
 // getLoadCargoCalls returns recorded calls to LoadCargo
@@ -1259,55 +3478,114 @@ func (m *mockVehicle) getLoadCargoCalls() []stubs.MethodCall {
 	return m.mocked.LoadCargo.Calls()
 }
 
-// enableLoadCargoMock turns the spy off
+// enableLoadCargoSpy turns the spy off
 func (m *mockVehicle) disableLoadCargoSpy() {
-	m.mocked.LoadCargo.SpyEnabled = false
+	m.mocked.LoadCargo.DisableSpy()
+}
+
+// disableLoadCargoArgCloning records arguments and results of LoadCargo by reference instead of deep copying them
+func (m *mockVehicle) disableLoadCargoArgCloning() {
+	m.mocked.LoadCargo.DisableArgCloning()
+}
+
+// enableLoadCargoArgCloning deep copies arguments and results of LoadCargo as they are recorded
+func (m *mockVehicle) enableLoadCargoArgCloning() {
+	m.mocked.LoadCargo.EnableArgCloning()
 }
 
 // LoadCargo overrides the method to return the mock response
 func (m *mockVehicle) LoadCargo(items []string) (int, error) {
-	m.mocked.LoadCargo.RecordCall(items)
+	callID := m.mocked.LoadCargo.RecordCall(items)
+	defer m.mocked.LoadCargo.EndCall(callID)
+	callArgs := mockVehicleLoadCargoArgs{Items: items}
+	m.hooks.LoadCargo.RunBefore(callArgs)
 	var (
 		out0 int
 		out1 error
-
-		result mockVehicleLoadCargoResult
 	)
 
-	if m.mocked.LoadCargo.Enabled {
+	if gateErr := m.mocked.LoadCargo.PassGate(); gateErr != nil {
+		out1 = gateErr
+	} else if faultErr := m.mocked.LoadCargo.ApplyFault(); faultErr != nil {
+		out1 = faultErr
+	} else if m.mocked.LoadCargo.IsEnabled() {
 		out0, out1 = m.mocked.LoadCargo.NextResponse(func(items []string) (int, error) {
 			return m.real.LoadCargo(items)
 		})(items)
-
 	} else {
 		out0, out1 = m.real.LoadCargo(items)
-
 	}
 
-	result = mockVehicleLoadCargoResult{
-		Output0: out0, Output1: out1,
-	}
-	if ch, ok := m.responseChans["LoadCargo"]; ok {
-		chTyped := ch.(chan mockVehicleLoadCargoResult)
-		chTyped <- result
-	}
-	return result.Output0, result.Output1
+	m.mocked.LoadCargo.RecordResults(callID, out0, out1)
+	callResult := mockVehicleLoadCargoResult{Loaded: out0, Err: out1}
+	m.hooks.LoadCargo.RunAfter(callArgs, callResult)
+	m.events.LoadCargo.Publish(mockVehicleLoadCargoEvent{
+		Args:   callArgs,
+		Result: callResult,
+	})
+	return out0, out1
+}
+
+// onLoadCargoBefore runs fn on every call to LoadCargo before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onLoadCargoBefore(fn func(args mockVehicleLoadCargoArgs)) func() {
+	return m.hooks.LoadCargo.Before(fn)
+}
+
+// onLoadCargoAfter runs fn once each call to LoadCargo has its result. The returned func removes the hook.
+func (m *mockVehicle) onLoadCargoAfter(fn func(args mockVehicleLoadCargoArgs, result mockVehicleLoadCargoResult)) func() {
+	return m.hooks.LoadCargo.After(fn)
+}
+
+// notifyLoadCargoCalled returns a channel that is closed when LoadCargo is next called, before it responds
+func (m *mockVehicle) notifyLoadCargoCalled() <-chan struct{} {
+	return m.hooks.LoadCargo.Notify()
+}
+
+// blockLoadCargo holds every later call to LoadCargo mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockLoadCargo() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.LoadCargo.SetGate(g)
+	return g
+}
+
+// unblockLoadCargo removes the gate set by blockLoadCargo, releasing any held calls
+func (m *mockVehicle) unblockLoadCargo() {
+	m.mocked.LoadCargo.ClearGate()
+}
+
+// assertLoadCargoMaxConcurrency fails the test if more than n calls to LoadCargo were ever in flight at once
+func (m *mockVehicle) assertLoadCargoMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.LoadCargo, n)
+}
+
+// assertLoadCargoNeverConcurrent fails the test if any two calls to LoadCargo were in flight at the same time
+func (m *mockVehicle) assertLoadCargoNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.LoadCargo)
+}
 
+// getLoadCargoConcurrencyStats returns the in-flight counts and overlapping calls to LoadCargo
+func (m *mockVehicle) getLoadCargoConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.LoadCargo.ConcurrencyStats()
 }
 
 // setLoadCargoFunc sets the function for LoadCargo
 func (m *mockVehicle) setLoadCargoFunc(f func([]string) (int, error)) {
-	m.mocked.LoadCargo.Fallback = f
+	m.mocked.LoadCargo.SetResponseFunc(f)
 }
 
 // enableLoadCargoMock turns the mock on
 func (m *mockVehicle) enableLoadCargoMock() {
-	m.mocked.LoadCargo.Enabled = true
+	m.mocked.LoadCargo.Enable()
 }
 
 // disableLoadCargoMock turns the mock off
 func (m *mockVehicle) disableLoadCargoMock() {
-	m.mocked.LoadCargo.Enabled = false
+	m.mocked.LoadCargo.Disable()
 }
 
 // enqueueLoadCargoResponseFunc enqueues a function response for LoadCargo
@@ -1320,26 +3598,126 @@ func (m *mockVehicle) enqueueLoadCargoResponseFuncWithDelay(f func([]string) (in
 	m.mocked.LoadCargo.EnqueueWithDelay(f, d)
 }
 
-// captureLoadCargoResult sets up a channel to capture LoadCargo results.
-func (m *mockVehicle) captureLoadCargoResult() <-chan mockVehicleLoadCargoResult {
-	ch := make(chan mockVehicleLoadCargoResult, 1)
-	m.responseChans["LoadCargo"] = ch
-	return ch
+// subscribeLoadCargo returns a subscription that receives an event for every call to LoadCargo. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeLoadCargo(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleLoadCargoEvent] {
+	return m.events.LoadCargo.Subscribe(opts)
+}
+
+// captureLoadCargoResult returns a channel that receives the result of every call to LoadCargo.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureLoadCargoResult(t stubs.TB) <-chan mockVehicleLoadCargoResult {
+	sub := stubs.SubscribeMapped(&m.events.LoadCargo, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleLoadCargoEvent) mockVehicleLoadCargoResult {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureLoadCargoCallSpy starts watching for LoadCargo spy calls and sends them into a channel.
-func (m *mockVehicle) captureLoadCargoCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getLoadCargoCalls, timeout)
-		ch <- m.getLoadCargoCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureLoadCargoCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.LoadCargo, "LoadCargo spy call", timeout)
+}
+
+// waitForLoadCargoCalls waits until at least n LoadCargo spy calls are recorded and returns them
+func (m *mockVehicle) waitForLoadCargoCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.LoadCargo, n, timeout)
+}
+
+// waitForLoadCargoCallWithin waits until a LoadCargo spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForLoadCargoCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.LoadCargo, match, timeout)
+}
+
+// eventuallyLoadCargoCalls waits until cond holds for the recorded LoadCargo spy calls
+func (m *mockVehicle) eventuallyLoadCargoCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.LoadCargo, cond, timeout)
+}
+
+// waitForLoadCargoCallWithArgs waits until LoadCargo is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForLoadCargoCallWithArgs(t stubs.TB, timeout time.Duration, wantItems []string) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.LoadCargo, timeout, wantItems)
+}
+
+// consistentlyLoadCargoNotCalled fails the test if LoadCargo is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyLoadCargoNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.LoadCargo, window)
+}
+
+// setLoadCargoPanic makes every mocked call to LoadCargo panic with v
+func (m *mockVehicle) setLoadCargoPanic(v any) {
+	m.mocked.LoadCargo.SetPanicResponse(v)
 }
 
+// enqueueLoadCargoPanic enqueues a response for LoadCargo that panics with v
+func (m *mockVehicle) enqueueLoadCargoPanic(v any) {
+	m.mocked.LoadCargo.EnqueuePanic(v)
+}
+
+// injectLoadCargoFaults applies a seeded fault policy to LoadCargo. Injected errors are returned in output 1.
+func (m *mockVehicle) injectLoadCargoFaults(policy stubs.FaultPolicy) {
+	m.mocked.LoadCargo.SetFaults(policy)
+}
+
+// clearLoadCargoFaults removes the fault policy from LoadCargo
+func (m *mockVehicle) clearLoadCargoFaults() {
+	m.mocked.LoadCargo.ClearFaults()
+}
+
+// mockVehicleLoadCargoResult holds the outputs of a call to LoadCargo
 type mockVehicleLoadCargoResult struct {
-	Output0 int
-	Output1 error
+	Loaded int
+	Err    error
+}
+
+// Values returns the outputs in the order LoadCargo returns them
+func (r mockVehicleLoadCargoResult) Values() (int, error) {
+	return r.Loaded, r.Err
+}
+
+// ReturnedErr returns the error output
+func (r mockVehicleLoadCargoResult) ReturnedErr() error {
+	return r.Err
+}
+
+// mockVehicleLoadCargoArgs holds the arguments of a call to LoadCargo
+type mockVehicleLoadCargoArgs struct {
+	Items []string
+}
+
+// mockVehicleLoadCargoEvent is published to subscribers on every call to LoadCargo
+type mockVehicleLoadCargoEvent struct {
+	Args   mockVehicleLoadCargoArgs
+	Result mockVehicleLoadCargoResult
+}
+
+// mockVehicleLoadCargoCall is a typed record of a call to LoadCargo. Outputs are zero until the call returns.
+type mockVehicleLoadCargoCall struct {
+	Items  []string
+	Loaded int
+	Err    error
+}
+
+// getLoadCargoTypedCalls returns the spied calls to LoadCargo with typed arguments and results
+func (m *mockVehicle) getLoadCargoTypedCalls() []mockVehicleLoadCargoCall {
+	calls := m.mocked.LoadCargo.Calls()
+	typed := make([]mockVehicleLoadCargoCall, len(calls))
+	for i, call := range calls {
+		typed[i].Items, _ = call.Args[0].([]string)
+		if call.Returned {
+			typed[i].Loaded, _ = call.Results[0].(int)
+			typed[i].Err, _ = call.Results[1].(error)
+		}
+	}
+	return typed
 }
 
 // setLoadCargoResponse sets the response for LoadCargo
@@ -1359,16 +3737,15 @@ func (m *mockVehicle) enqueueLoadCargoResponse(output0 int, output1 error) {
 // enqueueLoadCargoResponseWithDelay enqueues a static response with delay for LoadCargo
 func (m *mockVehicle) enqueueLoadCargoResponseWithDelay(output0 int, output1 error, d time.Duration) {
 	m.mocked.LoadCargo.EnqueueWithDelay(func([]string) (int, error) {
-		time.Sleep(d)
 		return output0, output1
 	}, d)
 }
 
 /* -------------------------- GetVehicleStatus Mock Helpers --------------------------- */
 
-// enableGetVehicleStatusMock turns the spy on
+// enableGetVehicleStatusSpy turns the spy on
 func (m *mockVehicle) enableGetVehicleStatusSpy() {
-	m.mocked.GetVehicleStatus.SpyEnabled = true
+	m.mocked.GetVehicleStatus.EnableSpy()
 }
 
 // getGetVehicleStatusCalls returns recorded calls to GetVehicleStatus
@@ -1376,49 +3753,115 @@ func (m *mockVehicle) getGetVehicleStatusCalls() []stubs.MethodCall {
 	return m.mocked.GetVehicleStatus.Calls()
 }
 
-// enableGetVehicleStatusMock turns the spy off
+// enableGetVehicleStatusSpy turns the spy off
 func (m *mockVehicle) disableGetVehicleStatusSpy() {
-	m.mocked.GetVehicleStatus.SpyEnabled = false
+	m.mocked.GetVehicleStatus.DisableSpy()
+}
+
+// disableGetVehicleStatusArgCloning records arguments and results of GetVehicleStatus by reference instead of deep copying them
+func (m *mockVehicle) disableGetVehicleStatusArgCloning() {
+	m.mocked.GetVehicleStatus.DisableArgCloning()
+}
+
+// enableGetVehicleStatusArgCloning deep copies arguments and results of GetVehicleStatus as they are recorded
+func (m *mockVehicle) enableGetVehicleStatusArgCloning() {
+	m.mocked.GetVehicleStatus.EnableArgCloning()
 }
 
 // GetVehicleStatus overrides the method to return the mock response
 func (m *mockVehicle) GetVehicleStatus() vehicle.VehicleStatus {
-	m.mocked.GetVehicleStatus.RecordCall()
+	callID := m.mocked.GetVehicleStatus.RecordCall()
+	defer m.mocked.GetVehicleStatus.EndCall(callID)
+	callArgs := mockVehicleGetVehicleStatusArgs{}
+	m.hooks.GetVehicleStatus.RunBefore(callArgs)
 	var (
 		out0 vehicle.VehicleStatus
 	)
 
-	if m.mocked.GetVehicleStatus.Enabled {
+	gatePassed := m.mocked.GetVehicleStatus.PassGateOrReport()
+	if gatePassed {
+		m.mocked.GetVehicleStatus.ApplyFault()
+	}
+
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.GetVehicleStatus.IsEnabled() {
 		out0 = m.mocked.GetVehicleStatus.NextResponse(func() vehicle.VehicleStatus {
 			return m.real.GetVehicleStatus()
 		})()
-
 	} else {
 		out0 = m.real.GetVehicleStatus()
-
 	}
 
-	if ch, ok := m.responseChans["GetVehicleStatus"]; ok {
-		chTyped := ch.(chan vehicle.VehicleStatus)
-		chTyped <- out0
-	}
+	m.mocked.GetVehicleStatus.RecordResults(callID, out0)
+	m.hooks.GetVehicleStatus.RunAfter(callArgs, out0)
+	m.events.GetVehicleStatus.Publish(mockVehicleGetVehicleStatusEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
+
+// onGetVehicleStatusBefore runs fn on every call to GetVehicleStatus before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onGetVehicleStatusBefore(fn func(args mockVehicleGetVehicleStatusArgs)) func() {
+	return m.hooks.GetVehicleStatus.Before(fn)
+}
+
+// onGetVehicleStatusAfter runs fn once each call to GetVehicleStatus has its result. The returned func removes the hook.
+func (m *mockVehicle) onGetVehicleStatusAfter(fn func(args mockVehicleGetVehicleStatusArgs, result vehicle.VehicleStatus)) func() {
+	return m.hooks.GetVehicleStatus.After(fn)
+}
+
+// notifyGetVehicleStatusCalled returns a channel that is closed when GetVehicleStatus is next called, before it responds
+func (m *mockVehicle) notifyGetVehicleStatusCalled() <-chan struct{} {
+	return m.hooks.GetVehicleStatus.Notify()
+}
+
+// blockGetVehicleStatus holds every later call to GetVehicleStatus mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockGetVehicleStatus() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.GetVehicleStatus.SetGate(g)
+	return g
+}
+
+// unblockGetVehicleStatus removes the gate set by blockGetVehicleStatus, releasing any held calls
+func (m *mockVehicle) unblockGetVehicleStatus() {
+	m.mocked.GetVehicleStatus.ClearGate()
+}
+
+// assertGetVehicleStatusMaxConcurrency fails the test if more than n calls to GetVehicleStatus were ever in flight at once
+func (m *mockVehicle) assertGetVehicleStatusMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.GetVehicleStatus, n)
+}
+
+// assertGetVehicleStatusNeverConcurrent fails the test if any two calls to GetVehicleStatus were in flight at the same time
+func (m *mockVehicle) assertGetVehicleStatusNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.GetVehicleStatus)
+}
 
+// getGetVehicleStatusConcurrencyStats returns the in-flight counts and overlapping calls to GetVehicleStatus
+func (m *mockVehicle) getGetVehicleStatusConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.GetVehicleStatus.ConcurrencyStats()
 }
 
 // setGetVehicleStatusFunc sets the function for GetVehicleStatus
 func (m *mockVehicle) setGetVehicleStatusFunc(f func() vehicle.VehicleStatus) {
-	m.mocked.GetVehicleStatus.Fallback = f
+	m.mocked.GetVehicleStatus.SetResponseFunc(f)
 }
 
 // enableGetVehicleStatusMock turns the mock on
 func (m *mockVehicle) enableGetVehicleStatusMock() {
-	m.mocked.GetVehicleStatus.Enabled = true
+	m.mocked.GetVehicleStatus.Enable()
 }
 
 // disableGetVehicleStatusMock turns the mock off
 func (m *mockVehicle) disableGetVehicleStatusMock() {
-	m.mocked.GetVehicleStatus.Enabled = false
+	m.mocked.GetVehicleStatus.Disable()
 }
 
 // enqueueGetVehicleStatusResponseFunc enqueues a function response for GetVehicleStatus
@@ -1431,25 +3874,101 @@ func (m *mockVehicle) enqueueGetVehicleStatusResponseFuncWithDelay(f func() vehi
 	m.mocked.GetVehicleStatus.EnqueueWithDelay(f, d)
 }
 
-// captureGetVehicleStatusResult sets up a channel to capture GetVehicleStatus results.
-func (m *mockVehicle) captureGetVehicleStatusResult() <-chan vehicle.VehicleStatus {
-	ch := make(chan vehicle.VehicleStatus, 1)
-	m.responseChans["GetVehicleStatus"] = ch
-	return ch
+// subscribeGetVehicleStatus returns a subscription that receives an event for every call to GetVehicleStatus. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeGetVehicleStatus(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleGetVehicleStatusEvent] {
+	return m.events.GetVehicleStatus.Subscribe(opts)
+}
+
+// captureGetVehicleStatusResult returns a channel that receives the result of every call to GetVehicleStatus.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureGetVehicleStatusResult(t stubs.TB) <-chan vehicle.VehicleStatus {
+	sub := stubs.SubscribeMapped(&m.events.GetVehicleStatus, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleGetVehicleStatusEvent) vehicle.VehicleStatus {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureGetVehicleStatusCallSpy starts watching for GetVehicleStatus spy calls and sends them into a channel.
-func (m *mockVehicle) captureGetVehicleStatusCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getGetVehicleStatusCalls, timeout)
-		ch <- m.getGetVehicleStatusCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureGetVehicleStatusCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.GetVehicleStatus, "GetVehicleStatus spy call", timeout)
+}
+
+// waitForGetVehicleStatusCalls waits until at least n GetVehicleStatus spy calls are recorded and returns them
+func (m *mockVehicle) waitForGetVehicleStatusCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.GetVehicleStatus, n, timeout)
+}
+
+// waitForGetVehicleStatusCallWithin waits until a GetVehicleStatus spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForGetVehicleStatusCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.GetVehicleStatus, match, timeout)
+}
+
+// eventuallyGetVehicleStatusCalls waits until cond holds for the recorded GetVehicleStatus spy calls
+func (m *mockVehicle) eventuallyGetVehicleStatusCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.GetVehicleStatus, cond, timeout)
+}
+
+// consistentlyGetVehicleStatusNotCalled fails the test if GetVehicleStatus is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyGetVehicleStatusNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.GetVehicleStatus, window)
+}
+
+// setGetVehicleStatusPanic makes every mocked call to GetVehicleStatus panic with v
+func (m *mockVehicle) setGetVehicleStatusPanic(v any) {
+	m.mocked.GetVehicleStatus.SetPanicResponse(v)
+}
+
+// enqueueGetVehicleStatusPanic enqueues a response for GetVehicleStatus that panics with v
+func (m *mockVehicle) enqueueGetVehicleStatusPanic(v any) {
+	m.mocked.GetVehicleStatus.EnqueuePanic(v)
+}
+
+// injectGetVehicleStatusFaults applies a seeded fault policy to GetVehicleStatus. GetVehicleStatus has no error output, so only latency and panics can be injected.
+func (m *mockVehicle) injectGetVehicleStatusFaults(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("injectGetVehicleStatusFaults: GetVehicleStatus has no error output to inject errors into")
+	}
+	m.mocked.GetVehicleStatus.SetFaults(policy)
+}
+
+// clearGetVehicleStatusFaults removes the fault policy from GetVehicleStatus
+func (m *mockVehicle) clearGetVehicleStatusFaults() {
+	m.mocked.GetVehicleStatus.ClearFaults()
+}
+
+// mockVehicleGetVehicleStatusArgs holds the arguments of a call to GetVehicleStatus
+type mockVehicleGetVehicleStatusArgs struct {
+}
+
+// mockVehicleGetVehicleStatusEvent is published to subscribers on every call to GetVehicleStatus
+type mockVehicleGetVehicleStatusEvent struct {
+	Args   mockVehicleGetVehicleStatusArgs
+	Result vehicle.VehicleStatus
 }
 
-type mockVehicleGetVehicleStatusResult struct {
-	Output0 vehicle.VehicleStatus
+// mockVehicleGetVehicleStatusCall is a typed record of a call to GetVehicleStatus. Outputs are zero until the call returns.
+type mockVehicleGetVehicleStatusCall struct {
+	VehicleStatus vehicle.VehicleStatus
+}
+
+// getGetVehicleStatusTypedCalls returns the spied calls to GetVehicleStatus with typed arguments and results
+func (m *mockVehicle) getGetVehicleStatusTypedCalls() []mockVehicleGetVehicleStatusCall {
+	calls := m.mocked.GetVehicleStatus.Calls()
+	typed := make([]mockVehicleGetVehicleStatusCall, len(calls))
+	for i, call := range calls {
+		if call.Returned {
+			typed[i].VehicleStatus, _ = call.Results[0].(vehicle.VehicleStatus)
+		}
+	}
+	return typed
 }
 
 // setGetVehicleStatusResponse sets the response for GetVehicleStatus
@@ -1469,16 +3988,15 @@ func (m *mockVehicle) enqueueGetVehicleStatusResponse(output0 vehicle.VehicleSta
 // enqueueGetVehicleStatusResponseWithDelay enqueues a static response with delay for GetVehicleStatus
 func (m *mockVehicle) enqueueGetVehicleStatusResponseWithDelay(output0 vehicle.VehicleStatus, d time.Duration) {
 	m.mocked.GetVehicleStatus.EnqueueWithDelay(func() vehicle.VehicleStatus {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
 
 /* -------------------------- UpdateStatus Mock Helpers --------------------------- */
 
-// enableUpdateStatusMock turns the spy on
+// enableUpdateStatusSpy turns the spy on
 func (m *mockVehicle) enableUpdateStatusSpy() {
-	m.mocked.UpdateStatus.SpyEnabled = true
+	m.mocked.UpdateStatus.EnableSpy()
 }
 
 // getUpdateStatusCalls returns recorded calls to UpdateStatus
@@ -1486,49 +4004,112 @@ func (m *mockVehicle) getUpdateStatusCalls() []stubs.MethodCall {
 	return m.mocked.UpdateStatus.Calls()
 }
 
-// enableUpdateStatusMock turns the spy off
+// enableUpdateStatusSpy turns the spy off
 func (m *mockVehicle) disableUpdateStatusSpy() {
-	m.mocked.UpdateStatus.SpyEnabled = false
+	m.mocked.UpdateStatus.DisableSpy()
+}
+
+// disableUpdateStatusArgCloning records arguments and results of UpdateStatus by reference instead of deep copying them
+func (m *mockVehicle) disableUpdateStatusArgCloning() {
+	m.mocked.UpdateStatus.DisableArgCloning()
+}
+
+// enableUpdateStatusArgCloning deep copies arguments and results of UpdateStatus as they are recorded
+func (m *mockVehicle) enableUpdateStatusArgCloning() {
+	m.mocked.UpdateStatus.EnableArgCloning()
 }
 
 // UpdateStatus overrides the method to return the mock response
 func (m *mockVehicle) UpdateStatus(status vehicle.VehicleStatus) error {
-	m.mocked.UpdateStatus.RecordCall(status)
+	callID := m.mocked.UpdateStatus.RecordCall(status)
+	defer m.mocked.UpdateStatus.EndCall(callID)
+	callArgs := mockVehicleUpdateStatusArgs{Status: status}
+	m.hooks.UpdateStatus.RunBefore(callArgs)
 	var (
 		out0 error
 	)
 
-	if m.mocked.UpdateStatus.Enabled {
+	if gateErr := m.mocked.UpdateStatus.PassGate(); gateErr != nil {
+		out0 = gateErr
+	} else if faultErr := m.mocked.UpdateStatus.ApplyFault(); faultErr != nil {
+		out0 = faultErr
+	} else if m.mocked.UpdateStatus.IsEnabled() {
 		out0 = m.mocked.UpdateStatus.NextResponse(func(status vehicle.VehicleStatus) error {
 			return m.real.UpdateStatus(status)
 		})(status)
-
 	} else {
 		out0 = m.real.UpdateStatus(status)
-
 	}
 
-	if ch, ok := m.responseChans["UpdateStatus"]; ok {
-		chTyped := ch.(chan error)
-		chTyped <- out0
-	}
+	m.mocked.UpdateStatus.RecordResults(callID, out0)
+	m.hooks.UpdateStatus.RunAfter(callArgs, out0)
+	m.events.UpdateStatus.Publish(mockVehicleUpdateStatusEvent{
+		Args:   callArgs,
+		Result: out0,
+	})
 	return out0
+}
 
+// onUpdateStatusBefore runs fn on every call to UpdateStatus before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *mockVehicle) onUpdateStatusBefore(fn func(args mockVehicleUpdateStatusArgs)) func() {
+	return m.hooks.UpdateStatus.Before(fn)
+}
+
+// onUpdateStatusAfter runs fn once each call to UpdateStatus has its result. The returned func removes the hook.
+func (m *mockVehicle) onUpdateStatusAfter(fn func(args mockVehicleUpdateStatusArgs, result error)) func() {
+	return m.hooks.UpdateStatus.After(fn)
+}
+
+// notifyUpdateStatusCalled returns a channel that is closed when UpdateStatus is next called, before it responds
+func (m *mockVehicle) notifyUpdateStatusCalled() <-chan struct{} {
+	return m.hooks.UpdateStatus.Notify()
+}
+
+// blockUpdateStatus holds every later call to UpdateStatus mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *mockVehicle) blockUpdateStatus() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.UpdateStatus.SetGate(g)
+	return g
+}
+
+// unblockUpdateStatus removes the gate set by blockUpdateStatus, releasing any held calls
+func (m *mockVehicle) unblockUpdateStatus() {
+	m.mocked.UpdateStatus.ClearGate()
+}
+
+// assertUpdateStatusMaxConcurrency fails the test if more than n calls to UpdateStatus were ever in flight at once
+func (m *mockVehicle) assertUpdateStatusMaxConcurrency(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.UpdateStatus, n)
+}
+
+// assertUpdateStatusNeverConcurrent fails the test if any two calls to UpdateStatus were in flight at the same time
+func (m *mockVehicle) assertUpdateStatusNeverConcurrent(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.UpdateStatus)
+}
+
+// getUpdateStatusConcurrencyStats returns the in-flight counts and overlapping calls to UpdateStatus
+func (m *mockVehicle) getUpdateStatusConcurrencyStats() stubs.ConcurrencyStats {
+	return m.mocked.UpdateStatus.ConcurrencyStats()
 }
 
 // setUpdateStatusFunc sets the function for UpdateStatus
 func (m *mockVehicle) setUpdateStatusFunc(f func(vehicle.VehicleStatus) error) {
-	m.mocked.UpdateStatus.Fallback = f
+	m.mocked.UpdateStatus.SetResponseFunc(f)
 }
 
 // enableUpdateStatusMock turns the mock on
 func (m *mockVehicle) enableUpdateStatusMock() {
-	m.mocked.UpdateStatus.Enabled = true
+	m.mocked.UpdateStatus.Enable()
 }
 
 // disableUpdateStatusMock turns the mock off
 func (m *mockVehicle) disableUpdateStatusMock() {
-	m.mocked.UpdateStatus.Enabled = false
+	m.mocked.UpdateStatus.Disable()
 }
 
 // enqueueUpdateStatusResponseFunc enqueues a function response for UpdateStatus
@@ -1541,25 +4122,108 @@ func (m *mockVehicle) enqueueUpdateStatusResponseFuncWithDelay(f func(vehicle.Ve
 	m.mocked.UpdateStatus.EnqueueWithDelay(f, d)
 }
 
-// captureUpdateStatusResult sets up a channel to capture UpdateStatus results.
-func (m *mockVehicle) captureUpdateStatusResult() <-chan error {
-	ch := make(chan error, 1)
-	m.responseChans["UpdateStatus"] = ch
-	return ch
+// subscribeUpdateStatus returns a subscription that receives an event for every call to UpdateStatus. Call Unsubscribe when done.
+func (m *mockVehicle) subscribeUpdateStatus(opts stubs.SubscribeOptions) *stubs.Subscription[mockVehicleUpdateStatusEvent] {
+	return m.events.UpdateStatus.Subscribe(opts)
+}
+
+// captureUpdateStatusResult returns a channel that receives the result of every call to UpdateStatus.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *mockVehicle) captureUpdateStatusResult(t stubs.TB) <-chan error {
+	sub := stubs.SubscribeMapped(&m.events.UpdateStatus, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e mockVehicleUpdateStatusEvent) error {
+		return e.Result
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }
 
 // captureUpdateStatusCallSpy starts watching for UpdateStatus spy calls and sends them into a channel.
-func (m *mockVehicle) captureUpdateStatusCallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.getUpdateStatusCalls, timeout)
-		ch <- m.getUpdateStatusCalls()
-	}()
-	return ch
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *mockVehicle) captureUpdateStatusCallSpy(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.UpdateStatus, "UpdateStatus spy call", timeout)
+}
+
+// waitForUpdateStatusCalls waits until at least n UpdateStatus spy calls are recorded and returns them
+func (m *mockVehicle) waitForUpdateStatusCalls(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.UpdateStatus, n, timeout)
+}
+
+// waitForUpdateStatusCallWithin waits until a UpdateStatus spy call matching match is recorded and returns it
+func (m *mockVehicle) waitForUpdateStatusCallWithin(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.UpdateStatus, match, timeout)
+}
+
+// eventuallyUpdateStatusCalls waits until cond holds for the recorded UpdateStatus spy calls
+func (m *mockVehicle) eventuallyUpdateStatusCalls(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.UpdateStatus, cond, timeout)
 }
 
-type mockVehicleUpdateStatusResult struct {
-	Output0 error
+// waitForUpdateStatusCallWithArgs waits until UpdateStatus is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *mockVehicle) waitForUpdateStatusCallWithArgs(t stubs.TB, timeout time.Duration, wantStatus vehicle.VehicleStatus) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.UpdateStatus, timeout, wantStatus)
+}
+
+// consistentlyUpdateStatusNotCalled fails the test if UpdateStatus is called during window, or if its spy is not enabled
+func (m *mockVehicle) consistentlyUpdateStatusNotCalled(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.UpdateStatus, window)
+}
+
+// setUpdateStatusPanic makes every mocked call to UpdateStatus panic with v
+func (m *mockVehicle) setUpdateStatusPanic(v any) {
+	m.mocked.UpdateStatus.SetPanicResponse(v)
+}
+
+// enqueueUpdateStatusPanic enqueues a response for UpdateStatus that panics with v
+func (m *mockVehicle) enqueueUpdateStatusPanic(v any) {
+	m.mocked.UpdateStatus.EnqueuePanic(v)
+}
+
+// injectUpdateStatusFaults applies a seeded fault policy to UpdateStatus. Injected errors are returned in output 0.
+func (m *mockVehicle) injectUpdateStatusFaults(policy stubs.FaultPolicy) {
+	m.mocked.UpdateStatus.SetFaults(policy)
+}
+
+// clearUpdateStatusFaults removes the fault policy from UpdateStatus
+func (m *mockVehicle) clearUpdateStatusFaults() {
+	m.mocked.UpdateStatus.ClearFaults()
+}
+
+// mockVehicleUpdateStatusArgs holds the arguments of a call to UpdateStatus
+type mockVehicleUpdateStatusArgs struct {
+	Status vehicle.VehicleStatus
+}
+
+// mockVehicleUpdateStatusEvent is published to subscribers on every call to UpdateStatus
+type mockVehicleUpdateStatusEvent struct {
+	Args   mockVehicleUpdateStatusArgs
+	Result error
+}
+
+// mockVehicleUpdateStatusCall is a typed record of a call to UpdateStatus. Outputs are zero until the call returns.
+type mockVehicleUpdateStatusCall struct {
+	Status vehicle.VehicleStatus
+	Err    error
+}
+
+// getUpdateStatusTypedCalls returns the spied calls to UpdateStatus with typed arguments and results
+func (m *mockVehicle) getUpdateStatusTypedCalls() []mockVehicleUpdateStatusCall {
+	calls := m.mocked.UpdateStatus.Calls()
+	typed := make([]mockVehicleUpdateStatusCall, len(calls))
+	for i, call := range calls {
+		typed[i].Status, _ = call.Args[0].(vehicle.VehicleStatus)
+		if call.Returned {
+			typed[i].Err, _ = call.Results[0].(error)
+		}
+	}
+	return typed
 }
 
 // setUpdateStatusResponse sets the response for UpdateStatus
@@ -1579,7 +4243,6 @@ func (m *mockVehicle) enqueueUpdateStatusResponse(output0 error) {
 // enqueueUpdateStatusResponseWithDelay enqueues a static response with delay for UpdateStatus
 func (m *mockVehicle) enqueueUpdateStatusResponseWithDelay(output0 error, d time.Duration) {
 	m.mocked.UpdateStatus.EnqueueWithDelay(func(vehicle.VehicleStatus) error {
-		time.Sleep(d)
 		return output0
 	}, d)
 }
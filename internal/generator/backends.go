@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// MockBackend emits the mock of one interface in a particular style
+type MockBackend interface {
+	// Name is the value used to select the backend in YAML
+	Name() string
+	// Generate writes a complete Go file containing the mock
+	Generate(w io.Writer, spec InterfaceSpec, structSpec StructSpec, common CommonSpec) error
+}
+
+// mockBackends holds every backend by name. native is used when none is selected.
+var mockBackends = map[string]MockBackend{}
+
+func init() {
+	for _, b := range []MockBackend{nativeBackend{}, gomockBackend{}, testifyBackend{}} {
+		RegisterMockBackend(b)
+	}
+}
+
+// RegisterMockBackend makes a backend selectable by its name, replacing any backend with the same name
+func RegisterMockBackend(b MockBackend) {
+	mockBackends[b.Name()] = b
+}
+
+// mockBackendFor returns the backend selected for an interface, falling back to mocks.backend and then native
+func mockBackendFor(spec InterfaceSpec, mocks MockSpec) (MockBackend, error) {
+	name := spec.MockBackend
+	if name == "" {
+		name = mocks.Backend
+	}
+	if name == "" {
+		name = "native"
+	}
+	backend, ok := mockBackends[name]
+	if !ok {
+		names := make([]string, 0, len(mockBackends))
+		for n := range mockBackends {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown mock backend %q for %s, expected one of %s", name, spec.Name, strings.Join(names, ", "))
+	}
+	return backend, nil
+}
+
+// backendData is the template data shared by the gomock and testify backends
+type backendData struct {
+	Interface   string
+	MockName    string
+	MockFactory string
+	Methods     []Method
+	Package     string
+	MockPackage string
+}
+
+func newBackendData(spec InterfaceSpec, common CommonSpec) backendData {
+	mockName, mockFactory := common.Mocks.mockNames(spec.Name)
+	return backendData{
+		Interface:   spec.Name,
+		MockName:    mockName,
+		MockFactory: mockFactory,
+		Methods:     spec.Methods,
+		Package:     common.Package,
+		MockPackage: common.Mocks.packageName(common.Importer),
+	}
+}
+
+// Positional argument names avoid clashes between YAML param names and the receiver or locals
+const backendSignatureTemplates = `
+{{- define "params" }}{{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}arg{{ $i }} {{ $p.Type }}{{ end }}{{ end }}
+{{- define "args" }}{{ range $i, $_ := .Inputs }}, arg{{ $i }}{{ end }}{{ end }}
+{{- define "results" }}{{ if eq (len .Outputs) 1 }} {{ (index .Outputs 0).Type }}{{ else if .Outputs }} ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}){{ end }}{{ end }}
+{{- define "returns" }}{{ if .Outputs }}
+	return {{ range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}ret{{ $i }}{{ end }}{{ end }}{{ end }}`
+
+// gomockBackend emits mocks in the style of mockgen, driven by a gomock.Controller and EXPECT()
+type gomockBackend struct{}
+
+func (gomockBackend) Name() string {
+	return "gomock"
+}
+
+const gomockTemplate = `package {{ .MockPackage }}
+
+import (
+	"reflect"
+
+	"github.com/jackclarke/GoStubGen/generated/{{ .Package }}"
+	"go.uber.org/mock/gomock"
+)
+
+// {{ .MockName }} is a gomock mock of {{ .Interface }}
+type {{ .MockName }} struct {
+	ctrl     *gomock.Controller
+	recorder *{{ .MockName }}Recorder
+}
+
+// {{ .MockName }}Recorder records expected calls to {{ .MockName }}
+type {{ .MockName }}Recorder struct {
+	mock *{{ .MockName }}
+}
+
+// {{ .MockFactory }} returns a mock whose expectations are checked by ctrl
+func {{ .MockFactory }}(ctrl *gomock.Controller) *{{ .MockName }} {
+	m := &{{ .MockName }}{ctrl: ctrl}
+	m.recorder = &{{ .MockName }}Recorder{mock: m}
+	return m
+}
+
+// EXPECT returns the recorder used to set expectations
+func (m *{{ .MockName }}) EXPECT() *{{ .MockName }}Recorder {
+	return m.recorder
+}
+
+var _ {{ .Package }}.{{ .Interface }} = (*{{ .MockName }})(nil)
+{{ range .Methods }}
+// {{ .Name }} calls through the controller to the matching expectation
+func (m *{{ $.MockName }}) {{ .Name }}({{ template "params" . }}){{ template "results" . }} {
+	m.ctrl.T.Helper()
+	{{ if .Outputs }}ret := {{ end }}m.ctrl.Call(m, "{{ .Name }}"{{ template "args" . }})
+	{{- range $i, $o := .Outputs }}
+	ret{{ $i }}, _ := ret[{{ $i }}].({{ $o.Type }})
+	{{- end }}
+	{{- template "returns" . }}
+}
+
+// {{ .Name }} expects a call to {{ .Name }} with arguments matching the given values or gomock matchers
+func (mr *{{ $.MockName }}Recorder) {{ .Name }}({{ range $i, $_ := .Inputs }}{{ if $i }}, {{ end }}arg{{ $i }}{{ end }}{{ if .Inputs }} any{{ end }}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "{{ .Name }}", reflect.TypeOf((*{{ $.MockName }})(nil).{{ .Name }}){{ template "args" . }})
+}
+{{ end }}`
+
+func (gomockBackend) Generate(w io.Writer, spec InterfaceSpec, _ StructSpec, common CommonSpec) error {
+	return writeBackendTemplate(w, gomockTemplate, newBackendData(spec, common), common.Mocks)
+}
+
+// testifyBackend emits mocks embedding testify's mock.Mock, configured with On and checked with AssertExpectations
+type testifyBackend struct{}
+
+func (testifyBackend) Name() string {
+	return "testify"
+}
+
+const testifyTemplate = `package {{ .MockPackage }}
+
+import (
+	"github.com/jackclarke/GoStubGen/generated/{{ .Package }}"
+	"github.com/stretchr/testify/mock"
+)
+
+// {{ .MockName }} is a testify mock of {{ .Interface }}. Set expectations with On.
+type {{ .MockName }} struct {
+	mock.Mock
+}
+
+// {{ .MockFactory }} returns a mock that asserts its expectations when t finishes
+func {{ .MockFactory }}(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *{{ .MockName }} {
+	m := &{{ .MockName }}{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+var _ {{ .Package }}.{{ .Interface }} = (*{{ .MockName }})(nil)
+{{ range .Methods }}
+// {{ .Name }} {{ if .Outputs }}returns the values given to Return on{{ else }}records a call against{{ end }} the matching expectation
+func (m *{{ $.MockName }}) {{ .Name }}({{ template "params" . }}){{ template "results" . }} {
+	{{ if .Outputs }}ret := {{ end }}m.Called({{ range $i, $_ := .Inputs }}{{ if $i }}, {{ end }}arg{{ $i }}{{ end }})
+	{{- range $i, $o := .Outputs }}
+	{{- if eq $o.Type "error" }}
+	ret{{ $i }} := ret.Error({{ $i }})
+	{{- else }}
+	ret{{ $i }}, _ := ret.Get({{ $i }}).({{ $o.Type }})
+	{{- end }}
+	{{- end }}
+	{{- template "returns" . }}
+}
+{{ end }}`
+
+func (testifyBackend) Generate(w io.Writer, spec InterfaceSpec, _ StructSpec, common CommonSpec) error {
+	return writeBackendTemplate(w, testifyTemplate, newBackendData(spec, common), common.Mocks)
+}
+
+func writeBackendTemplate(w io.Writer, tmplStr string, data backendData, mocks MockSpec) error {
+	tmpl, err := template.New("mock").Funcs(mockFuncs(mocks)).Parse(backendSignatureTemplates)
+	if err != nil {
+		return fmt.Errorf("failed to parse mock templates: %w", err)
+	}
+	if _, err := tmpl.Parse(tmplStr); err != nil {
+		return fmt.Errorf("failed to parse mock templates: %w", err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to write mock: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,24 @@
 package generator
 
 type CommonSpec struct {
-	Package  string `yaml:"package"`
-	Importer string `yaml:"importer"`
+	Package  string   `yaml:"package"`
+	Importer string   `yaml:"importer"`
+	Mocks    MockSpec `yaml:"mocks"`
+}
+
+// MockSpec configures how mocks are emitted
+type MockSpec struct {
+	// Package the mocks are written to. Defaults to the importer package
+	Package string `yaml:"package,omitempty"`
+	// Visibility is "unexported" (default) for mocks only the importer's tests can use,
+	// or "exported" for an importable package e.g. vehiclemock.NewVehicle(real)
+	Visibility string `yaml:"visibility,omitempty"`
+	// FileSuffix is appended to the lowercased interface name. Defaults to _mock_test.go, or _mock.go when exported
+	FileSuffix string `yaml:"file_suffix,omitempty"`
+	// ExternalTestPackage declares the mocks in <package>_test
+	ExternalTestPackage bool `yaml:"external_test_package,omitempty"`
+	// Backend is the default mock style: native (default), gomock or testify
+	Backend string `yaml:"backend,omitempty"`
 }
 
 // InterfaceSpec represents an interface definition
@@ -10,6 +26,8 @@ type InterfaceSpec struct {
 	Name     string   `yaml:"name"`
 	Embedded []string `yaml:"embedded"`
 	Methods  []Method `yaml:"methods"`
+	// MockBackend overrides mocks.backend for this interface
+	MockBackend string `yaml:"mock_backend,omitempty"`
 }
 
 // The top level yaml entry containing all interfaces
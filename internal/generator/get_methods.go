@@ -19,6 +19,9 @@ type InterfaceNameToMethodMap map[string]MethodNameToMethodMap
 // StructNameToMethodMap maps struct names to the full set of methods required by the interfaces they implement (including embedded structs)
 type StructNameToMethodMap map[string]MethodNameToMethodMap
 
+// NameToMethodOrigins maps interface or struct names to the origin of each method in their full set
+type NameToMethodOrigins map[string]map[string]MethodOrigin
+
 // each entry maps an interface or struct name to its method set
 type MethodSets struct {
 	// full set of methods a struct must have to implement interfaces specified in yaml
@@ -27,6 +30,8 @@ type MethodSets struct {
 	UniqueSets map[string][]Method
 	// methods provided by embedded structs
 	EmbeddedSets map[string][]Method
+	// why each method in the full set is there, keyed by type then method name
+	Origins NameToMethodOrigins
 }
 
 // mapSpecsByName takes a slice of specs (struct or interface) and returns a map from name to spec for quick lookup
@@ -38,13 +43,15 @@ func mapSpecsByName[T interface{ getName() string }](items []T) map[string]T {
 	return m
 }
 
-// BuildMethodSetMap recursively builds the method set for an interface, including methods from embedded interfaces
-func (interfaceMethods InterfaceNameToMethodMap) BuildMethodSetMap(interfaceSpecs InterfaceNameToSpec, name string) {
+// BuildMethodSetMap recursively builds the method set for an interface, including methods from embedded interfaces,
+// and records the origin of each method. Methods declared directly keep their origin over embedded ones.
+func (interfaceMethods InterfaceNameToMethodMap) BuildMethodSetMap(interfaceSpecs InterfaceNameToSpec, origins NameToMethodOrigins, name string) {
 	if _, exists := interfaceMethods[name]; exists {
 		return
 	}
 
 	interfaceMethods[name] = MethodNameToMethodMap{}
+	origins[name] = map[string]MethodOrigin{}
 
 	spec, found := interfaceSpecs[name]
 	if !found {
@@ -55,26 +62,30 @@ func (interfaceMethods InterfaceNameToMethodMap) BuildMethodSetMap(interfaceSpec
 	// Add methods declared directly in the interface
 	for _, method := range spec.Methods {
 		interfaceMethods[name][method.Name] = method
+		origins[name][method.Name] = MethodOrigin{Method: method.Name}
 	}
 
 	// Recursively add methods from embedded interfaces. Todo MAKE OPTIONAL
 	for _, embeddedName := range spec.Embedded {
 		if _, exists := interfaceMethods[embeddedName]; !exists {
-			interfaceMethods.BuildMethodSetMap(interfaceSpecs, embeddedName)
+			interfaceMethods.BuildMethodSetMap(interfaceSpecs, origins, embeddedName)
 		}
 		for methodName, method := range interfaceMethods[embeddedName] {
 			interfaceMethods[name][methodName] = method
+			origins.addEmbedded(name, embeddedName, methodName)
 		}
 	}
 }
 
-// BuildMethodSetMap recursively builds the method set for a struct, including methods from embedded structs and implemented interfaces
-func (structMethods StructNameToMethodMap) BuildMethodSetMap(structSpecs StructNameToSpec, interfaceMethods InterfaceNameToMethodMap, name string) {
+// BuildMethodSetMap recursively builds the method set for a struct, including methods from embedded structs and implemented interfaces,
+// and records the origin of each method in structOrigins. Implemented interfaces keep their origin over embedded structs.
+func (structMethods StructNameToMethodMap) BuildMethodSetMap(structSpecs StructNameToSpec, interfaceMethods InterfaceNameToMethodMap, interfaceOrigins, structOrigins NameToMethodOrigins, name string) {
 	if _, exists := structMethods[name]; exists {
 		return
 	}
 
 	structMethods[name] = MethodNameToMethodMap{}
+	structOrigins[name] = map[string]MethodOrigin{}
 
 	spec, found := structSpecs[name]
 	if !found {
@@ -87,6 +98,10 @@ func (structMethods StructNameToMethodMap) BuildMethodSetMap(structSpecs StructN
 		if methods, found := interfaceMethods[interfaceName]; found {
 			for methodName, method := range methods {
 				structMethods[name][methodName] = method
+				if _, ok := structOrigins[name][methodName]; !ok {
+					via := prepend(interfaceName, interfaceOrigins[interfaceName][methodName].Via)
+					structOrigins[name][methodName] = MethodOrigin{Method: methodName, Via: via}
+				}
 			}
 		} else {
 			fmt.Printf("Warning: Interface %s not found for struct %s\n", interfaceName, name)
@@ -96,14 +111,28 @@ func (structMethods StructNameToMethodMap) BuildMethodSetMap(structSpecs StructN
 	// Recursively add methods from embedded structs. Could probably remove this, though might be useful later. Todo MAKE OPTIONAL. Useful for getting whole set
 	for _, embeddedName := range spec.Embedded {
 		if _, exists := structMethods[embeddedName]; !exists {
-			structMethods.BuildMethodSetMap(structSpecs, interfaceMethods, embeddedName)
+			structMethods.BuildMethodSetMap(structSpecs, interfaceMethods, interfaceOrigins, structOrigins, embeddedName)
 		}
 		for methodName, method := range structMethods[embeddedName] {
 			structMethods[name][methodName] = method
+			structOrigins.addEmbedded(name, embeddedName, methodName)
 		}
 	}
 }
 
+// addEmbedded records that name gets method from the embedded type. The method keeps any origin it already has,
+// and is marked as provided by the first embedded type supplying it.
+func (origins NameToMethodOrigins) addEmbedded(name, embeddedName, method string) {
+	origin, ok := origins[name][method]
+	if !ok {
+		origin = MethodOrigin{Method: method, Via: prepend(embeddedName, origins[embeddedName][method].Via)}
+	}
+	if origin.ProvidedBy == "" {
+		origin.ProvidedBy = embeddedName
+	}
+	origins[name][method] = origin
+}
+
 // mergeMethodMaps merges multiple method maps into a deduplicated slice
 func mergeMethodMaps[T ~map[string]Method](sets ...T) []Method {
 	result := []Method{}
@@ -169,19 +198,21 @@ func GetMethods(structSpecs []StructSpec, interfaceSpecs []InterfaceSpec) (Metho
 	interfaceNameToMethods := InterfaceNameToMethodMap{}
 	// init map mapping struct names to their method sets
 	structNameToMethods := StructNameToMethodMap{}
+	// init maps recording where each method of each interface and struct comes from
+	interfaceOrigins, structOrigins := NameToMethodOrigins{}, NameToMethodOrigins{}
 
 	// create map mapping interface names to their full spec
 	interfaceNameToSpec := mapSpecsByName(interfaceSpecs)
 	// Hydrate interfaceNameToMethods so that each interface name is mapped against its full method set
 	for name := range interfaceNameToSpec {
-		interfaceNameToMethods.BuildMethodSetMap(interfaceNameToSpec, name)
+		interfaceNameToMethods.BuildMethodSetMap(interfaceNameToSpec, interfaceOrigins, name)
 	}
 
 	// create map mapping struct names to their full spec
 	structNameToSpec := mapSpecsByName(structSpecs)
 	// Hydrate structNameToMethods so that each struct name is mapped against its full method set
 	for name := range structNameToSpec {
-		structNameToMethods.BuildMethodSetMap(structNameToSpec, interfaceNameToMethods, name)
+		structNameToMethods.BuildMethodSetMap(structNameToSpec, interfaceNameToMethods, interfaceOrigins, structOrigins, name)
 	}
 
 	// Compute unique methods for each interface by taking union of methods across interface and any embedded interfaces, then removing methods already present due to embedded interfaces
@@ -189,6 +220,7 @@ func GetMethods(structSpecs []StructSpec, interfaceSpecs []InterfaceSpec) (Metho
 		FullSets:     map[string][]Method{},
 		UniqueSets:   map[string][]Method{},
 		EmbeddedSets: map[string][]Method{},
+		Origins:      interfaceOrigins,
 	}
 	for _, iface := range interfaceSpecs {
 		fullSet := interfaceNameToMethods[iface.Name]
@@ -205,6 +237,7 @@ func GetMethods(structSpecs []StructSpec, interfaceSpecs []InterfaceSpec) (Metho
 		FullSets:     map[string][]Method{},
 		UniqueSets:   map[string][]Method{},
 		EmbeddedSets: map[string][]Method{},
+		Origins:      structOrigins,
 	}
 
 	for _, s := range structSpecs {
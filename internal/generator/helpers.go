@@ -85,3 +85,75 @@ func prefixTypeIfCustom(typeName, pkg string, customTypes map[string]bool) strin
 	}
 	return typeName
 }
+
+// errorIndex returns the position of the last error output, or -1 if the method does not return an error
+func errorIndex(outputs []Param) int {
+	for i := len(outputs) - 1; i >= 0; i-- {
+		if strings.TrimSpace(outputs[i].Type) == "error" {
+			return i
+		}
+	}
+	return -1
+}
+
+// fieldName returns an exported struct field name for a param, falling back to prefix and position if it is unnamed
+func fieldName(p Param, prefix string, i int) string {
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		return fmt.Sprintf("%s%d", prefix, i)
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// resultFields returns the fields of a multi-output result struct, named after the outputs
+func resultFields(outputs []Param) []callField {
+	fields := make([]callField, len(outputs))
+	for i, p := range outputs {
+		fields[i] = callField{Name: fieldName(p, "Output", i), Type: p.Type, Index: i}
+	}
+	return fields
+}
+
+// checkResultFields fails if a multi-output method's result struct would have two fields, or a field and
+// one of its methods, with the same name. Field names come from the YAML, so they are never renamed.
+func checkResultFields(method Method) error {
+	if len(method.Outputs) < 2 {
+		return nil
+	}
+	used := map[string]string{"Values": "the Values method", "ReturnedErr": "the ReturnedErr method"}
+	for _, f := range resultFields(method.Outputs) {
+		if clash, ok := used[f.Name]; ok {
+			return fmt.Errorf("method %s: output field %s of its result struct clashes with %s, rename the output", method.Name, f.Name, clash)
+		}
+		used[f.Name] = fmt.Sprintf("output %d", f.Index)
+	}
+	return nil
+}
+
+// callField is a field of a typed call record
+type callField struct {
+	Name  string
+	Type  string
+	Index int
+}
+
+// callFields returns the fields of a typed call record, inputs first then outputs.
+// An output whose name is already used by an input gets a Result suffix.
+func callFields(inputs, outputs []Param) struct{ Inputs, Outputs []callField } {
+	var fields struct{ Inputs, Outputs []callField }
+	used := map[string]bool{}
+	for i, p := range inputs {
+		name := fieldName(p, "Input", i)
+		used[name] = true
+		fields.Inputs = append(fields.Inputs, callField{Name: name, Type: p.Type, Index: i})
+	}
+	for i, p := range outputs {
+		name := fieldName(p, "Output", i)
+		if used[name] {
+			name += "Result"
+		}
+		used[name] = true
+		fields.Outputs = append(fields.Outputs, callField{Name: name, Type: p.Type, Index: i})
+	}
+	return fields
+}
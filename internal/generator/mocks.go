@@ -23,18 +23,191 @@ func generateMockStruct() string {
 type {{ .MockName }} struct {
 	real   {{ .Package }}.{{ .Interface }}
 	mocked {{ .MockConfigName }}
-	responseChans map[string]any
+	events {{ lower .MockName }}Events
+	hooks  {{ lower .MockName }}Hooks
+}`
+}
+
+func generateEventsStruct() string {
+	return `// {{ lower .MockName }}Events fans out an event for every call to each method
+type {{ lower .MockName }}Events struct {
+{{- range .Methods }}
+	{{ .Name }} stubs.Broadcaster[{{ $.MockName }}{{ .Name }}Event]
+{{- end }}
+}`
+}
+
+func generateHooksStruct() string {
+	return `// {{ lower .MockName }}Hooks holds the before and after hooks of each method
+type {{ lower .MockName }}Hooks struct {
+{{- range .Methods }}
+	{{ .Name }} stubs.Hooks[{{ $.MockName }}{{ .Name }}Args, {{ if gt (len .Outputs) 1 }}{{ $.MockName }}{{ title .Name }}Result{{ else if eq (len .Outputs) 1 }}{{ (index .Outputs 0).Type }}{{ else }}struct{}{{ end }}]
+{{- end }}
 }`
 }
 
 func generateFactoryFunc() string {
-	return `// {{ .MockFactory }} returns a new mock
+	return `// {{ .MockFactory }} returns a new mock. Its usage is counted in the stubs coverage report when STUBS_COVERAGE is set.
 func {{ .MockFactory }}(v {{ .Package }}.{{ .Interface }}) *{{ .MockName }} {
-	return &{{ .MockName }}{
-		real:   v,
-		mocked: {{ .MockConfigName }}{},
-		responseChans: make(map[string]any),
+	m := &{{ .MockName }}{
+		real: v,
+	}
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.TrackCoverage("{{ $.Package }}.{{ $.Interface }}", "{{ .Name }}")
+{{- end }}
+	return m
+}
+
+// {{ .MockFactory }}WithClock returns a new mock whose delays, call timestamps and wait helpers use clock
+func {{ .MockFactory }}WithClock(v {{ .Package }}.{{ .Interface }}, clock stubs.Clock) *{{ .MockName }} {
+	m := {{ .MockFactory }}(v)
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.SetClock(clock)
+{{- end }}
+	return m
+}
+
+// {{ .MockFactory }}ForTest returns a new mock that is reset when t finishes, after checking with
+// stubs.VerifyNoLeaks that no call to it is left held at a gate or blocked on a subscription.
+// Calls without an error output report gate failures on t, and the mock stops counting towards the
+// coverage report when t finishes. When STUBS_RECORD is set, calls are recorded with every other mock
+// built for t and printed if t fails.
+func {{ .MockFactory }}ForTest(t stubs.TB, v {{ .Package }}.{{ .Interface }}) *{{ .MockName }} {
+	t.Helper()
+	m := {{ .MockFactory }}(v)
+	t.Cleanup(m.{{ helper "reset" }})
+	if stubs.RecordingEnabled() {
+		m.{{ helper "attachRecorder" }}(stubs.RecorderFor(t))
+	}
+	stubs.VerifyNoLeaks(t)
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.TrackLeaks(t)
+	m.mocked.{{ .Name }}.ReportTo(t)
+	m.events.{{ .Name }}.TrackLeaks(t)
+	t.Cleanup(m.mocked.{{ .Name }}.FinishCoverage)
+{{- end }}
+	return m
+}`
+}
+
+func generateAttachSequenceFunc() string {
+	return `// {{ helper "attachSequence" }} records every call on the mock into seq so call order can be asserted across mocks
+func (m *{{ .MockName }}) {{ helper "attachSequence" }}(seq *stubs.Sequence) {
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.AttachSequence(seq, "{{ $.Interface }}", "{{ .Name }}")
+{{- end }}
+}
+
+// {{ helper "attachRecorder" }} records every call on the mock into rec with its arguments, results, timing and goroutine
+func (m *{{ .MockName }}) {{ helper "attachRecorder" }}(rec *stubs.Recorder) {
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.AttachRecorder(rec, "{{ $.Interface }}", "{{ .Name }}")
+{{- end }}
+}`
+}
+
+func generateSnapshotFuncs() string {
+	return `// {{ .MockName }}Snapshot is a point-in-time copy of a {{ .MockName }}'s configuration, spy calls, subscriptions and hooks
+type {{ .MockName }}Snapshot struct {
+	methods struct {
+{{- range .Methods }}
+		{{ .Name }} stubs.MethodSnapshot[{{ responseSignature .Inputs .Outputs }}]
+{{- end }}
+	}
+	events struct {
+{{- range .Methods }}
+		{{ .Name }} uint64
+{{- end }}
+	}
+	hooks struct {
+{{- range .Methods }}
+		{{ .Name }} uint64
+{{- end }}
+	}
+}
+
+// {{ helper "reset" }} clears the configuration, spy calls, subscriptions and hooks of every method
+func (m *{{ .MockName }}) {{ helper "reset" }}() {
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.Reset()
+	m.events.{{ .Name }}.UnsubscribeAll()
+	m.hooks.{{ .Name }}.RemoveAll()
+{{- end }}
+}
+
+// {{ helper "snapshot" }} captures the configuration, spy calls, subscriptions and hooks of every method
+func (m *{{ .MockName }}) {{ helper "snapshot" }}() {{ .MockName }}Snapshot {
+	var snap {{ .MockName }}Snapshot
+{{- range .Methods }}
+	snap.methods.{{ .Name }} = m.mocked.{{ .Name }}.Snapshot()
+	snap.events.{{ .Name }} = m.events.{{ .Name }}.Mark()
+	snap.hooks.{{ .Name }} = m.hooks.{{ .Name }}.Mark()
+{{- end }}
+	return snap
+}
+
+// {{ helper "restore" }} returns every method to the state captured by snap and removes subscriptions and hooks added since
+func (m *{{ .MockName }}) {{ helper "restore" }}(snap {{ .MockName }}Snapshot) {
+{{- range .Methods }}
+	m.mocked.{{ .Name }}.Restore(snap.methods.{{ .Name }})
+	m.events.{{ .Name }}.UnsubscribeAfter(snap.events.{{ .Name }})
+	m.hooks.{{ .Name }}.RemoveAfter(snap.hooks.{{ .Name }})
+{{- end }}
+}
+
+// {{ helper "scope" }} rolls back configuration made during t when t and its subtests finish.
+// Only methods configured since scope was called are restored, so parallel subtests
+// that configure different methods of a shared mock do not undo each other.
+// Subscriptions and hooks added since scope was called are removed.
+func (m *{{ .MockName }}) {{ helper "scope" }}(t stubs.TB) {
+	snap := m.{{ helper "snapshot" }}()
+	t.Cleanup(func() {
+{{- range .Methods }}
+		if m.mocked.{{ .Name }}.ModifiedSince(snap.methods.{{ .Name }}) {
+			m.mocked.{{ .Name }}.Restore(snap.methods.{{ .Name }})
+		}
+		m.events.{{ .Name }}.UnsubscribeAfter(snap.events.{{ .Name }})
+		m.hooks.{{ .Name }}.RemoveAfter(snap.hooks.{{ .Name }})
+{{- end }}
+	})
+}`
+}
+
+func generateScenarioFunc() string {
+	return `// {{ helper "load" .Interface "Scenario" }} configures the mock from a YAML or JSON scenario file, see stubs.Scenario
+func (m *{{ .MockName }}) {{ helper "load" .Interface "Scenario" }}(path string) error {
+	sc, err := stubs.LoadScenario(path)
+	if err != nil {
+		return err
 	}
+	return sc.Apply(map[string]func(stubs.ScenarioMethod) error{
+	{{- range .Methods }}
+		"{{ .Name }}": func(sm stubs.ScenarioMethod) error {
+			return m.mocked.{{ .Name }}.ApplyScenario("{{ .Name }}", sm,
+				[]string{ {{- range $i, $p := .Inputs }}{{ if $i }}, {{ end }}"{{ $p.Name }}"{{ end -}} },
+				[]string{ {{- range $i, $p := .Outputs }}{{ if $i }}, {{ end }}"{{ $p.Name }}"{{ end -}} })
+		},
+	{{- end }}
+	})
+}`
+}
+
+func generateGoldenFuncs() string {
+	return `// {{ helper "use" .Interface "Golden" }} records every method to g or replays them from it, see stubs.Golden
+func (m *{{ .MockName }}) {{ helper "use" .Interface "Golden" }}(g *stubs.Golden) {
+	{{- range .Methods }}
+	m.mocked.{{ .Name }}.UseGolden(g, "{{ $.Interface }}.{{ .Name }}",
+		[]string{ {{- range $i, $p := .Inputs }}{{ if $i }}, {{ end }}"{{ $p.Name }}"{{ end -}} },
+		[]string{ {{- range $i, $p := .Outputs }}{{ if $i }}, {{ end }}"{{ $p.Name }}"{{ end -}} })
+	{{- end }}
+}
+
+// {{ helper "use" .Interface "GoldenFile" }} records to or replays from path in the mode given by stubs.GoldenModeFromFlags
+func (m *{{ .MockName }}) {{ helper "use" .Interface "GoldenFile" }}(t stubs.TB, path string) *stubs.Golden {
+	t.Helper()
+	g := stubs.NewGolden(t, path, stubs.GoldenModeFromFlags())
+	m.{{ helper "use" .Interface "Golden" }}(g)
+	return g
 }`
 }
 
@@ -45,145 +218,371 @@ const methodDividerTemplate = `
 const methodOverrideTemplate = `
 // {{ .Name }} overrides the method to return the mock response
 func (m *{{ .MockName }}) {{ .Name }}({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }} {{ $p.Type }}{{ end }}){{ if gt (len .Outputs) 0 }} ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}){{ end }} {
-	m.mocked.{{ title .Name }}.RecordCall({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
+	callID := m.mocked.{{ title .Name }}.RecordCall({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
+	defer m.mocked.{{ title .Name }}.EndCall(callID)
+	callArgs := {{ .MockName }}{{ .Name }}Args{ {{- range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ fieldName $p "Input" $i }}: {{ $p.Name }}{{ end -}} }
+	m.hooks.{{ .Name }}.RunBefore(callArgs)
+	{{- if gt (len .Outputs) 0 }}
 	var (
-		{{ range $i, $o := .Outputs }}out{{ $i }} {{ $o.Type }}
-		{{ end }}
-		{{ if gt (len .Outputs) 1 }}result {{ .MockName }}{{ title .Name }}Result{{ end }}
+	{{- range $i, $o := .Outputs }}
+		out{{ $i }} {{ $o.Type }}
+	{{- end }}
 	)
+	{{- end }}
+
+	{{ $errIdx := errorIndex .Outputs -}}
+	{{ if ge $errIdx 0 -}}
+	if gateErr := m.mocked.{{ title .Name }}.PassGate(); gateErr != nil {
+		out{{ $errIdx }} = gateErr
+	} else if faultErr := m.mocked.{{ title .Name }}.ApplyFault(); faultErr != nil {
+		out{{ $errIdx }} = faultErr
+	} else if m.mocked.{{ title .Name }}.IsEnabled() {
+	{{- else -}}
+	gatePassed := m.mocked.{{ title .Name }}.PassGateOrReport()
+	if gatePassed {
+		m.mocked.{{ title .Name }}.ApplyFault()
+	}
 
-	if m.mocked.{{ title .Name }}.Enabled {
-		{{ if gt (len .Outputs) 0 }}
-		{{- range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}out{{ $i }}{{ end }} = m.mocked.{{ .Name }}.NextResponse(func({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }} {{ $p.Type }}{{ end }}) ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}) {
-			return m.real.{{ .Name }}({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
+	if !gatePassed {
+		// the gate reported its failure on the test, so the call returns its zero values
+	} else if m.mocked.{{ title .Name }}.IsEnabled() {
+	{{- end }}
+		{{ range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}out{{ $i }}{{ end }}{{ if gt (len .Outputs) 0 }} = {{ end }}m.mocked.{{ .Name }}.NextResponse(func({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }} {{ $p.Type }}{{ end }}){{ if gt (len .Outputs) 0 }} ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}){{ end }} {
+			{{ if gt (len .Outputs) 0 }}return {{ end }}m.real.{{ .Name }}({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
 		})({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
-		{{ end }}
 	} else {
-		{{ if gt (len .Outputs) 0 }}
-		{{- range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}out{{ $i }}{{ end }} = m.real.{{ .Name }}({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
-		{{ end }}
+		{{ range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}out{{ $i }}{{ end }}{{ if gt (len .Outputs) 0 }} = {{ end }}m.real.{{ .Name }}({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }})
 	}
 
-	{{ if gt (len .Outputs) 1 }}
-	result = {{ .MockName }}{{ title .Name }}Result{
-		{{ range $i, $_ := .Outputs }}Output{{ $i }}: out{{ $i }}, {{ end }}
-	}
-	if ch, ok := m.responseChans["{{ .Name }}"]; ok {
-		chTyped := ch.(chan {{ .MockName }}{{ title .Name }}Result)
-		chTyped <- result
-	}
-	return {{ range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}result.Output{{ $i }}{{ end }}
-	{{ else if eq (len .Outputs) 1 }}
-	if ch, ok := m.responseChans["{{ .Name }}"]; ok {
-		chTyped := ch.(chan {{ (index .Outputs 0).Type }})
-		chTyped <- out0
-	}
-	return out0
-	{{ end }}
-	{{ if eq (len .Outputs) 0 }}
-	return
-	{{ end }}
+	m.mocked.{{ title .Name }}.RecordResults(callID{{ range $i, $_ := .Outputs }}, out{{ $i }}{{ end }})
+	{{- if gt (len .Outputs) 1 }}
+	callResult := {{ .MockName }}{{ title .Name }}Result{ {{- range $i, $f := resultFields .Outputs }}{{ if $i }}, {{ end }}{{ $f.Name }}: out{{ $f.Index }}{{ end -}} }
+	m.hooks.{{ .Name }}.RunAfter(callArgs, callResult)
+	{{- else if eq (len .Outputs) 1 }}
+	m.hooks.{{ .Name }}.RunAfter(callArgs, out0)
+	{{- else }}
+	m.hooks.{{ .Name }}.RunAfter(callArgs, struct{}{})
+	{{- end }}
+	m.events.{{ .Name }}.Publish({{ .MockName }}{{ .Name }}Event{
+		Args: callArgs,
+		{{- if gt (len .Outputs) 1 }}
+		Result: callResult,
+		{{- else if eq (len .Outputs) 1 }}
+		Result: out0,
+		{{- end }}
+	})
+	{{- if gt (len .Outputs) 0 }}
+	return {{ range $i, $_ := .Outputs }}{{ if $i }}, {{ end }}out{{ $i }}{{ end }}
+	{{- end }}
 }
 `
 
+const hooksTemplate = `
+// {{ helper "on" .Name "Before" }} runs fn on every call to {{ .Name }} before its response is chosen, whether it comes from
+// the real implementation, the queue or the fallback. Args are passed as given, so fn can fill pointer and slice
+// arguments. The returned func removes the hook.
+func (m *{{ .MockName }}) {{ helper "on" .Name "Before" }}(fn func(args {{ .MockName }}{{ .Name }}Args)) func() {
+	return m.hooks.{{ .Name }}.Before(fn)
+}
+
+// {{ helper "on" .Name "After" }} runs fn once each call to {{ .Name }} has its result. The returned func removes the hook.
+func (m *{{ .MockName }}) {{ helper "on" .Name "After" }}(fn func(args {{ .MockName }}{{ .Name }}Args{{ if .Outputs }}, result {{ if gt (len .Outputs) 1 }}{{ .MockName }}{{ title .Name }}Result{{ else if eq (len .Outputs) 1 }}{{ (index .Outputs 0).Type }}{{ end }}{{ end }})) func() {
+	{{- if .Outputs }}
+	return m.hooks.{{ .Name }}.After(fn)
+	{{- else }}
+	return m.hooks.{{ .Name }}.After(func(args {{ .MockName }}{{ .Name }}Args, _ struct{}) { fn(args) })
+	{{- end }}
+}
+
+// {{ helper "notify" .Name "Called" }} returns a channel that is closed when {{ .Name }} is next called, before it responds
+func (m *{{ .MockName }}) {{ helper "notify" .Name "Called" }}() <-chan struct{} {
+	return m.hooks.{{ .Name }}.Notify()
+}`
+
+const blockTemplate = `
+// {{ helper "block" .Name "" }} holds every later call to {{ .Name }} mid-call, after its before hooks, until the returned
+// gate lets it through. Resetting the mock releases held calls.
+func (m *{{ .MockName }}) {{ helper "block" .Name "" }}() *stubs.Gate {
+	g := stubs.NewGate()
+	m.mocked.{{ .Name }}.SetGate(g)
+	return g
+}
+
+// {{ helper "unblock" .Name "" }} removes the gate set by {{ helper "block" .Name "" }}, releasing any held calls
+func (m *{{ .MockName }}) {{ helper "unblock" .Name "" }}() {
+	m.mocked.{{ .Name }}.ClearGate()
+}`
+
+const concurrencyTemplate = `
+// {{ helper "assert" .Name "MaxConcurrency" }} fails the test if more than n calls to {{ .Name }} were ever in flight at once
+func (m *{{ .MockName }}) {{ helper "assert" .Name "MaxConcurrency" }}(t stubs.TB, n int) {
+	t.Helper()
+	stubs.AssertMaxConcurrency(t, &m.mocked.{{ .Name }}, n)
+}
+
+// {{ helper "assert" .Name "NeverConcurrent" }} fails the test if any two calls to {{ .Name }} were in flight at the same time
+func (m *{{ .MockName }}) {{ helper "assert" .Name "NeverConcurrent" }}(t stubs.TB) {
+	t.Helper()
+	stubs.AssertNeverConcurrent(t, &m.mocked.{{ .Name }})
+}
+
+// {{ helper "get" .Name "ConcurrencyStats" }} returns the in-flight counts and overlapping calls to {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "get" .Name "ConcurrencyStats" }}() stubs.ConcurrencyStats {
+	return m.mocked.{{ .Name }}.ConcurrencyStats()
+}`
+
 const setFuncTemplate = `
-// set{{ title .Name }}Func sets the function for {{ .Name }}
-func (m *{{ .MockName }}) set{{ title .Name }}Func(f {{ responseSignature .Inputs .Outputs }}) {
-	m.mocked.{{ .Name }}.Fallback = f
+// {{ helper "set" .Name "Func" }} sets the function for {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "set" .Name "Func" }}(f {{ responseSignature .Inputs .Outputs }}) {
+	m.mocked.{{ .Name }}.SetResponseFunc(f)
 }`
 
 const setResponseTemplate = `
-// set{{ title .Name }}Response sets the response for {{ .Name }}
-func (m *{{ .MockName }}) set{{ title .Name }}Response({{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }} {{ $p.Type }}{{ end }}) {
-	m.set{{ title .Name }}Func(func({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Type }}{{ end }}) ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}) {
+// {{ helper "set" .Name "Response" }} sets the response for {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "set" .Name "Response" }}({{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }} {{ $p.Type }}{{ end }}) {
+	m.{{ helper "set" .Name "Func" }}(func({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Type }}{{ end }}) ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}) {
 		return {{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }}{{ end }}
 	})
 }`
 
 const enableTemplate = `
-// enable{{ title .Name }}Mock turns the mock on
-func (m *{{ .MockName }}) enable{{ title .Name }}Mock() {
-	m.mocked.{{ title .Name }}.Enabled = true
+// {{ helper "enable" .Name "Mock" }} turns the mock on
+func (m *{{ .MockName }}) {{ helper "enable" .Name "Mock" }}() {
+	m.mocked.{{ title .Name }}.Enable()
 }`
 
 const enableSpyTemplate = `
-// enable{{ title .Name }}Spy turns the spy on
-func (m *{{ .MockName }}) enable{{ title .Name }}Spy() {
-	m.mocked.{{ title .Name }}.SpyEnabled = true
+// {{ helper "enable" .Name "Spy" }} turns the spy on
+func (m *{{ .MockName }}) {{ helper "enable" .Name "Spy" }}() {
+	m.mocked.{{ title .Name }}.EnableSpy()
 }`
 
 const getSpiedCallsTemplate = `
-// get{{ title .Name }}Calls returns recorded calls to {{ .Name }}
-func (m *{{ .MockName }}) get{{ title .Name }}Calls() []stubs.MethodCall {
+// {{ helper "get" .Name "Calls" }} returns recorded calls to {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "get" .Name "Calls" }}() []stubs.MethodCall {
 	return m.mocked.{{ title .Name }}.Calls()
 }
 `
 
 const disableSpyTemplate = `
-// enable{{ title .Name }}Spy turns the spy off
-func (m *{{ .MockName }}) disable{{ title .Name }}Spy() {
-	m.mocked.{{ title .Name }}.SpyEnabled = false
+// {{ helper "enable" .Name "Spy" }} turns the spy off
+func (m *{{ .MockName }}) {{ helper "disable" .Name "Spy" }}() {
+	m.mocked.{{ title .Name }}.DisableSpy()
 }`
 
 const disableTemplate = `
-// disable{{ title .Name }}Mock turns the mock off
-func (m *{{ .MockName }}) disable{{ title .Name }}Mock() {
-	m.mocked.{{ title .Name }}.Enabled = false
+// {{ helper "disable" .Name "Mock" }} turns the mock off
+func (m *{{ .MockName }}) {{ helper "disable" .Name "Mock" }}() {
+	m.mocked.{{ title .Name }}.Disable()
 }`
 
 const enqueueFuncTemplate = `
-// enqueue{{ title .Name }}ResponseFunc enqueues a function response for {{ .Name }}
-func (m *{{ .MockName }}) enqueue{{ title .Name }}ResponseFunc(f {{ responseSignature .Inputs .Outputs }}) {
+// {{ helper "enqueue" .Name "ResponseFunc" }} enqueues a function response for {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "enqueue" .Name "ResponseFunc" }}(f {{ responseSignature .Inputs .Outputs }}) {
 	m.mocked.{{ .Name }}.EnqueueWithDelay(f, 0)
 }`
 
 const enqueueFuncWithDelayTemplate = `
-// enqueue{{ title .Name }}ResponseFuncWithDelay enqueues a function response with delay for {{ .Name }}
-func (m *{{ .MockName }}) enqueue{{ title .Name }}ResponseFuncWithDelay(f {{ responseSignature .Inputs .Outputs }}, d time.Duration) {
+// {{ helper "enqueue" .Name "ResponseFuncWithDelay" }} enqueues a function response with delay for {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "enqueue" .Name "ResponseFuncWithDelay" }}(f {{ responseSignature .Inputs .Outputs }}, d time.Duration) {
 	m.mocked.{{ .Name }}.EnqueueWithDelay(f, d)
 }`
 
 const enqueueStaticTemplate = `
-// enqueue{{ title .Name }}Response enqueues a static response for {{ .Name }}
-func (m *{{ .MockName }}) enqueue{{ title .Name }}Response({{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }} {{ $p.Type }}{{ end }}) {
+// {{ helper "enqueue" .Name "Response" }} enqueues a static response for {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "enqueue" .Name "Response" }}({{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }} {{ $p.Type }}{{ end }}) {
 	m.mocked.{{ .Name }}.EnqueueWithDelay(func({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Type }}{{ end }}) ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}) {
 		return {{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }}{{ end }}
 	}, 0)
 }`
 
 const enqueueStaticWithDelayTemplate = `
-// enqueue{{ title .Name }}ResponseWithDelay enqueues a static response with delay for {{ .Name }}
-func (m *{{ .MockName }}) enqueue{{ title .Name }}ResponseWithDelay({{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }} {{ $p.Type }}{{ end }}, d time.Duration) {
+// {{ helper "enqueue" .Name "ResponseWithDelay" }} enqueues a static response with delay for {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "enqueue" .Name "ResponseWithDelay" }}({{ range $i, $p := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }} {{ $p.Type }}{{ end }}, d time.Duration) {
 	m.mocked.{{ .Name }}.EnqueueWithDelay(func({{ range $i, $p := .Inputs }}{{ if $i }}, {{ end }}{{ $p.Type }}{{ end }}) ({{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}{{ $o.Type }}{{ end }}) {
-		time.Sleep(d)
 		return {{ range $i, $o := .Outputs }}{{ if $i }}, {{ end }}output{{ $i }}{{ end }}
 	}, d)
 }`
 
+const subscribeTemplate = `
+// {{ helper "subscribe" .Name "" }} returns a subscription that receives an event for every call to {{ .Name }}. Call Unsubscribe when done.
+func (m *{{ .MockName }}) {{ helper "subscribe" .Name "" }}(opts stubs.SubscribeOptions) *stubs.Subscription[{{ .MockName }}{{ .Name }}Event] {
+	return m.events.{{ .Name }}.Subscribe(opts)
+}`
+
 const captureResultTemplate = `
-// capture{{ title .Name }}Result sets up a channel to capture {{ .Name }} results.
-func (m *{{ .MockName }}) capture{{ title .Name }}Result() <-chan {{ if gt (len .Outputs) 1 }}{{ .MockName }}{{ title .Name }}Result{{ else if eq (len .Outputs) 1 }}{{ (index .Outputs 0).Type }}{{ else }}struct{}{{ end }} {
-	ch := make(chan {{ if gt (len .Outputs) 1 }}{{ .MockName }}{{ title .Name }}Result{{ else if eq (len .Outputs) 1 }}{{ (index .Outputs 0).Type }}{{ else }}struct{}{{ end }}, 1)
-	m.responseChans["{{ .Name }}"] = ch
-	return ch
+// {{ helper "capture" .Name "Result" }} returns a channel that receives the result of every call to {{ .Name }}.
+// Results are queued without limit, so calls never block on an unread channel. The capture stops and
+// the channel is closed when t finishes.
+func (m *{{ .MockName }}) {{ helper "capture" .Name "Result" }}(t stubs.TB) <-chan {{ if gt (len .Outputs) 1 }}{{ .MockName }}{{ title .Name }}Result{{ else if eq (len .Outputs) 1 }}{{ (index .Outputs 0).Type }}{{ else }}struct{}{{ end }} {
+	sub := stubs.SubscribeMapped(&m.events.{{ .Name }}, stubs.SubscribeOptions{Overflow: stubs.OverflowUnbounded}, func(e {{ .MockName }}{{ .Name }}Event) {{ if gt (len .Outputs) 1 }}{{ .MockName }}{{ title .Name }}Result{{ else if eq (len .Outputs) 1 }}{{ (index .Outputs 0).Type }}{{ else }}struct{}{{ end }} {
+		return {{ if gt (len .Outputs) 0 }}e.Result{{ else }}struct{}{}{{ end }}
+	})
+	t.Cleanup(sub.Unsubscribe)
+	return sub.C
 }`
 const captureSpyCallTemplate = `
-// capture{{ title .Name }}CallSpy starts watching for {{ .Name }} spy calls and sends them into a channel.
-func (m *{{ .MockName }}) capture{{ title .Name }}CallSpy(t *testing.T, timeout time.Duration) <-chan []stubs.MethodCall {
-	ch := make(chan []stubs.MethodCall, 1)
-	go func() {
-		stubs.WaitForSpyCall(t, m.get{{ title .Name }}Calls, timeout)
-		ch <- m.get{{ title .Name }}Calls()
-	}()
-	return ch
+// {{ helper "capture" .Name "CallSpy" }} starts watching for {{ .Name }} spy calls and sends them into a channel.
+// If no call arrives within timeout the channel is closed and the test fails when it finishes.
+// The watch stops when t finishes.
+func (m *{{ .MockName }}) {{ helper "capture" .Name "CallSpy" }}(t stubs.TB, timeout time.Duration) <-chan []stubs.MethodCall {
+	return stubs.CaptureCalls(t, &m.mocked.{{ .Name }}, "{{ .Name }} spy call", timeout)
 }`
 
+const waitForCallsTemplate = `
+// {{ helper "waitFor" .Name "Calls" }} waits until at least n {{ .Name }} spy calls are recorded and returns them
+func (m *{{ .MockName }}) {{ helper "waitFor" .Name "Calls" }}(t stubs.TB, n int, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForNCalls(t, &m.mocked.{{ .Name }}, n, timeout)
+}
+
+// {{ helper "waitFor" .Name "CallWithin" }} waits until a {{ .Name }} spy call matching match is recorded and returns it
+func (m *{{ .MockName }}) {{ helper "waitFor" .Name "CallWithin" }}(t stubs.TB, match func(stubs.MethodCall) bool, timeout time.Duration) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithin(t, &m.mocked.{{ .Name }}, match, timeout)
+}
+
+// {{ helper "eventually" .Name "Calls" }} waits until cond holds for the recorded {{ .Name }} spy calls
+func (m *{{ .MockName }}) {{ helper "eventually" .Name "Calls" }}(t stubs.TB, cond func([]stubs.MethodCall) bool, timeout time.Duration) []stubs.MethodCall {
+	t.Helper()
+	return stubs.Eventually(t, &m.mocked.{{ .Name }}, cond, timeout)
+}
+
+{{- if .Inputs }}
+
+// {{ helper "waitFor" .Name "CallWithArgs" }} waits until {{ .Name }} is called with the given args and returns the call.
+// On timeout it fails with a diff against every recorded call.
+func (m *{{ .MockName }}) {{ helper "waitFor" .Name "CallWithArgs" }}(t stubs.TB, timeout time.Duration{{ range $i, $p := .Inputs }}, want{{ fieldName $p "Input" $i }} {{ $p.Type }}{{ end }}) stubs.MethodCall {
+	t.Helper()
+	return stubs.WaitForCallWithArgs(t, &m.mocked.{{ .Name }}, timeout{{ range $i, $p := .Inputs }}, want{{ fieldName $p "Input" $i }}{{ end }})
+}
+{{- end }}
+
+// {{ helper "consistently" .Name "NotCalled" }} fails the test if {{ .Name }} is called during window, or if its spy is not enabled
+func (m *{{ .MockName }}) {{ helper "consistently" .Name "NotCalled" }}(t stubs.TB, window time.Duration) {
+	t.Helper()
+	stubs.Consistently(t, &m.mocked.{{ .Name }}, window)
+}`
+
+const argCloningTemplate = `
+// {{ helper "disable" .Name "ArgCloning" }} records arguments and results of {{ .Name }} by reference instead of deep copying them
+func (m *{{ .MockName }}) {{ helper "disable" .Name "ArgCloning" }}() {
+	m.mocked.{{ title .Name }}.DisableArgCloning()
+}
+
+// {{ helper "enable" .Name "ArgCloning" }} deep copies arguments and results of {{ .Name }} as they are recorded
+func (m *{{ .MockName }}) {{ helper "enable" .Name "ArgCloning" }}() {
+	m.mocked.{{ title .Name }}.EnableArgCloning()
+}`
+
+const setPanicTemplate = `
+// {{ helper "set" .Name "Panic" }} makes every mocked call to {{ .Name }} panic with v
+func (m *{{ .MockName }}) {{ helper "set" .Name "Panic" }}(v any) {
+	m.mocked.{{ .Name }}.SetPanicResponse(v)
+}`
+
+const enqueuePanicTemplate = `
+// {{ helper "enqueue" .Name "Panic" }} enqueues a response for {{ .Name }} that panics with v
+func (m *{{ .MockName }}) {{ helper "enqueue" .Name "Panic" }}(v any) {
+	m.mocked.{{ .Name }}.EnqueuePanic(v)
+}`
+
+const injectFaultsTemplate = `
+{{- $errIdx := errorIndex .Outputs }}
+{{- if ge $errIdx 0 }}
+// {{ helper "inject" .Name "Faults" }} applies a seeded fault policy to {{ .Name }}. Injected errors are returned in output {{ $errIdx }}.
+func (m *{{ .MockName }}) {{ helper "inject" .Name "Faults" }}(policy stubs.FaultPolicy) {
+	m.mocked.{{ .Name }}.SetFaults(policy)
+}
+{{- else }}
+// {{ helper "inject" .Name "Faults" }} applies a seeded fault policy to {{ .Name }}. {{ .Name }} has no error output, so only latency and panics can be injected.
+func (m *{{ .MockName }}) {{ helper "inject" .Name "Faults" }}(policy stubs.FaultPolicy) {
+	if policy.ErrorRate > 0 {
+		panic("{{ helper "inject" .Name "Faults" }}: {{ .Name }} has no error output to inject errors into")
+	}
+	m.mocked.{{ .Name }}.SetFaults(policy)
+}
+{{- end }}
+
+// {{ helper "clear" .Name "Faults" }} removes the fault policy from {{ .Name }}
+func (m *{{ .MockName }}) {{ helper "clear" .Name "Faults" }}() {
+	m.mocked.{{ .Name }}.ClearFaults()
+}`
+
+const eventStructTemplate = `
+// {{ .MockName }}{{ .Name }}Args holds the arguments of a call to {{ .Name }}
+type {{ .MockName }}{{ .Name }}Args struct {
+{{- range $i, $p := .Inputs }}
+	{{ fieldName $p "Input" $i }} {{ $p.Type }}
+{{- end }}
+}
+
+// {{ .MockName }}{{ .Name }}Event is published to subscribers on every call to {{ .Name }}
+type {{ .MockName }}{{ .Name }}Event struct {
+	Args {{ .MockName }}{{ .Name }}Args
+{{- if gt (len .Outputs) 1 }}
+	Result {{ .MockName }}{{ title .Name }}Result
+{{- else if eq (len .Outputs) 1 }}
+	Result {{ (index .Outputs 0).Type }}
+{{- end }}
+}`
+
+const typedCallsTemplate = `
+{{- $fields := callFields .Inputs .Outputs }}
+// {{ .MockName }}{{ .Name }}Call is a typed record of a call to {{ .Name }}. Outputs are zero until the call returns.
+type {{ .MockName }}{{ .Name }}Call struct {
+{{- range $fields.Inputs }}
+	{{ .Name }} {{ .Type }}
+{{- end }}
+{{- range $fields.Outputs }}
+	{{ .Name }} {{ .Type }}
+{{- end }}
+}
+
+// {{ helper "get" .Name "TypedCalls" }} returns the spied calls to {{ .Name }} with typed arguments and results
+func (m *{{ .MockName }}) {{ helper "get" .Name "TypedCalls" }}() []{{ .MockName }}{{ .Name }}Call {
+	calls := m.mocked.{{ title .Name }}.Calls()
+	typed := make([]{{ .MockName }}{{ .Name }}Call, len(calls))
+	{{- if or $fields.Inputs $fields.Outputs }}
+	for i, call := range calls {
+		{{- range $fields.Inputs }}
+		typed[i].{{ .Name }}, _ = call.Args[{{ .Index }}].({{ .Type }})
+		{{- end }}
+		{{- if $fields.Outputs }}
+		if call.Returned {
+			{{- range $fields.Outputs }}
+			typed[i].{{ .Name }}, _ = call.Results[{{ .Index }}].({{ .Type }})
+			{{- end }}
+		}
+		{{- end }}
+	}
+	{{- end }}
+	return typed
+}
+`
+
 const tupleStructTemplate = `
+{{- if gt (len .Outputs) 1 }}
+// {{ .MockName }}{{ title .Name }}Result holds the outputs of a call to {{ .Name }}
 type {{ .MockName }}{{ title .Name }}Result struct {
-{{ range $i, $o := .Outputs }}
-	Output{{ $i }} {{ $o.Type }}
+{{- range resultFields .Outputs }}
+	{{ .Name }} {{ .Type }}
 {{- end }}
-}`
+}
+
+// Values returns the outputs in the order {{ .Name }} returns them
+func (r {{ .MockName }}{{ title .Name }}Result) Values() ({{ range $i, $f := resultFields .Outputs }}{{ if $i }}, {{ end }}{{ $f.Type }}{{ end }}) {
+	return {{ range $i, $f := resultFields .Outputs }}{{ if $i }}, {{ end }}r.{{ $f.Name }}{{ end }}
+}
+{{- $errIdx := errorIndex .Outputs }}
+{{- if ge $errIdx 0 }}
+
+// ReturnedErr returns the error output
+func (r {{ .MockName }}{{ title .Name }}Result) ReturnedErr() error {
+	return r.{{ (index (resultFields .Outputs) $errIdx).Name }}
+}
+{{- end }}
+{{- end }}`
 
 func writeTemplate(w io.Writer, tmplStr string, data any, funcs template.FuncMap) error {
 	tmpl, err := template.New("").Funcs(funcs).Parse(tmplStr)
@@ -193,19 +592,103 @@ func writeTemplate(w io.Writer, tmplStr string, data any, funcs template.FuncMap
 	return tmpl.Execute(w, data)
 }
 
+func (m MockSpec) exported() bool {
+	return m.Visibility == "exported"
+}
+
+func (m MockSpec) validate(common CommonSpec) error {
+	if m.Visibility != "" && m.Visibility != "exported" && m.Visibility != "unexported" {
+		return fmt.Errorf("mocks.visibility must be \"exported\" or \"unexported\", got %q", m.Visibility)
+	}
+	if m.Package != "" && m.Package == common.Package {
+		return fmt.Errorf("mocks.package must differ from package %q", common.Package)
+	}
+	if m.ExternalTestPackage && !strings.HasSuffix(m.fileSuffix(), "_test.go") {
+		return fmt.Errorf("mocks.file_suffix must end in _test.go when external_test_package is set, got %q", m.fileSuffix())
+	}
+	return nil
+}
+
+func (m MockSpec) fileSuffix() string {
+	if m.FileSuffix != "" {
+		return m.FileSuffix
+	}
+	if m.exported() {
+		return "_mock.go"
+	}
+	return "_mock_test.go"
+}
+
+// dir returns the directory mocks are written to
+func (m MockSpec) dir() string {
+	if m.Package == "" {
+		return "generated/importer"
+	}
+	return fmt.Sprintf("generated/%s", m.Package)
+}
+
+// packageName returns the package clause of the mock files
+func (m MockSpec) packageName(importer string) string {
+	name := m.Package
+	if name == "" {
+		name = importer
+	}
+	if m.ExternalTestPackage {
+		name += "_test"
+	}
+	return name
+}
+
+// identifier joins parts into an identifier, exported or unexported to match the mock visibility
+func (m MockSpec) identifier(parts ...string) string {
+	name := strings.Join(parts, "")
+	if name == "" {
+		return name
+	}
+	if m.exported() {
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
 func GenerateMock(spec InterfaceSpec, structSpec StructSpec, common CommonSpec) error {
-	if err := os.MkdirAll("generated/importer", os.ModePerm); err != nil {
+	mocks := common.Mocks
+	if err := mocks.validate(common); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(mocks.dir(), os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create 'generated' directory: %w", err)
 	}
 
-	filePath := fmt.Sprintf("generated/importer/%s_mock_test.go", strings.ToLower(spec.Name))
+	backend, err := mockBackendFor(spec, mocks)
+	if err != nil {
+		return err
+	}
+
+	filePath := fmt.Sprintf("%s/%s%s", mocks.dir(), strings.ToLower(spec.Name), mocks.fileSuffix())
 	file, err := os.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
 	defer file.Close()
 
-	funcs := template.FuncMap{
+	return backend.Generate(file, spec, structSpec, common)
+}
+
+// mockNames returns the mock type and factory names for an interface
+func (m MockSpec) mockNames(iface string) (mockName, mockFactory string) {
+	if m.exported() {
+		mockName = strings.ToUpper(iface[:1]) + iface[1:]
+		return mockName, fmt.Sprintf("New%s", mockName)
+	}
+	return fmt.Sprintf("mock%s", iface), fmt.Sprintf("new%sMock", strings.ToUpper(iface[:1])+iface[1:])
+}
+
+// mockFuncs returns the template functions shared by every mock backend
+func mockFuncs(mocks MockSpec) template.FuncMap {
+	return template.FuncMap{
+		"helper": mocks.identifier,
 		"title": func(s string) string {
 			return strings.Title(s)
 		},
@@ -237,37 +720,40 @@ func GenerateMock(spec InterfaceSpec, structSpec StructSpec, common CommonSpec)
 			}
 			return b.String()
 		},
-		// come back here
-		"outputVars": func(inputs, outputs []Param) string {
-			var b strings.Builder
-			b.WriteString("func(")
-			for i, in := range inputs {
-				if i > 0 {
-					b.WriteString(", ")
-				}
-				b.WriteString(in.Type)
-			}
-			b.WriteString(")")
-			if len(outputs) > 0 {
-				b.WriteString(" (")
-				for i, out := range outputs {
-					if i > 0 {
-						b.WriteString(", ")
-					}
-					b.WriteString(out.Type)
-				}
-				b.WriteString(")")
-			}
-			return b.String()
-		},
+		"errorIndex":   errorIndex,
+		"fieldName":    fieldName,
+		"callFields":   callFields,
+		"resultFields": resultFields,
+	}
+}
+
+// nativeBackend emits mocks built on the stubs package
+type nativeBackend struct{}
+
+func (nativeBackend) Name() string {
+	return "native"
+}
+
+func (nativeBackend) Generate(w io.Writer, spec InterfaceSpec, structSpec StructSpec, common CommonSpec) error {
+	for _, method := range spec.Methods {
+		if err := checkResultFields(method); err != nil {
+			return fmt.Errorf("interface %s: %w", spec.Name, err)
+		}
 	}
+	mocks := common.Mocks
+	mockName, mockFactory := mocks.mockNames(spec.Name)
+	funcs := mockFuncs(mocks)
 
-	headerTemplate := `package {{ .Importer }}
+	headerTemplate := `package {{ .MockPackage }}
 
-import "github.com/jackclarke/GoStubGen/generated/{{ .Package }}"
-import "github.com/jackclarke/GoStubGen/stubs"
+import (
+	"time"
+
+	"github.com/jackclarke/GoStubGen/generated/{{ .Package }}"
+	"github.com/jackclarke/GoStubGen/stubs"
+)
 
-` + generateMethodConfig() + "\n\n" + generateMockStruct() + "\n\n" + generateFactoryFunc() + "\n"
+` + generateMethodConfig() + "\n\n" + generateMockStruct() + "\n\n" + generateEventsStruct() + "\n\n" + generateHooksStruct() + "\n\n" + generateFactoryFunc() + "\n\n" + generateAttachSequenceFunc() + "\n\n" + generateSnapshotFuncs() + "\n\n" + generateScenarioFunc() + "\n\n" + generateGoldenFuncs() + "\n"
 
 	// Write the header section
 	tmpl, err := template.New("header").Funcs(funcs).Parse(headerTemplate)
@@ -275,7 +761,7 @@ import "github.com/jackclarke/GoStubGen/stubs"
 		return fmt.Errorf("failed to parse header template: %w", err)
 	}
 
-	err = tmpl.Execute(file, struct {
+	err = tmpl.Execute(w, struct {
 		Interface      string
 		Concrete       string
 		MockName       string
@@ -284,15 +770,17 @@ import "github.com/jackclarke/GoStubGen/stubs"
 		Methods        []Method
 		Package        string
 		Importer       string
+		MockPackage    string
 	}{
 		Interface:      spec.Name,
 		Concrete:       structSpec.Name,
-		MockName:       fmt.Sprintf("mock%s", spec.Name),
-		MockConfigName: fmt.Sprintf("mock%sConfig", spec.Name),
-		MockFactory:    fmt.Sprintf("new%sMock", strings.ToUpper(spec.Name[:1])+spec.Name[1:]),
+		MockName:       mockName,
+		MockConfigName: fmt.Sprintf("%sConfig", strings.ToLower(mockName[:1])+mockName[1:]),
+		MockFactory:    mockFactory,
 		Methods:        spec.Methods,
 		Package:        common.Package,
 		Importer:       common.Importer,
+		MockPackage:    mocks.packageName(common.Importer),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
@@ -306,13 +794,13 @@ import "github.com/jackclarke/GoStubGen/stubs"
 			Inputs   []Param
 			Outputs  []Param
 		}{
-			MockName: fmt.Sprintf("mock%s", spec.Name),
+			MockName: mockName,
 			Name:     method.Name,
 			Inputs:   method.Inputs,
 			Outputs:  method.Outputs,
 		}
 
-		if err := writeTemplate(file, methodDividerTemplate, data, funcs); err != nil {
+		if err := writeTemplate(w, methodDividerTemplate, data, funcs); err != nil {
 			return err
 		}
 		// Always generate core + function enqueue templates
@@ -320,17 +808,28 @@ import "github.com/jackclarke/GoStubGen/stubs"
 			enableSpyTemplate,
 			getSpiedCallsTemplate,
 			disableSpyTemplate,
+			argCloningTemplate,
 			methodOverrideTemplate,
+			hooksTemplate,
+			blockTemplate,
+			concurrencyTemplate,
 			setFuncTemplate,
 			enableTemplate,
 			disableTemplate,
 			enqueueFuncTemplate,
 			enqueueFuncWithDelayTemplate,
+			subscribeTemplate,
 			captureResultTemplate,
 			captureSpyCallTemplate,
+			waitForCallsTemplate,
+			setPanicTemplate,
+			enqueuePanicTemplate,
+			injectFaultsTemplate,
 			tupleStructTemplate,
+			eventStructTemplate,
+			typedCallsTemplate,
 		} {
-			if err := writeTemplate(file, tmplStr, data, funcs); err != nil {
+			if err := writeTemplate(w, tmplStr, data, funcs); err != nil {
 				return err
 			}
 		}
@@ -342,7 +841,7 @@ import "github.com/jackclarke/GoStubGen/stubs"
 				enqueueStaticTemplate,
 				enqueueStaticWithDelayTemplate,
 			} {
-				if err := writeTemplate(file, tmplStr, data, funcs); err != nil {
+				if err := writeTemplate(w, tmplStr, data, funcs); err != nil {
 					return err
 				}
 			}
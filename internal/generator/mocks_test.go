@@ -0,0 +1,393 @@
+package generator
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generateMockIn runs GenerateMock in a temporary directory and parses the resulting file
+func generateMockIn(t *testing.T, spec InterfaceSpec, common CommonSpec, relPath string) *ast.File {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := GenerateMock(spec, StructSpec{}, common); err != nil {
+		t.Fatalf("GenerateMock: %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, relPath), nil, 0)
+	if err != nil {
+		t.Fatalf("generated mock does not parse: %v", err)
+	}
+	return file
+}
+
+// checkFset and sourceImporter are shared by every type check so stubs and its imports are only read once
+var (
+	checkFset      = token.NewFileSet()
+	sourceImporter = importer.ForCompiler(checkFset, "source", nil).(types.ImporterFrom)
+)
+
+// typeCheckMock generates spec's interface package next to the mock at relPath and type-checks the mock
+// against it. Other imports, including stubs, are read from source in this module.
+func typeCheckMock(t *testing.T, spec InterfaceSpec, common CommonSpec, relPath string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateInterfaces([]InterfaceSpec{spec}, common)
+	if err == nil {
+		err = GenerateMock(spec, StructSpec{}, common)
+	}
+	os.Chdir(wd)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	generatedPath := "github.com/jackclarke/GoStubGen/generated/" + common.Package
+	fromModule := importerFunc(func(path string) (*types.Package, error) {
+		return sourceImporter.ImportFrom(path, wd, 0)
+	})
+	generated := checkPackage(t, generatedPath, filepath.Join(dir, "generated", common.Package, strings.ToLower(spec.Name)+".go"), fromModule)
+	checkPackage(t, "mocks", filepath.Join(dir, relPath), importerFunc(func(path string) (*types.Package, error) {
+		if path == generatedPath {
+			return generated, nil
+		}
+		return fromModule(path)
+	}))
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// checkPackage type-checks file as the package at path and fails t with every type error
+func checkPackage(t *testing.T, path, file string, imports types.Importer) *types.Package {
+	t.Helper()
+	parsed, err := parser.ParseFile(checkFset, file, nil, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	var errs []string
+	conf := types.Config{Importer: imports, Error: func(err error) { errs = append(errs, err.Error()) }}
+	pkg, _ := conf.Check(path, checkFset, []*ast.File{parsed}, nil)
+	if len(errs) > 0 {
+		t.Fatalf("generated code does not compile:\n%s", strings.Join(errs, "\n"))
+	}
+	return pkg
+}
+
+func declaredNames(file *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			names[d.Name.Name] = true
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				if ts, ok := s.(*ast.TypeSpec); ok {
+					names[ts.Name.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+var loaderSpec = InterfaceSpec{
+	Name: "Loader",
+	Methods: []Method{
+		{Name: "LoadCargo", Inputs: []Param{{Name: "items", Type: "[]string"}}, Outputs: []Param{{Type: "int"}, {Type: "error"}}},
+	},
+}
+
+func TestGenerateMock_Visibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		mocks    MockSpec
+		path     string
+		pkg      string
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "unexported by default",
+			path:     "generated/importer/loader_mock_test.go",
+			pkg:      "driver",
+			expected: []string{"mockLoader", "newLoaderMock", "enableLoadCargoMock", "reset", "scope"},
+			absent:   []string{"Loader", "NewLoader"},
+		},
+		{
+			name:     "exported into a mocks package",
+			mocks:    MockSpec{Package: "vehiclemock", Visibility: "exported"},
+			path:     "generated/vehiclemock/loader_mock.go",
+			pkg:      "vehiclemock",
+			expected: []string{"Loader", "NewLoader", "NewLoaderWithClock", "EnableLoadCargoMock", "SetLoadCargoResponse", "Reset", "Scope", "LoaderSnapshot", "OnLoadCargoBefore", "NotifyLoadCargoCalled"},
+			absent:   []string{"mockLoader", "enableLoadCargoMock"},
+		},
+		{
+			name:     "external test package",
+			mocks:    MockSpec{ExternalTestPackage: true, FileSuffix: "_gen_test.go"},
+			path:     "generated/importer/loader_gen_test.go",
+			pkg:      "driver_test",
+			expected: []string{"mockLoader", "newLoaderMock"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common := CommonSpec{Package: "vehicle", Importer: "driver", Mocks: tt.mocks}
+			file := generateMockIn(t, loaderSpec, common, tt.path)
+			if file.Name.Name != tt.pkg {
+				t.Errorf("expected package %q, got %q", tt.pkg, file.Name.Name)
+			}
+			names := declaredNames(file)
+			for _, n := range tt.expected {
+				if !names[n] {
+					t.Errorf("expected %s to be declared", n)
+				}
+			}
+			for _, n := range tt.absent {
+				if names[n] {
+					t.Errorf("expected %s not to be declared", n)
+				}
+			}
+			typeCheckMock(t, loaderSpec, common, tt.path)
+		})
+	}
+}
+
+func TestGenerateMock_InvalidMockSpec(t *testing.T) {
+	tests := []struct {
+		name  string
+		mocks MockSpec
+	}{
+		{name: "unknown visibility", mocks: MockSpec{Visibility: "public"}},
+		{name: "same package as interfaces", mocks: MockSpec{Package: "vehicle"}},
+		{name: "external test package without test suffix", mocks: MockSpec{ExternalTestPackage: true, FileSuffix: "_mock.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common := CommonSpec{Package: "vehicle", Importer: "driver", Mocks: tt.mocks}
+			if err := GenerateMock(loaderSpec, StructSpec{}, common); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateMock_Backends(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     InterfaceSpec
+		mocks    MockSpec
+		imports  string
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "gomock selected per interface",
+			spec:     InterfaceSpec{Name: "Loader", Methods: loaderSpec.Methods, MockBackend: "gomock"},
+			imports:  "go.uber.org/mock/gomock",
+			expected: []string{"mockLoader", "mockLoaderRecorder", "newLoaderMock", "EXPECT", "LoadCargo"},
+			absent:   []string{"enableLoadCargoMock"},
+		},
+		{
+			name:     "testify as the default backend",
+			spec:     loaderSpec,
+			mocks:    MockSpec{Backend: "testify", Visibility: "exported", Package: "vehiclemock"},
+			imports:  "github.com/stretchr/testify/mock",
+			expected: []string{"Loader", "NewLoader", "LoadCargo"},
+			absent:   []string{"EnableLoadCargoMock"},
+		},
+		{
+			name:     "interface overrides the default backend",
+			spec:     InterfaceSpec{Name: "Loader", Methods: loaderSpec.Methods, MockBackend: "native"},
+			mocks:    MockSpec{Backend: "gomock"},
+			imports:  "github.com/jackclarke/GoStubGen/stubs",
+			expected: []string{"enableLoadCargoMock"},
+			absent:   []string{"EXPECT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common := CommonSpec{Package: "vehicle", Importer: "driver", Mocks: tt.mocks}
+			path := filepath.Join(tt.mocks.dir(), "loader"+tt.mocks.fileSuffix())
+			file := generateMockIn(t, tt.spec, common, path)
+
+			imported := false
+			for _, imp := range file.Imports {
+				if imp.Path.Value == `"`+tt.imports+`"` {
+					imported = true
+				}
+			}
+			if !imported {
+				t.Errorf("expected import of %s", tt.imports)
+			}
+			names := declaredNames(file)
+			for _, n := range tt.expected {
+				if !names[n] {
+					t.Errorf("expected %s to be declared", n)
+				}
+			}
+			for _, n := range tt.absent {
+				if names[n] {
+					t.Errorf("expected %s not to be declared", n)
+				}
+			}
+		})
+	}
+}
+
+// backendDependencies are the modules generated mocks import for each third-party backend
+var backendDependencies = map[string]string{
+	"gomock":  "go.uber.org/mock",
+	"testify": "github.com/stretchr/testify",
+}
+
+func TestGenerateMock_BackendsBuild(t *testing.T) {
+	for name := range mockBackends {
+		t.Run(name, func(t *testing.T) {
+			spec := InterfaceSpec{Name: "Loader", Methods: loaderSpec.Methods, MockBackend: name}
+			common := CommonSpec{Package: "vehicle", Importer: "driver", Mocks: MockSpec{Visibility: "exported", Package: "vehiclemock"}}
+			dep, ok := backendDependencies[name]
+			if !ok {
+				typeCheckMock(t, spec, common, "generated/vehiclemock/loader_mock.go")
+				return
+			}
+			buildWithDependency(t, spec, common, dep)
+		})
+	}
+}
+
+// requireBackendBuildEnv makes the third-party backend builds fail instead of skipping when their
+// dependencies cannot be downloaded. CI sets it, so the backends are always built there.
+const requireBackendBuildEnv = "STUBS_REQUIRE_BACKEND_BUILD"
+
+// buildWithDependency generates spec's interface and mock into a module requiring dep and builds it.
+// The test is skipped when dep cannot be downloaded, unless STUBS_REQUIRE_BACKEND_BUILD is set.
+func buildWithDependency(t *testing.T, spec InterfaceSpec, common CommonSpec, dep string) {
+	t.Helper()
+	required := os.Getenv(requireBackendBuildEnv) != ""
+	if testing.Short() && !required {
+		t.Skip("downloads " + dep)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	err = GenerateInterfaces([]InterfaceSpec{spec}, common)
+	if err == nil {
+		err = GenerateMock(spec, StructSpec{}, common)
+	}
+	os.Chdir(wd)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	goMod := "module github.com/jackclarke/GoStubGen\n\ngo 1.22\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := goCommand(dir, "mod", "tidy"); err != nil {
+		if required {
+			t.Fatalf("%s is not available: %v\n%s", dep, err, out)
+		}
+		t.Skipf("%s is not available: %v\n%s", dep, err, out)
+	}
+	if out, err := goCommand(dir, "build", "./..."); err != nil {
+		t.Fatalf("generated code does not build: %v\n%s", err, out)
+	}
+}
+
+func goCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+func TestGenerateMock_UnknownBackend(t *testing.T) {
+	spec := InterfaceSpec{Name: "Loader", Methods: loaderSpec.Methods, MockBackend: "mockery"}
+	err := GenerateMock(spec, StructSpec{}, CommonSpec{Package: "vehicle", Importer: "driver"})
+	if err == nil || !strings.Contains(err.Error(), "gomock, native, testify") {
+		t.Fatalf("expected an error listing the backends, got %v", err)
+	}
+}
+
+func TestResultFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []Param
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "named outputs",
+			outputs: []Param{{Name: "newSpeed", Type: "int"}, {Name: "err", Type: "error"}},
+			want:    "NewSpeed Err",
+		},
+		{
+			name:    "unnamed outputs",
+			outputs: []Param{{Type: "int"}, {Type: "error"}},
+			want:    "Output0 Output1",
+		},
+		{
+			name:    "clashes with a method",
+			outputs: []Param{{Name: "values", Type: "[]int"}, {Name: "err", Type: "error"}},
+			wantErr: "output field Values of its result struct clashes with the Values method",
+		},
+		{
+			name:    "clashes with another output",
+			outputs: []Param{{Name: "speed", Type: "int"}, {Name: "Speed", Type: "int"}},
+			wantErr: "output field Speed of its result struct clashes with output 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkResultFields(Method{Name: "Accelerate", Outputs: tt.outputs})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, f := range resultFields(tt.outputs) {
+				names = append(names, f.Name)
+			}
+			if got := strings.Join(names, " "); got != tt.want {
+				t.Fatalf("expected fields %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
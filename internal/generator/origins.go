@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"strings"
+)
+
+// MethodOrigin explains why a type has a method
+type MethodOrigin struct {
+	Method string
+	// Via lists the types the method is reached through, ending with the interface that declares it.
+	// It is empty for methods an interface declares itself.
+	Via []string
+	// ProvidedBy is the embedded interface or struct that supplies the method, so it is not declared again
+	// or stubbed. It is set exactly for the methods in the type's embedded set.
+	ProvidedBy string
+}
+
+// String describes the origin, e.g. "Accelerate via Car → Vehicle"
+func (o MethodOrigin) String() string {
+	s := o.Method
+	if len(o.Via) == 0 {
+		s += " declared"
+	} else {
+		s += " via " + strings.Join(o.Via, " → ")
+	}
+	if o.ProvidedBy != "" {
+		s += ", provided by " + o.ProvidedBy
+	}
+	return s
+}
+
+func prepend(name string, path []string) []string {
+	return append([]string{name}, path...)
+}
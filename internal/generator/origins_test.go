@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestMethodOrigins(t *testing.T) {
+	interfaces := []InterfaceSpec{
+		{Name: "FourWheelVehicle", Methods: []Method{{Name: "Drive"}}},
+		{Name: "Car", Embedded: []string{"FourWheelVehicle"}, Methods: []Method{{Name: "OpenTrunk"}}},
+		{Name: "SelfDriving", Embedded: []string{"Car"}, Methods: []Method{{Name: "ActivateAutopilot"}, {Name: "Drive"}}},
+	}
+	structs := []StructSpec{
+		{Name: "Sedan", Implements: []string{"Car"}},
+		{Name: "RoboCar", Implements: []string{"SelfDriving"}, Embedded: []string{"Sedan"}},
+	}
+	interfaceSets, structSets := GetMethods(structs, interfaces)
+
+	tests := []struct {
+		origins NameToMethodOrigins
+		typ     string
+		method  string
+		want    string
+	}{
+		{interfaceSets.Origins, "Car", "OpenTrunk", "OpenTrunk declared"},
+		{interfaceSets.Origins, "Car", "Drive", "Drive via FourWheelVehicle, provided by FourWheelVehicle"},
+		// declared directly wins over the embedded declaration, though Car still provides it
+		{interfaceSets.Origins, "SelfDriving", "Drive", "Drive declared, provided by Car"},
+		{structSets.Origins, "Sedan", "Drive", "Drive via Car → FourWheelVehicle"},
+		{structSets.Origins, "RoboCar", "ActivateAutopilot", "ActivateAutopilot via SelfDriving"},
+		{structSets.Origins, "RoboCar", "OpenTrunk", "OpenTrunk via SelfDriving → Car, provided by Sedan"},
+	}
+	for _, tt := range tests {
+		origin, ok := tt.origins[tt.typ][tt.method]
+		if !ok {
+			t.Errorf("expected an origin for %s.%s", tt.typ, tt.method)
+			continue
+		}
+		if got := origin.String(); got != tt.want {
+			t.Errorf("%s.%s: expected %q, got %q", tt.typ, tt.method, tt.want, got)
+		}
+	}
+}
+
+func TestMethodOriginsEmbeddingCycle(t *testing.T) {
+	interfaces := []InterfaceSpec{
+		{Name: "A", Embedded: []string{"B"}, Methods: []Method{{Name: "Foo"}}},
+		{Name: "B", Embedded: []string{"A"}, Methods: []Method{{Name: "Bar"}}},
+	}
+	sets, _ := GetMethods(nil, interfaces)
+	if got := sets.Origins["A"]["Bar"].String(); got != "Bar via B, provided by B" {
+		t.Fatalf("expected cycles to terminate with the first path, got %q", got)
+	}
+}
+
+func TestMethodOriginsMatchMethodSets_Examples(t *testing.T) {
+	paths, _ := filepath.Glob("../../examples/vehicle-example/*.yaml")
+	if len(paths) == 0 {
+		t.Fatal("expected example configs")
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var config struct {
+			Interfaces   []InterfaceSpec `yaml:"interfaces"`
+			Implementers []StructSpec    `yaml:"implementers"`
+		}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			t.Fatal(err)
+		}
+
+		interfaceSets, structSets := GetMethods(config.Implementers, config.Interfaces)
+		for _, sets := range []MethodSets{interfaceSets, structSets} {
+			for name, full := range sets.FullSets {
+				embedded := map[string]bool{}
+				for _, m := range sets.EmbeddedSets[name] {
+					embedded[m.Name] = true
+				}
+				if len(sets.Origins[name]) != len(full) {
+					t.Errorf("%s: expected an origin for every method of %s, got %v", path, name, sets.Origins[name])
+				}
+				for _, m := range full {
+					origin := sets.Origins[name][m.Name]
+					if (origin.ProvidedBy != "") != embedded[m.Name] {
+						t.Errorf("%s: %s.%s is provided by %q but in the embedded set: %v", path, name, m.Name, origin.ProvidedBy, embedded[m.Name])
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package stubs
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// cleanups runs registered funcs last-in first-out, as the testing package does
+type cleanups struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+func (c *cleanups) add(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fns = append(c.fns, fn)
+}
+
+// run runs and forgets every func, including ones registered by a func while it runs
+func (c *cleanups) run() {
+	for {
+		c.mu.Lock()
+		if len(c.fns) == 0 {
+			c.mu.Unlock()
+			return
+		}
+		fn := c.fns[len(c.fns)-1]
+		c.fns = c.fns[:len(c.fns)-1]
+		c.mu.Unlock()
+		fn()
+	}
+}
+
+// Failure is the value PanicTB panics with
+type Failure struct {
+	Messages []string
+}
+
+func (f *Failure) Error() string {
+	return strings.Join(f.Messages, "\n")
+}
+
+// PanicTB reports failures by panicking, for using mocks outside go test, e.g. in a main program or
+// a custom test runner. Fatalf panics with a *Failure straight away; Errorf records the message and
+// Finish panics with every recorded message once the cleanups have run. The zero value is ready to use.
+type PanicTB struct {
+	cleanups cleanups
+
+	mu     sync.Mutex
+	errors []string
+}
+
+// Helper does nothing, as there is no test output to trim
+func (p *PanicTB) Helper() {}
+
+// Errorf records a failure reported by Finish
+func (p *PanicTB) Errorf(format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errors = append(p.errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf panics with a *Failure holding the message and any earlier ones
+func (p *PanicTB) Fatalf(format string, args ...any) {
+	p.Errorf(format, args...)
+	panic(p.failure())
+}
+
+// Fatal is Fatalf with the message formatted as by fmt.Sprint
+func (p *PanicTB) Fatal(args ...any) {
+	p.Fatalf("%s", fmt.Sprint(args...))
+}
+
+// Cleanup registers fn to run in Finish
+func (p *PanicTB) Cleanup(fn func()) {
+	p.cleanups.add(fn)
+}
+
+// Failed reports whether a failure has been recorded
+func (p *PanicTB) Failed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.errors) > 0
+}
+
+// Finish runs the cleanups, then panics with a *Failure if any failure was recorded. Call it with defer.
+func (p *PanicTB) Finish() {
+	p.cleanups.run()
+	if p.Failed() {
+		panic(p.failure())
+	}
+}
+
+func (p *PanicTB) failure() *Failure {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &Failure{Messages: append([]string(nil), p.errors...)}
+}
+
+// FuncTB adapts frameworks that report failures through callbacks, such as BDD suites with a
+// Fail(message) handler and a DeferCleanup(func) hook:
+//
+//	tb := &stubs.FuncTB{
+//		Fail:    func(msg string) { Fail(msg) },
+//		Cleanup: func(fn func()) { DeferCleanup(fn) },
+//	}
+type FuncTB struct {
+	// Fail reports a failure. It is called by both Errorf and Fatalf.
+	Fail func(message string)
+	// Cleanup registers fn to run when the spec or test ends
+	Cleanup func(fn func())
+	// Helper, if set, marks the calling function as a test helper
+	Helper func()
+}
+
+// funcTB is the TB view of a FuncTB, which cannot implement TB itself as its fields share the method names
+type funcTB struct {
+	f *FuncTB
+}
+
+// TB returns f as a TB. Fatalf stops the calling goroutine with runtime.Goexit if Fail returns.
+func (f *FuncTB) TB() TB {
+	return funcTB{f: f}
+}
+
+func (t funcTB) Helper() {
+	if t.f.Helper != nil {
+		t.f.Helper()
+	}
+}
+
+func (t funcTB) Errorf(format string, args ...any) {
+	t.f.Fail(fmt.Sprintf(format, args...))
+}
+
+func (t funcTB) Fatalf(format string, args ...any) {
+	t.f.Fail(fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func (t funcTB) Fatal(args ...any) {
+	t.Fatalf("%s", fmt.Sprint(args...))
+}
+
+func (t funcTB) Cleanup(fn func()) {
+	t.f.Cleanup(fn)
+}
+
+// IterationTB scopes cleanups to one iteration of a benchmark loop or one fuzz input, so mocks built per
+// iteration are reset and leak-checked as each iteration ends instead of piling up until the benchmark
+// or fuzz target finishes. Failures are reported to the parent.
+type IterationTB struct {
+	parent   TB
+	cleanups cleanups
+}
+
+// NewIterationTB returns an IterationTB reporting to parent, usually a *testing.B or *testing.F
+func NewIterationTB(parent TB) *IterationTB {
+	it := &IterationTB{parent: parent}
+	// anything left when the parent finishes still runs
+	parent.Cleanup(it.Done)
+	return it
+}
+
+func (it *IterationTB) Helper() {
+	it.parent.Helper()
+}
+
+func (it *IterationTB) Errorf(format string, args ...any) {
+	it.parent.Helper()
+	it.parent.Errorf(format, args...)
+}
+
+func (it *IterationTB) Fatalf(format string, args ...any) {
+	it.parent.Helper()
+	it.parent.Fatalf(format, args...)
+}
+
+func (it *IterationTB) Fatal(args ...any) {
+	it.parent.Helper()
+	it.parent.Fatal(args...)
+}
+
+// Cleanup registers fn to run in the next call to Done
+func (it *IterationTB) Cleanup(fn func()) {
+	it.cleanups.add(fn)
+}
+
+// Done ends the iteration, running its cleanups last-in first-out
+func (it *IterationTB) Done() {
+	it.cleanups.run()
+}
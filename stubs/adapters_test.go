@@ -0,0 +1,161 @@
+package stubs
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	_ TB = (*testing.T)(nil)
+	_ TB = (*testing.B)(nil)
+	_ TB = (*testing.F)(nil)
+	_ TB = testing.TB(nil)
+	_ TB = (*PanicTB)(nil)
+	_ TB = (*IterationTB)(nil)
+)
+
+// baselineT implements only the original TestingT, as third-party test types did
+type baselineT struct {
+	failed bool
+}
+
+func (b *baselineT) Helper()                           {}
+func (b *baselineT) Fatal(args ...any)                 { b.failed = true }
+func (b *baselineT) Fatalf(format string, args ...any) { b.failed = true }
+
+func TestTestingTStillAccepted(t *testing.T) {
+	bt := &baselineT{}
+	MustPanic(bt, func() {})
+	if !bt.failed {
+		t.Fatal("expected MustPanic to fail a TestingT when fn does not panic")
+	}
+	ch := make(chan int, 1)
+	ch <- 1
+	if got := WaitForResult(bt, ch, time.Second); got != 1 {
+		t.Fatalf("expected 1, got %d", got)
+	}
+}
+
+// recoverFailure runs fn and returns the *Failure it panicked with, if any
+func recoverFailure(fn func()) (f *Failure) {
+	defer func() {
+		if r := recover(); r != nil {
+			f = r.(*Failure)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestPanicTBFinish(t *testing.T) {
+	var tb PanicTB
+	var order []int
+	tb.Cleanup(func() { order = append(order, 1) })
+	tb.Cleanup(func() { order = append(order, 2) })
+	tb.Errorf("expected %d calls", 2)
+
+	f := recoverFailure(tb.Finish)
+	if f == nil || f.Error() != "expected 2 calls" {
+		t.Fatalf("expected Finish to panic with the recorded failure, got %v", f)
+	}
+	if len(order) != 2 || order[0] != 2 || order[1] != 1 {
+		t.Fatalf("expected cleanups to run last-in first-out, got %v", order)
+	}
+}
+
+func TestPanicTBFatalf(t *testing.T) {
+	var tb PanicTB
+	tb.Errorf("first")
+	f := recoverFailure(func() { tb.Fatalf("second %s", "failure") })
+	if f == nil || len(f.Messages) != 2 || f.Messages[1] != "second failure" {
+		t.Fatalf("expected Fatalf to panic with every failure, got %v", f)
+	}
+	if !tb.Failed() {
+		t.Fatal("expected the reporter to be marked failed")
+	}
+}
+
+func TestPanicTBFinishPasses(t *testing.T) {
+	var tb PanicTB
+	ran := false
+	tb.Cleanup(func() { ran = true })
+	if f := recoverFailure(tb.Finish); f != nil {
+		t.Fatalf("expected no failure, got %v", f)
+	}
+	if !ran {
+		t.Fatal("expected the cleanup to run")
+	}
+}
+
+func TestPanicTBWithMock(t *testing.T) {
+	var tb PanicTB
+	var m MethodConfig[func(int) int]
+	m.RecordCall(1)
+	m.RecordCall(2)
+	f := recoverFailure(func() {
+		defer tb.Finish()
+		AssertNeverConcurrent(&tb, &m)
+	})
+	if f == nil || !strings.Contains(f.Error(), "call 2(2) overlapped call 1(1)") {
+		t.Fatalf("expected the assertion to panic with a failure, got %v", f)
+	}
+}
+
+func TestFuncTB(t *testing.T) {
+	var failures []string
+	var cleanups []func()
+	ftb := &FuncTB{
+		Fail:    func(msg string) { failures = append(failures, msg) },
+		Cleanup: func(fn func()) { cleanups = append(cleanups, fn) },
+	}
+	tb := ftb.TB()
+	tb.Helper()
+	tb.Errorf("soft %d", 1)
+
+	var wg sync.WaitGroup
+	reached := false
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		tb.Fatalf("hard %d", 2)
+		reached = true
+	}()
+	wg.Wait()
+	if reached {
+		t.Fatal("expected Fatalf to stop the goroutine")
+	}
+	if len(failures) != 2 || failures[0] != "soft 1" || failures[1] != "hard 2" {
+		t.Fatalf("expected both failures to reach Fail, got %v", failures)
+	}
+
+	tb.Cleanup(func() {})
+	if len(cleanups) != 1 {
+		t.Fatalf("expected the cleanup to be passed on, got %d", len(cleanups))
+	}
+}
+
+func TestIterationTB(t *testing.T) {
+	parent := &fakeT{}
+	it := NewIterationTB(parent)
+	runs := 0
+	for i := 0; i < 3; i++ {
+		it.Cleanup(func() { runs++ })
+		it.Done()
+		if runs != i+1 {
+			t.Fatalf("expected iteration %d's cleanup to run at Done, got %d runs", i, runs)
+		}
+	}
+
+	it.Cleanup(func() { runs++ })
+	parent.finish()
+	if runs != 4 {
+		t.Fatalf("expected leftover cleanups to run with the parent's, got %d runs", runs)
+	}
+
+	it.Errorf("bad %s", "input")
+	if len(parent.errors) != 1 || parent.errors[0] != "bad input" {
+		t.Fatalf("expected failures to reach the parent, got %v", parent.errors)
+	}
+}
@@ -0,0 +1,263 @@
+package stubs
+
+import (
+	"sync"
+)
+
+// OverflowPolicy decides what happens to an event published to a subscription whose buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowDrop discards the event and counts it in Dropped
+	OverflowDrop OverflowPolicy = iota
+	// OverflowBlock blocks the publishing call until the subscriber reads or unsubscribes
+	OverflowBlock
+	// OverflowUnbounded queues the event in memory so the publisher never blocks
+	OverflowUnbounded
+)
+
+// SubscribeOptions configures a Subscription
+type SubscribeOptions struct {
+	// Buffer is the capacity of the subscription channel
+	Buffer   int
+	Overflow OverflowPolicy
+}
+
+// subscriber receives events from a Broadcaster
+type subscriber[E any] interface {
+	publish(E)
+	Unsubscribe()
+}
+
+// Broadcaster fans out events to any number of subscriptions. The zero value is ready to use.
+type Broadcaster[E any] struct {
+	mu   sync.RWMutex
+	next uint64
+	subs map[uint64]subscriber[E]
+	// leaks tracks publishers blocked on subscriptions for the test passed to TrackLeaks, or is nil
+	leaks *leakRegistry
+}
+
+// Subscribe returns a subscription that receives every event published from now on
+func (b *Broadcaster[E]) Subscribe(opts SubscribeOptions) *Subscription[E] {
+	return SubscribeMapped(b, opts, func(e E) E { return e })
+}
+
+// SubscribeMapped is Subscribe with each event converted by fn before delivery
+func SubscribeMapped[E, R any](b *Broadcaster[E], opts SubscribeOptions, fn func(E) R) *Subscription[R] {
+	sub := newSubscription[R](opts)
+
+	b.mu.Lock()
+	if b.subs == nil {
+		b.subs = make(map[uint64]subscriber[E])
+	}
+	b.next++
+	id := b.next
+	sub.leaks = b.leaks
+	b.subs[id] = mappedSubscriber[E, R]{sub: sub, fn: fn}
+	b.mu.Unlock()
+
+	sub.detach = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+	return sub
+}
+
+// Publish delivers e to every subscription according to its overflow policy
+func (b *Broadcaster[E]) Publish(e E) {
+	b.mu.RLock()
+	subs := make([]subscriber[E], 0, len(b.subs))
+	for _, s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		s.publish(e)
+	}
+}
+
+// Mark returns a marker for the subscriptions made so far, for use with UnsubscribeAfter
+func (b *Broadcaster[E]) Mark() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.next
+}
+
+// UnsubscribeAfter unsubscribes every subscription made after mark was taken
+func (b *Broadcaster[E]) UnsubscribeAfter(mark uint64) {
+	b.mu.RLock()
+	var subs []subscriber[E]
+	for id, s := range b.subs {
+		if id > mark {
+			subs = append(subs, s)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		s.Unsubscribe()
+	}
+}
+
+// UnsubscribeAll unsubscribes every subscription
+func (b *Broadcaster[E]) UnsubscribeAll() {
+	b.UnsubscribeAfter(0)
+}
+
+// Subscribers returns the number of active subscriptions
+func (b *Broadcaster[E]) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+type mappedSubscriber[E, R any] struct {
+	sub *Subscription[R]
+	fn  func(E) R
+}
+
+func (m mappedSubscriber[E, R]) publish(e E) {
+	m.sub.send(m.fn(e))
+}
+
+func (m mappedSubscriber[E, R]) Unsubscribe() {
+	m.sub.Unsubscribe()
+}
+
+// Subscription receives events on C until Unsubscribe is called, after which C is closed
+type Subscription[E any] struct {
+	C <-chan E
+
+	ch       chan E
+	overflow OverflowPolicy
+	detach   func()
+	leaks    *leakRegistry
+
+	mu       sync.Mutex
+	closed   bool
+	dropped  int
+	done     chan struct{}
+	inflight sync.WaitGroup
+
+	// used by OverflowUnbounded only
+	pending   []E
+	wake      chan struct{}
+	forwarded chan struct{}
+}
+
+func newSubscription[E any](opts SubscribeOptions) *Subscription[E] {
+	ch := make(chan E, opts.Buffer)
+	s := &Subscription[E]{
+		C:        ch,
+		ch:       ch,
+		overflow: opts.Overflow,
+		done:     make(chan struct{}),
+	}
+	if s.overflow == OverflowUnbounded {
+		s.wake = make(chan struct{}, 1)
+		s.forwarded = make(chan struct{})
+		go s.forward()
+	}
+	return s
+}
+
+func (s *Subscription[E]) send(e E) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	if s.overflow == OverflowUnbounded {
+		s.pending = append(s.pending, e)
+		s.mu.Unlock()
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+		return
+	}
+	s.inflight.Add(1)
+	s.mu.Unlock()
+	defer s.inflight.Done()
+
+	if s.overflow == OverflowBlock {
+		select {
+		case s.ch <- e:
+			return
+		default:
+		}
+		defer s.leaks.track("blocked sending to a subscription")()
+		select {
+		case s.ch <- e:
+		case <-s.done:
+		}
+		return
+	}
+
+	select {
+	case s.ch <- e:
+	default:
+		s.mu.Lock()
+		s.dropped++
+		s.mu.Unlock()
+	}
+}
+
+// forward moves queued events onto the channel for OverflowUnbounded subscriptions
+func (s *Subscription[E]) forward() {
+	defer close(s.forwarded)
+	defer close(s.ch)
+	for {
+		s.mu.Lock()
+		if len(s.pending) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.wake:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		e := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+
+		select {
+		case s.ch <- e:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Dropped returns the number of events discarded by OverflowDrop
+func (s *Subscription[E]) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Unsubscribe stops delivery, releases any blocked publisher and closes C. It is safe to call more than once.
+func (s *Subscription[E]) Unsubscribe() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.done)
+	s.mu.Unlock()
+
+	if s.detach != nil {
+		s.detach()
+	}
+	s.inflight.Wait()
+	if s.overflow == OverflowUnbounded {
+		<-s.forwarded
+		return
+	}
+	close(s.ch)
+}
@@ -0,0 +1,75 @@
+package stubs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcasterOverflowPolicies(t *testing.T) {
+	var b Broadcaster[int]
+	drop := b.Subscribe(SubscribeOptions{Buffer: 1, Overflow: OverflowDrop})
+	unbounded := b.Subscribe(SubscribeOptions{Overflow: OverflowUnbounded})
+
+	for i := 1; i <= 3; i++ {
+		b.Publish(i)
+	}
+
+	if got := <-drop.C; got != 1 {
+		t.Fatalf("expected first event 1, got %d", got)
+	}
+	if n := drop.Dropped(); n != 2 {
+		t.Fatalf("expected 2 dropped events, got %d", n)
+	}
+	for want := 1; want <= 3; want++ {
+		if got := WaitForResult(t, unbounded.C, time.Second); got != want {
+			t.Fatalf("expected %d, got %d", want, got)
+		}
+	}
+
+	drop.Unsubscribe()
+	unbounded.Unsubscribe()
+	if n := b.Subscribers(); n != 0 {
+		t.Fatalf("expected no subscribers after unsubscribe, got %d", n)
+	}
+	if _, ok := <-unbounded.C; ok {
+		t.Fatal("expected channel to be closed after unsubscribe")
+	}
+}
+
+func TestBroadcasterBlockPolicyReleasedByUnsubscribe(t *testing.T) {
+	var b Broadcaster[string]
+	sub := b.Subscribe(SubscribeOptions{Overflow: OverflowBlock})
+
+	published := make(chan struct{})
+	go func() {
+		b.Publish("first")
+		b.Publish("second")
+		close(published)
+	}()
+
+	if got := WaitForResult(t, sub.C, time.Second); got != "first" {
+		t.Fatalf("expected first, got %s", got)
+	}
+	select {
+	case <-published:
+		t.Fatal("publisher should block until the second event is read")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	sub.Unsubscribe()
+	WaitForResult(t, published, time.Second)
+}
+
+func TestSubscribeMapped(t *testing.T) {
+	var b Broadcaster[int]
+	sub := SubscribeMapped(&b, SubscribeOptions{Buffer: 2}, func(i int) string {
+		return string(rune('a' + i))
+	})
+	defer sub.Unsubscribe()
+
+	b.Publish(0)
+	b.Publish(1)
+	if got := <-sub.C + <-sub.C; got != "ab" {
+		t.Fatalf("expected ab, got %s", got)
+	}
+}
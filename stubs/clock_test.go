@@ -0,0 +1,107 @@
+package stubs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	woke := make(chan time.Time, 1)
+	go func() {
+		clock.Sleep(time.Minute)
+		woke <- clock.Now()
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+	select {
+	case <-woke:
+		t.Fatal("sleeper woke before its deadline")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if n := clock.Waiters(); n != 1 {
+		t.Fatalf("expected 1 waiter, got %d", n)
+	}
+
+	clock.Advance(30 * time.Second)
+	if got := WaitForResult(t, woke, time.Second); !got.Equal(start.Add(time.Minute)) {
+		t.Fatalf("expected to wake at %s, got %s", start.Add(time.Minute), got)
+	}
+}
+
+func TestMethodConfigUsesClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	var m MethodConfig[func() int]
+	m.SetClock(clock)
+	m.EnableSpy()
+	m.RecordCall()
+	if ts := m.Calls()[0].Timestamp; !ts.Equal(start) {
+		t.Fatalf("expected call timestamp %s, got %s", start, ts)
+	}
+
+	m.EnqueueWithDelay(func() int { return 7 }, time.Hour)
+	got := make(chan int, 1)
+	go func() {
+		got <- m.NextResponse(nil)()
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	if v := WaitForResult(t, got, time.Second); v != 7 {
+		t.Fatalf("expected 7, got %d", v)
+	}
+}
+
+func TestWaitForSpyCallWithFakeClockTimesOut(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var m MethodConfig[func()]
+
+	ft := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		WaitForSpyCallWithClock(ft, clock, &m, time.Second)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	WaitForResult(t, done, time.Second)
+	if !ft.failed {
+		t.Fatal("expected timeout once the fake clock passed the deadline")
+	}
+}
+
+func TestFakeClockTimerStop(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	timer := clock.NewTimer(time.Minute)
+	if n := clock.Waiters(); n != 1 {
+		t.Fatalf("expected 1 waiter, got %d", n)
+	}
+	if !timer.Stop() {
+		t.Fatal("expected Stop to report the timer was pending")
+	}
+	if n := clock.Waiters(); n != 0 {
+		t.Fatalf("expected the stopped timer to stop waiting, got %d waiters", n)
+	}
+	clock.Advance(time.Minute)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+	if timer.Stop() {
+		t.Fatal("expected a second Stop to report nothing was pending")
+	}
+
+	fired := clock.NewTimer(time.Second)
+	clock.Advance(time.Second)
+	<-fired.C()
+	if fired.Stop() {
+		t.Fatal("expected Stop after firing to return false")
+	}
+}
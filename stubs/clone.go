@@ -0,0 +1,209 @@
+package stubs
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+	// opaqueTypes caches opaque by type
+	opaqueTypes sync.Map
+
+	clonersMu sync.RWMutex
+	cloners   = map[reflect.Type]func(reflect.Value) reflect.Value{}
+)
+
+// RegisterCloner makes Clone use fn for values of type T instead of copying them through reflection.
+// Registering a cloner for a type that already has one replaces it.
+func RegisterCloner[T any](fn func(T) T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	clonersMu.Lock()
+	defer clonersMu.Unlock()
+	cloners[t] = func(v reflect.Value) reflect.Value {
+		out := reflect.New(t).Elem()
+		if c := reflect.ValueOf(fn(v.Interface().(T))); c.IsValid() {
+			out.Set(c)
+		}
+		return out
+	}
+}
+
+// UnregisterCloner removes the cloner registered for T, if any
+func UnregisterCloner[T any]() {
+	clonersMu.Lock()
+	defer clonersMu.Unlock()
+	delete(cloners, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func clonerFor(t reflect.Type) func(reflect.Value) reflect.Value {
+	clonersMu.RLock()
+	defer clonersMu.RUnlock()
+	return cloners[t]
+}
+
+// Clone returns a deep copy of the plain data in v. Pointers, slices, maps and structs with only
+// exported fields are copied, and cycles are preserved. Structs with unexported fields, such as
+// sync and sync/atomic types or the values behind a context.Context, are kept by reference along
+// with anything holding them by value, since their state may be in use by other goroutines.
+// Channels, funcs, unsafe pointers, errors and contexts are kept by reference too.
+// Types with a cloner registered through RegisterCloner are copied by it.
+func Clone(v any) any {
+	if v == nil {
+		return nil
+	}
+	return cloneValue(reflect.ValueOf(v), map[visit]reflect.Value{}).Interface()
+}
+
+// visit identifies memory already copied, so shared and cyclic references are copied once
+type visit struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+// opaque reports whether values of t hold state Clone must not read: a struct with an unexported
+// field, or a struct or array holding one by value
+func opaque(t reflect.Type) bool {
+	if o, ok := opaqueTypes.Load(t); ok {
+		return o.(bool)
+	}
+	o := false
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !o; i++ {
+			f := t.Field(i)
+			o = !f.IsExported() || opaque(f.Type)
+		}
+	case reflect.Array:
+		o = opaque(t.Elem())
+	}
+	opaqueTypes.Store(t, o)
+	return o
+}
+
+// cloneValue deep copies the plain data in v
+func cloneValue(v reflect.Value, seen map[visit]reflect.Value) reflect.Value {
+	if fn := clonerFor(v.Type()); fn != nil {
+		return fn(v)
+	}
+	// errors are compared by identity, e.g. with errors.Is, and contexts are shared by design,
+	// so they are never copied
+	if v.Kind() != reflect.Interface && (v.Type().Implements(errorType) || v.Type().Implements(contextType)) {
+		return v
+	}
+	if opaque(v.Type()) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || opaque(v.Type().Elem()) {
+			return v
+		}
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[key] = c
+		c.Elem().Set(cloneValue(v.Elem(), seen))
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cloneValue(v.Elem(), seen))
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() || opaque(v.Type().Elem()) {
+			return v
+		}
+		key := visit{ptr: v.Pointer(), len: v.Len(), typ: v.Type()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		seen[key] = c
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i), seen))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i), seen))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() || opaque(v.Type().Key()) || opaque(v.Type().Elem()) {
+			return v
+		}
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		seen[key] = c
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(cloneValue(iter.Key(), seen), cloneValue(iter.Value(), seen))
+		}
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			c.Field(i).Set(cloneValue(v.Field(i), seen))
+		}
+		return c
+
+	default:
+		// scalars are copied by value; channels, funcs and unsafe pointers are shared
+		return v
+	}
+}
+
+// cloneArgs deep copies each value in args
+func cloneArgs(args []any) []any {
+	if args == nil {
+		return nil
+	}
+	out := make([]any, len(args))
+	for i, a := range args {
+		out[i] = Clone(a)
+	}
+	return out
+}
+
+// EnableArgCloning deep copies arguments and results as they are recorded. This is the default.
+func (m *MethodConfig[T]) EnableArgCloning() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.noClone = false
+}
+
+// DisableArgCloning records arguments and results by reference, e.g. for values too large to copy
+// or types that must keep their identity
+func (m *MethodConfig[T]) DisableArgCloning() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.noClone = true
+}
+
+// IsArgCloningEnabled reports whether arguments and results are deep copied as they are recorded
+func (m *MethodConfig[T]) IsArgCloningEnabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return !m.noClone
+}
@@ -0,0 +1,175 @@
+package stubs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type cloneNode struct {
+	Name  string
+	Next  *cloneNode
+	Tags  []string
+	Attrs map[string]int
+	Ch    chan int
+	Fn    func() int
+}
+
+func TestCloneCopiesNestedValues(t *testing.T) {
+	ch := make(chan int)
+	fn := func() int { return 1 }
+	a := &cloneNode{Name: "a", Tags: []string{"x"}, Attrs: map[string]int{"k": 1}, Ch: ch, Fn: fn}
+	b := &cloneNode{Name: "b", Next: a}
+	a.Next = b // cycle
+
+	c := Clone(a).(*cloneNode)
+	if c == a || c.Next == b {
+		t.Fatal("expected pointers to be copied")
+	}
+	if c.Next.Next != c {
+		t.Fatal("expected the cycle to be preserved in the copy")
+	}
+
+	a.Tags[0] = "changed"
+	a.Attrs["k"] = 2
+	if c.Tags[0] != "x" || c.Attrs["k"] != 1 {
+		t.Fatalf("expected slice and map to be copied, got %v %v", c.Tags, c.Attrs)
+	}
+	if c.Ch != ch || c.Fn == nil || c.Fn() != 1 {
+		t.Fatal("expected channels and funcs to be kept by reference")
+	}
+}
+
+func TestCloneSharedSliceCopiedOnce(t *testing.T) {
+	shared := []int{1, 2}
+	pair := [2][]int{shared, shared}
+	c := Clone(pair).([2][]int)
+	c[0][0] = 9
+	if c[1][0] != 9 || shared[0] != 1 {
+		t.Fatalf("expected shared slice to be copied once, got %v (original %v)", c, shared)
+	}
+}
+
+func TestCloneKeepsErrorsByReference(t *testing.T) {
+	sentinel := errors.New("boom")
+	args := Clone([]any{sentinel}).([]any)
+	if args[0] != sentinel {
+		t.Fatal("expected errors to keep their identity")
+	}
+}
+
+func TestCloneKeepsOpaqueValuesByReference(t *testing.T) {
+	type counter struct {
+		Hits atomic.Int64
+	}
+	type guarded struct {
+		Mu    sync.Mutex
+		Items []string
+	}
+	type hidden struct {
+		Items []string
+		state int
+	}
+
+	c := &counter{}
+	g := &guarded{Items: []string{"x"}}
+	h := &hidden{Items: []string{"x"}}
+	if Clone(c).(*counter) != c || Clone(g).(*guarded) != g || Clone(h).(*hidden) != h {
+		t.Fatal("expected pointers to structs with unexported state to be kept")
+	}
+	hs := []hidden{{state: 1}}
+	if got := Clone(hs).([]hidden); &got[0] != &hs[0] {
+		t.Fatal("expected slices of structs with unexported fields to be kept")
+	}
+}
+
+func TestCloneLiveContext(t *testing.T) {
+	// run with -race: cloning must not read the context's internals while it is in use
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_, cancelChild := context.WithCancel(ctx)
+			cancelChild()
+		}
+	}()
+
+	var m MethodConfig[func(context.Context, []string) error]
+	m.EnableSpy()
+	for i := 0; i < 200; i++ {
+		m.RecordCall(ctx, []string{"clothes"})
+	}
+	close(stop)
+	wg.Wait()
+
+	for _, call := range m.Calls() {
+		if call.Args[0].(context.Context) != ctx {
+			t.Fatal("expected the context to be recorded by reference")
+		}
+	}
+}
+
+func TestRegisterCloner(t *testing.T) {
+	type token struct{ ID int }
+	RegisterCloner(func(tok *token) *token { return tok })
+	defer UnregisterCloner[*token]()
+
+	tok := &token{ID: 1}
+	if Clone(tok).(*token) != tok {
+		t.Fatal("expected the registered cloner to be used")
+	}
+	UnregisterCloner[*token]()
+	if Clone(tok).(*token) == tok {
+		t.Fatal("expected reflection copy once the cloner is removed")
+	}
+}
+
+func TestRecordCallClonesArgs(t *testing.T) {
+	var m MethodConfig[func([]string) int]
+	m.EnableSpy()
+
+	items := []string{"clothes"}
+	m.RecordCall(items)
+	items[0] = "reused"
+	if got := m.Calls()[0].Args[0].([]string)[0]; got != "clothes" {
+		t.Fatalf("expected recorded args to be unaffected by later mutation, got %q", got)
+	}
+
+	m.DisableArgCloning()
+	m.RecordCall(items)
+	items[0] = "mutated"
+	if got := m.Calls()[1].Args[0].([]string)[0]; got != "mutated" {
+		t.Fatalf("expected args to be recorded by reference with cloning disabled, got %q", got)
+	}
+}
+
+func TestRecordResultsClonesOnlyWhenRecorded(t *testing.T) {
+	type payload struct{ N int }
+	copies := 0
+	RegisterCloner(func(p payload) payload { copies++; return p })
+	defer UnregisterCloner[payload]()
+
+	var m MethodConfig[func() payload]
+	m.RecordResults(m.RecordCall(), payload{N: 1})
+	if copies != 0 {
+		t.Fatalf("expected results not to be copied when nothing records them, got %d copies", copies)
+	}
+
+	m.EnableSpy()
+	m.RecordResults(m.RecordCall(), payload{N: 2})
+	if copies != 1 {
+		t.Fatalf("expected results to be copied once for the spy, got %d copies", copies)
+	}
+}
@@ -0,0 +1,148 @@
+package stubs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxOverlaps caps the overlapping call pairs kept per method, so heavy fan-out cannot grow memory without bound
+const maxOverlaps = 100
+
+// ConcurrencyStats describes how calls to a method overlapped in time
+type ConcurrencyStats struct {
+	// InFlight is the number of calls that have started and not yet returned or panicked
+	InFlight int
+	// MaxInFlight is the most calls that were in flight at once
+	MaxInFlight int
+	// Overlaps holds the first overlapping call pairs, in the order the later call of each pair started
+	Overlaps []CallOverlap
+	// OverlapCount is the number of overlapping call pairs, including those not kept in Overlaps
+	OverlapCount int
+}
+
+// CallOverlap is a pair of calls that were in flight at the same time.
+// Calls are identified by the numbers RecordCall gives them, in the order they started.
+type CallOverlap struct {
+	First      uint64
+	FirstArgs  []any
+	Second     uint64
+	SecondArgs []any
+}
+
+func (o CallOverlap) String() string {
+	return fmt.Sprintf("call %d%s overlapped call %d%s", o.Second, formatArgs(o.SecondArgs), o.First, formatArgs(o.FirstArgs))
+}
+
+// concurrencyTracker counts in-flight calls. Callers must hold the MethodConfig's lock.
+type concurrencyTracker struct {
+	inFlight     map[uint64][]any
+	max          int
+	overlaps     []CallOverlap
+	overlapCount int
+}
+
+func (c *concurrencyTracker) start(id uint64, args []any) {
+	if c.inFlight == nil {
+		c.inFlight = make(map[uint64][]any)
+	}
+	ids := make([]uint64, 0, len(c.inFlight))
+	for other := range c.inFlight {
+		ids = append(ids, other)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, other := range ids {
+		c.overlapCount++
+		if len(c.overlaps) < maxOverlaps {
+			c.overlaps = append(c.overlaps, CallOverlap{First: other, FirstArgs: c.inFlight[other], Second: id, SecondArgs: args})
+		}
+	}
+	c.inFlight[id] = args
+	if len(c.inFlight) > c.max {
+		c.max = len(c.inFlight)
+	}
+}
+
+func (c *concurrencyTracker) end(id uint64) {
+	delete(c.inFlight, id)
+}
+
+// reset clears the peak and overlaps, keeping calls that are still in flight
+func (c *concurrencyTracker) reset() {
+	c.max = len(c.inFlight)
+	c.overlaps = nil
+	c.overlapCount = 0
+}
+
+func (c *concurrencyTracker) stats() ConcurrencyStats {
+	return ConcurrencyStats{
+		InFlight:     len(c.inFlight),
+		MaxInFlight:  c.max,
+		Overlaps:     append([]CallOverlap(nil), c.overlaps...),
+		OverlapCount: c.overlapCount,
+	}
+}
+
+// EndCall marks the call with the given id, as returned by RecordCall, as no longer in flight.
+// Generated mocks defer it so calls that panic are counted as finished too.
+func (m *MethodConfig[T]) EndCall(id uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.concurrency.end(id)
+	if rec, ok := m.recordings[id]; ok {
+		delete(m.recordings, id)
+		rec.finish(m.getClock().Now())
+	}
+}
+
+// ConcurrencyStats returns the in-flight counts and overlapping calls recorded since the last reset
+func (m *MethodConfig[T]) ConcurrencyStats() ConcurrencyStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.concurrency.stats()
+}
+
+// ResetConcurrencyStats clears the peak and overlapping calls. Calls still in flight keep being counted.
+func (m *MethodConfig[T]) ResetConcurrencyStats() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.concurrency.reset()
+}
+
+// ConcurrencySource is anything that tracks overlapping calls, usually a MethodConfig
+type ConcurrencySource interface {
+	ConcurrencyStats() ConcurrencyStats
+}
+
+// AssertMaxConcurrency fails t if more than n calls were ever in flight at once, listing the overlapping calls
+func AssertMaxConcurrency(t TB, src ConcurrencySource, n int) {
+	t.Helper()
+	stats := src.ConcurrencyStats()
+	if stats.MaxInFlight <= n {
+		return
+	}
+	t.Fatalf("expected at most %d calls in flight at once, got %d\n%s", n, stats.MaxInFlight, describeOverlaps(stats))
+}
+
+// AssertNeverConcurrent fails t if any two calls were in flight at the same time, listing the overlapping calls
+func AssertNeverConcurrent(t TB, src ConcurrencySource) {
+	t.Helper()
+	stats := src.ConcurrencyStats()
+	if stats.OverlapCount == 0 {
+		return
+	}
+	t.Fatalf("expected calls never to overlap, got %d overlapping pairs\n%s", stats.OverlapCount, describeOverlaps(stats))
+}
+
+func describeOverlaps(stats ConcurrencyStats) string {
+	var b strings.Builder
+	b.WriteString("overlapping calls:")
+	for _, o := range stats.Overlaps {
+		b.WriteString("\n  ")
+		b.WriteString(o.String())
+	}
+	if more := stats.OverlapCount - len(stats.Overlaps); more > 0 {
+		fmt.Fprintf(&b, "\n  ... and %d more", more)
+	}
+	return b.String()
+}
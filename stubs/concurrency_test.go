@@ -0,0 +1,196 @@
+package stubs
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// These tests are most useful under the race detector: go test -race ./stubs
+
+// call mimics a generated override: record, roll faults, then answer from the mock or the real function
+func call(m *MethodConfig[func(int) (int, error)], v int) (int, error) {
+	defer m.EndCall(m.RecordCall(v))
+	if err := m.ApplyFault(); err != nil {
+		return 0, err
+	}
+	if m.IsEnabled() {
+		return m.NextResponse(func(v int) (int, error) { return v, nil })(v)
+	}
+	return v, nil
+}
+
+func TestMethodConfigConcurrentUseAndConfiguration(t *testing.T) {
+	var m MethodConfig[func(int) (int, error)]
+	seq := NewSequence()
+	stop := make(chan struct{})
+
+	var callers sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		callers.Add(1)
+		go func(i int) {
+			defer callers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_, _ = call(&m, i)
+				}
+			}
+		}(i)
+	}
+
+	for i := 0; i < 200; i++ {
+		switch i % 10 {
+		case 0:
+			m.Enable()
+		case 1:
+			m.EnableSpy()
+		case 2:
+			m.SetResponseFunc(func(v int) (int, error) { return v * 2, nil })
+		case 3:
+			m.EnqueueWithDelay(func(int) (int, error) { return 0, nil }, 0)
+		case 4:
+			m.SetResponseFuncTimes(func(int) (int, error) { return 1, nil }, 3)
+		case 5:
+			m.AttachSequence(seq, "Mock", "Method")
+		case 6:
+			m.SetFaults(FaultPolicy{Seed: int64(i), ErrorRate: 0.5, Err: errors.New("boom")})
+		case 7:
+			m.ClearFaults()
+			_ = m.PeekQueueLength()
+			_ = m.CallCount()
+		case 8:
+			m.DisableSpy()
+			m.ResetQueue()
+		case 9:
+			m.Disable()
+			m.SetClock(RealClock())
+			_ = m.Calls()
+		}
+	}
+
+	close(stop)
+	callers.Wait()
+	_ = seq.Calls()
+}
+
+func TestNextResponseDoesNotHoldLockWhileResponding(t *testing.T) {
+	var m MethodConfig[func() int]
+	m.Enable()
+	m.EnableSpy()
+	// a response that reconfigures the same method would deadlock if NextResponse held the lock
+	m.SetResponseFunc(func() int {
+		m.RecordCall()
+		m.SetResponseFunc(func() int { return 2 })
+		return len(m.Calls())
+	})
+
+	done := make(chan int, 1)
+	go func() {
+		done <- m.NextResponse(nil)()
+	}()
+	if got := WaitForResult(t, done, time.Second); got != 1 {
+		t.Fatalf("expected 1 recorded call, got %d", got)
+	}
+	if got := m.NextResponse(nil)(); got != 2 {
+		t.Fatalf("expected reconfigured response 2, got %d", got)
+	}
+}
+
+func TestRecordResultsMatchesCall(t *testing.T) {
+	var m MethodConfig[func(int) (int, error)]
+	m.EnableSpy()
+	first := m.RecordCall(1)
+	second := m.RecordCall(2)
+
+	changed := m.Changed()
+	m.RecordResults(second, 20, nil)
+	select {
+	case <-changed:
+	default:
+		t.Fatal("expected recording results to signal Changed")
+	}
+
+	calls := m.Calls()
+	if calls[0].Returned || calls[0].Results != nil {
+		t.Fatalf("expected first call to still be running, got %+v", calls[0])
+	}
+	if !calls[1].Returned || calls[1].Results[0] != 20 {
+		t.Fatalf("expected second call results (20, nil), got %+v", calls[1])
+	}
+
+	// results for a call cleared by Reset are ignored
+	m.Reset()
+	m.EnableSpy()
+	m.RecordCall(3)
+	m.RecordResults(first, 10, nil)
+	if m.Calls()[0].Returned {
+		t.Fatal("expected results for a cleared call to be ignored")
+	}
+}
+
+func TestConcurrencyStatsTrackPeakAndOverlaps(t *testing.T) {
+	var m MethodConfig[func(int) (int, error)]
+	gate := NewGate()
+	m.SetGate(gate)
+	m.Enable()
+	m.SetResponseFunc(func(v int) (int, error) { return v, nil })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			id := m.RecordCall(v)
+			defer m.EndCall(id)
+			m.PassGate()
+		}(i)
+	}
+	gate.WaitUntilEnteredWithin(t, 3, time.Second)
+	if stats := m.ConcurrencyStats(); stats.InFlight != 3 || stats.MaxInFlight != 3 {
+		t.Fatalf("expected 3 calls in flight, got %+v", stats)
+	}
+	gate.Release()
+	wg.Wait()
+
+	stats := m.ConcurrencyStats()
+	if stats.InFlight != 0 || stats.MaxInFlight != 3 || stats.OverlapCount != 3 || len(stats.Overlaps) != 3 {
+		t.Fatalf("expected a peak of 3 and 3 overlapping pairs, got %+v", stats)
+	}
+	AssertMaxConcurrency(t, &m, 3)
+
+	ft := &fakeT{}
+	AssertNeverConcurrent(ft, &m)
+	if !ft.failed || !strings.Contains(ft.msg, "got 3 overlapping pairs") || !strings.Contains(ft.msg, "overlapped call") {
+		t.Fatalf("expected the overlapping calls to be listed, got %q", ft.msg)
+	}
+	ft = &fakeT{}
+	AssertMaxConcurrency(ft, &m, 2)
+	if !ft.failed || !strings.Contains(ft.msg, "expected at most 2 calls in flight at once, got 3") {
+		t.Fatalf("expected a max concurrency failure, got %q", ft.msg)
+	}
+
+	m.Reset()
+	AssertNeverConcurrent(t, &m)
+}
+
+func TestConcurrencyStatsSequentialCallsAndPanics(t *testing.T) {
+	var m MethodConfig[func(int) (int, error)]
+	m.Enable()
+	m.EnqueuePanic("boom")
+	MustPanic(t, func() {
+		call(&m, 1)
+	})
+	call(&m, 2)
+	call(&m, 3)
+
+	stats := m.ConcurrencyStats()
+	if stats.InFlight != 0 || stats.MaxInFlight != 1 {
+		t.Fatalf("expected panicking calls to end and sequential calls never to overlap, got %+v", stats)
+	}
+	AssertNeverConcurrent(t, &m)
+}
@@ -0,0 +1,417 @@
+package stubs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CoverageEnv names the environment variable holding the directory mock usage reports are written to.
+// Reporting is off when it is unset.
+const CoverageEnv = "STUBS_COVERAGE"
+
+// CoverageRunEnv names the environment variable identifying the test run a report belongs to. When it is
+// unset, the run is the process that started the test binary, i.e. one go test invocation.
+const CoverageRunEnv = "STUBS_COVERAGE_RUN"
+
+// CoverageReport describes how the mocks of each interface were used across a test run
+type CoverageReport struct {
+	// Run identifies the test run the report was written in, see CoverageRunEnv
+	Run string `json:"run,omitempty"`
+	// Binaries lists the test binaries the report was gathered from
+	Binaries   []string            `json:"binaries,omitempty"`
+	Interfaces []InterfaceCoverage `json:"interfaces"`
+}
+
+// InterfaceCoverage holds the usage of each method of an interface, sorted by method name
+type InterfaceCoverage struct {
+	// Name is the package-qualified interface name, e.g. vehicle.Vehicle
+	Name    string           `json:"name"`
+	Methods []MethodCoverage `json:"methods"`
+}
+
+// MethodCoverage counts how a method's mocks were configured and called
+type MethodCoverage struct {
+	Name string `json:"name"`
+	// Mocks is the number of mocks built for the interface
+	Mocks int `json:"mocks"`
+	// Calls counts every call, whether answered by the mock or the real implementation
+	Calls int `json:"calls"`
+	// MockedCalls counts the calls made while the mock was enabled
+	MockedCalls int `json:"mockedCalls"`
+	// Enables counts the times the mock was turned on
+	Enables int `json:"enables"`
+	// UnusedEnables counts the times the mock was turned on and then off, reset or left on with no call in between
+	UnusedEnables int `json:"unusedEnables"`
+	// QueuedResponses counts the responses and panics enqueued
+	QueuedResponses int `json:"queuedResponses"`
+	// UnusedResponses counts the queued responses that were cleared, reset or left over without being consumed
+	UnusedResponses int `json:"unusedResponses"`
+}
+
+// Exercised reports whether the method was called at all
+func (c MethodCoverage) Exercised() bool {
+	return c.Calls > 0
+}
+
+// Unused describes configuration that was set up and never used, or returns nil
+func (c MethodCoverage) Unused() []string {
+	var out []string
+	if c.UnusedResponses > 0 {
+		out = append(out, fmt.Sprintf("%d of %d queued responses never consumed", c.UnusedResponses, c.QueuedResponses))
+	}
+	if c.UnusedEnables > 0 {
+		out = append(out, fmt.Sprintf("%d of %d enables not followed by a call", c.UnusedEnables, c.Enables))
+	}
+	return out
+}
+
+func (c *MethodCoverage) add(o MethodCoverage) {
+	c.Mocks += o.Mocks
+	c.Calls += o.Calls
+	c.MockedCalls += o.MockedCalls
+	c.Enables += o.Enables
+	c.UnusedEnables += o.UnusedEnables
+	c.QueuedResponses += o.QueuedResponses
+	c.UnusedResponses += o.UnusedResponses
+}
+
+// ReadCoverageReport reads a JSON report written by WriteCoverage
+func ReadCoverageReport(path string) (*CoverageReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r CoverageReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("invalid coverage report %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// MergeCoverageReports adds up reports from several test binaries. The merged report keeps the run
+// of its reports if they all share one.
+func MergeCoverageReports(reports ...*CoverageReport) *CoverageReport {
+	merged := &CoverageReport{}
+	for i, r := range reports {
+		if i == 0 {
+			merged.Run = r.Run
+		} else if merged.Run != r.Run {
+			merged.Run = ""
+		}
+		merged.Binaries = append(merged.Binaries, r.Binaries...)
+		for _, iface := range r.Interfaces {
+			for _, method := range iface.Methods {
+				merged.method(iface.Name, method.Name).add(method)
+			}
+		}
+	}
+	sort.Strings(merged.Binaries)
+	return merged
+}
+
+// Include adds any of the given methods missing from the report with zero usage, so methods of
+// interfaces that were never mocked are listed too
+func (r *CoverageReport) Include(iface string, methods ...string) {
+	for _, name := range methods {
+		r.method(iface, name)
+	}
+}
+
+// method returns the entry for iface.name, adding it in sorted position if missing
+func (r *CoverageReport) method(iface, name string) *MethodCoverage {
+	i := sort.Search(len(r.Interfaces), func(i int) bool { return r.Interfaces[i].Name >= iface })
+	if i == len(r.Interfaces) || r.Interfaces[i].Name != iface {
+		r.Interfaces = append(r.Interfaces, InterfaceCoverage{})
+		copy(r.Interfaces[i+1:], r.Interfaces[i:])
+		r.Interfaces[i] = InterfaceCoverage{Name: iface}
+	}
+	methods := &r.Interfaces[i].Methods
+	j := sort.Search(len(*methods), func(j int) bool { return (*methods)[j].Name >= name })
+	if j == len(*methods) || (*methods)[j].Name != name {
+		*methods = append(*methods, MethodCoverage{})
+		copy((*methods)[j+1:], (*methods)[j:])
+		(*methods)[j] = MethodCoverage{Name: name}
+	}
+	return &(*methods)[j]
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *CoverageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteHTML writes the report as a standalone HTML page
+func (r *CoverageReport) WriteHTML(w io.Writer) error {
+	return coverageHTML.Execute(w, r)
+}
+
+var coverageHTML = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Mock coverage</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: right; }
+th:first-child, td:first-child, td:last-child { text-align: left; }
+tr.never td { background: #fdd; }
+tr.unused td:last-child { background: #ffd; }
+</style>
+</head>
+<body>
+<h1>Mock coverage</h1>
+{{with .Binaries}}<p>From {{range $i, $b := .}}{{if $i}}, {{end}}{{$b}}{{end}}</p>{{end}}
+{{range .Interfaces}}
+<h2>{{.Name}}</h2>
+<table>
+<tr><th>Method</th><th>Mocks</th><th>Calls</th><th>Mocked calls</th><th>Enables</th><th>Queued responses</th><th>Unused configuration</th></tr>
+{{range .Methods}}<tr class="{{if not .Exercised}}never{{else if .Unused}}unused{{end}}">
+<td>{{.Name}}</td><td>{{.Mocks}}</td><td>{{.Calls}}</td><td>{{.MockedCalls}}</td><td>{{.Enables}}</td><td>{{.QueuedResponses}}</td>
+<td>{{if not .Exercised}}never called{{if .Unused}}; {{end}}{{end}}{{range $i, $u := .Unused}}{{if $i}}; {{end}}{{$u}}{{end}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// methodUsage counts the usage of one interface method across every mock built for it
+type methodUsage struct {
+	mocks           atomic.Int64
+	calls           atomic.Int64
+	mockedCalls     atomic.Int64
+	enables         atomic.Int64
+	unusedEnables   atomic.Int64
+	queuedResponses atomic.Int64
+	unusedResponses atomic.Int64
+}
+
+// usageProbe reports the configuration a tracked MethodConfig holds that has not been used yet
+type usageProbe struct {
+	usage *methodUsage
+	probe func() (pendingResponses int, unusedEnable bool)
+}
+
+type coverageKey struct {
+	iface, method string
+}
+
+// coverageRegistry gathers usage from every tracked MethodConfig in the process. Configs are probed
+// until FinishCoverage, so configuration left over when the report is written is counted too.
+type coverageRegistry struct {
+	mu      sync.Mutex
+	methods map[coverageKey]*methodUsage
+	live    map[*usageProbe]struct{}
+}
+
+var coverage = &coverageRegistry{methods: map[coverageKey]*methodUsage{}}
+
+func (r *coverageRegistry) track(iface, method string, probe func() (int, bool)) *usageProbe {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := coverageKey{iface, method}
+	u := r.methods[key]
+	if u == nil {
+		u = &methodUsage{}
+		r.methods[key] = u
+	}
+	u.mocks.Add(1)
+	p := &usageProbe{usage: u, probe: probe}
+	if r.live == nil {
+		r.live = map[*usageProbe]struct{}{}
+	}
+	r.live[p] = struct{}{}
+	return p
+}
+
+// untrack stops probing p, dropping the registry's reference to its config
+func (r *coverageRegistry) untrack(p *usageProbe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.live, p)
+}
+
+// report adds up the counters and the configuration still unused in live configs
+func (r *coverageRegistry) report() *CoverageReport {
+	r.mu.Lock()
+	methods := make(map[coverageKey]*methodUsage, len(r.methods))
+	for k, u := range r.methods {
+		methods[k] = u
+	}
+	live := make([]*usageProbe, 0, len(r.live))
+	for p := range r.live {
+		live = append(live, p)
+	}
+	r.mu.Unlock()
+
+	// probes take each config's lock, so they run without holding the registry's
+	pending := map[*methodUsage]MethodCoverage{}
+	for _, p := range live {
+		responses, enable := p.probe()
+		c := pending[p.usage]
+		c.UnusedResponses += responses
+		if enable {
+			c.UnusedEnables++
+		}
+		pending[p.usage] = c
+	}
+
+	report := &CoverageReport{Run: coverageRun(), Binaries: []string{binaryName()}}
+	for k, u := range methods {
+		c := report.method(k.iface, k.method)
+		c.add(MethodCoverage{
+			Mocks:           int(u.mocks.Load()),
+			Calls:           int(u.calls.Load()),
+			MockedCalls:     int(u.mockedCalls.Load()),
+			Enables:         int(u.enables.Load()),
+			UnusedEnables:   int(u.unusedEnables.Load()),
+			QueuedResponses: int(u.queuedResponses.Load()),
+			UnusedResponses: int(u.unusedResponses.Load()),
+		})
+		c.add(pending[u])
+	}
+	return report
+}
+
+// coverageRun returns the run reports are written in: STUBS_COVERAGE_RUN, or else the parent process,
+// which go test shares between the binaries it starts
+func coverageRun() string {
+	if run := os.Getenv(CoverageRunEnv); run != "" {
+		return run
+	}
+	return fmt.Sprintf("ppid-%d", os.Getppid())
+}
+
+// binaryName returns the name of the running test binary, e.g. driver.test
+func binaryName() string {
+	return strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+}
+
+// WriteCoverage writes the mock usage gathered by the process to the directory named by STUBS_COVERAGE,
+// as <binary>-<pid>.json and <binary>-<pid>.html. It does nothing when the variable is unset. Call it
+// once from TestMain after m.Run.
+func WriteCoverage() error {
+	dir := os.Getenv(CoverageEnv)
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	report := coverage.report()
+	base := filepath.Join(dir, fmt.Sprintf("%s-%d", binaryName(), os.Getpid()))
+	if err := writeFileAtomic(base+".json", report.WriteJSON); err != nil {
+		return err
+	}
+	return writeFileAtomic(base+".html", report.WriteHTML)
+}
+
+// writeFileAtomic replaces path, so a reader never sees a report half written
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// TrackCoverage counts the method's usage under iface.method in the report written by WriteCoverage.
+// It does nothing when STUBS_COVERAGE is unset. Generated constructors call it for every method.
+func (m *MethodConfig[T]) TrackCoverage(iface, method string) {
+	if os.Getenv(CoverageEnv) == "" {
+		return
+	}
+	p := coverage.track(iface, method, m.unusedConfig)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage = p.usage
+	m.probe = p
+}
+
+// FinishCoverage counts the configuration the method still holds as unused and stops tracking it, so the
+// report no longer keeps the method alive. Mocks built with new<Interface>MockForTest call it when the
+// test finishes.
+func (m *MethodConfig[T]) FinishCoverage() {
+	m.mu.Lock()
+	p := m.probe
+	if m.usage != nil {
+		m.countDiscarded(len(m.queue))
+		if m.enabled && !m.calledSinceEnable {
+			m.usage.unusedEnables.Add(1)
+		}
+	}
+	m.usage = nil
+	m.probe = nil
+	m.mu.Unlock()
+	if p != nil {
+		coverage.untrack(p)
+	}
+}
+
+// unusedConfig returns the number of queued responses and whether the mock is enabled with no call yet
+func (m *MethodConfig[T]) unusedConfig() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queue), m.enabled && !m.calledSinceEnable
+}
+
+// The methods below count usage. Callers must hold m.mu; they do nothing for untracked configs.
+
+func (m *MethodConfig[T]) countEnable(enabled bool) {
+	switch {
+	case enabled && !m.enabled:
+		m.calledSinceEnable = false
+		if m.usage != nil {
+			m.usage.enables.Add(1)
+		}
+	case !enabled && m.enabled && !m.calledSinceEnable:
+		if m.usage != nil {
+			m.usage.unusedEnables.Add(1)
+		}
+	}
+}
+
+func (m *MethodConfig[T]) countCall() {
+	if m.enabled {
+		m.calledSinceEnable = true
+	}
+	if m.usage == nil {
+		return
+	}
+	m.usage.calls.Add(1)
+	if m.enabled {
+		m.usage.mockedCalls.Add(1)
+	}
+}
+
+func (m *MethodConfig[T]) countQueued(n int) {
+	if m.usage != nil {
+		m.usage.queuedResponses.Add(int64(n))
+	}
+}
+
+func (m *MethodConfig[T]) countDiscarded(n int) {
+	if m.usage != nil {
+		m.usage.unusedResponses.Add(int64(n))
+	}
+}
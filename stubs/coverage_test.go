@@ -0,0 +1,157 @@
+package stubs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useCoverage points STUBS_COVERAGE at a temporary directory and gives the test its own registry
+func useCoverage(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv(CoverageEnv, dir)
+	old := coverage
+	coverage = &coverageRegistry{methods: map[coverageKey]*methodUsage{}}
+	t.Cleanup(func() { coverage = old })
+	return dir
+}
+
+func methodCoverage(t *testing.T, r *CoverageReport, iface, method string) MethodCoverage {
+	t.Helper()
+	for _, i := range r.Interfaces {
+		for _, m := range i.Methods {
+			if i.Name == iface && m.Name == method {
+				return m
+			}
+		}
+	}
+	t.Fatalf("no coverage for %s.%s in %+v", iface, method, r)
+	return MethodCoverage{}
+}
+
+func TestCoverageCountsUnusedConfiguration(t *testing.T) {
+	useCoverage(t)
+	var load, honk MethodConfig[func() int]
+	load.TrackCoverage("vehicle.Vehicle", "LoadCargo")
+	honk.TrackCoverage("vehicle.Vehicle", "Honk")
+
+	load.Enable()
+	load.SetResponseFuncTimes(func() int { return 1 }, 3)
+	load.RecordCall()
+	load.NextResponse(nil)
+	load.Reset()
+
+	load.Enable()
+	load.Disable()
+	load.RecordCall()
+
+	honk.Enable()
+	honk.EnqueuePanic("boom")
+
+	r := coverage.report()
+	got := methodCoverage(t, r, "vehicle.Vehicle", "LoadCargo")
+	want := MethodCoverage{Name: "LoadCargo", Mocks: 1, Calls: 2, MockedCalls: 1, Enables: 2, UnusedEnables: 1, QueuedResponses: 3, UnusedResponses: 2}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if unused := got.Unused(); len(unused) != 2 || unused[0] != "2 of 3 queued responses never consumed" {
+		t.Fatalf("unexpected unused description %q", unused)
+	}
+
+	// configuration still held when the report is written counts as unused
+	got = methodCoverage(t, r, "vehicle.Vehicle", "Honk")
+	if got.Exercised() || got.UnusedEnables != 1 || got.UnusedResponses != 1 {
+		t.Fatalf("expected leftover configuration to be reported, got %+v", got)
+	}
+}
+
+func TestFinishCoverageStopsTracking(t *testing.T) {
+	useCoverage(t)
+	var m MethodConfig[func() int]
+	m.TrackCoverage("vehicle.Vehicle", "LoadCargo")
+	m.Enable()
+	m.SetResponseFuncTimes(func() int { return 1 }, 2)
+
+	m.FinishCoverage()
+	if n := len(coverage.live); n != 0 {
+		t.Fatalf("expected the finished config to be dropped from the registry, %d left", n)
+	}
+	// configuration left when coverage finished counts as unused once, even if the mock is reset later
+	m.Reset()
+	m.RecordCall()
+	got := methodCoverage(t, coverage.report(), "vehicle.Vehicle", "LoadCargo")
+	want := MethodCoverage{Name: "LoadCargo", Mocks: 1, Enables: 1, UnusedEnables: 1, QueuedResponses: 2, UnusedResponses: 2}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestCoverageOffWithoutEnv(t *testing.T) {
+	useCoverage(t)
+	t.Setenv(CoverageEnv, "")
+	var m MethodConfig[func()]
+	m.TrackCoverage("vehicle.Vehicle", "Honk")
+	m.RecordCall()
+	if r := coverage.report(); len(r.Interfaces) != 0 {
+		t.Fatalf("expected nothing tracked, got %+v", r.Interfaces)
+	}
+	if err := WriteCoverage(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteCoverage(t *testing.T) {
+	dir := useCoverage(t)
+	var m MethodConfig[func()]
+	m.TrackCoverage("vehicle.Vehicle", "Honk")
+	m.RecordCall()
+
+	t.Setenv(CoverageRunEnv, "run-1")
+	if err := WriteCoverage(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(paths) != 1 {
+		t.Fatalf("expected one JSON report, got %v", paths)
+	}
+	r, err := ReadCoverageReport(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := methodCoverage(t, r, "vehicle.Vehicle", "Honk"); got.Calls != 1 {
+		t.Fatalf("expected 1 call, got %+v", got)
+	}
+	if r.Run != "run-1" {
+		t.Fatalf("expected the report to name its run, got %q", r.Run)
+	}
+	html, err := os.ReadFile(strings.TrimSuffix(paths[0], ".json") + ".html")
+	if err != nil || !strings.Contains(string(html), "<td>Honk</td>") {
+		t.Fatalf("expected an HTML report listing Honk, got %v:\n%s", err, html)
+	}
+}
+
+func TestMergeCoverageReports(t *testing.T) {
+	a := &CoverageReport{Run: "run-1", Binaries: []string{"b.test"}}
+	a.method("vehicle.Vehicle", "Honk").add(MethodCoverage{Mocks: 1, Calls: 2})
+	b := &CoverageReport{Run: "run-1", Binaries: []string{"a.test"}}
+	b.method("vehicle.Vehicle", "Honk").add(MethodCoverage{Mocks: 1, Calls: 1, QueuedResponses: 1, UnusedResponses: 1})
+	b.method("vehicle.SelfDriving", "DriveSelf").add(MethodCoverage{Mocks: 1})
+
+	merged := MergeCoverageReports(a, b)
+	merged.Include("vehicle.Vehicle", "Honk", "Turn")
+	if strings.Join(merged.Binaries, ",") != "a.test,b.test" || merged.Run != "run-1" {
+		t.Fatalf("unexpected binaries %v of run %q", merged.Binaries, merged.Run)
+	}
+	if len(merged.Interfaces) != 2 || merged.Interfaces[0].Name != "vehicle.SelfDriving" {
+		t.Fatalf("expected interfaces sorted by name, got %+v", merged.Interfaces)
+	}
+	honk := methodCoverage(t, merged, "vehicle.Vehicle", "Honk")
+	if honk.Mocks != 2 || honk.Calls != 3 || honk.UnusedResponses != 1 {
+		t.Fatalf("expected counts to be added up, got %+v", honk)
+	}
+	if turn := methodCoverage(t, merged, "vehicle.Vehicle", "Turn"); turn.Exercised() {
+		t.Fatalf("expected an included method with no usage, got %+v", turn)
+	}
+}
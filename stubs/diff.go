@@ -0,0 +1,277 @@
+package stubs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// maxReportedDifferences caps how many differences Diff.String prints
+const maxReportedDifferences = 20
+
+// Difference is a single mismatch between an expected and an actual value
+type Difference struct {
+	// Path locates the mismatch, e.g. args[0].Items[2] or args[1]["speed"]
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "value"
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", path, d.Expected, d.Actual)
+}
+
+// Diff lists every mismatch between two values. It is empty when they are reflect.DeepEqual.
+type Diff []Difference
+
+// Equal reports whether no differences were found
+func (d Diff) Equal() bool {
+	return len(d) == 0
+}
+
+// String renders one difference per line, indented by two spaces
+func (d Diff) String() string {
+	if len(d) == 0 {
+		return "  (no differences)"
+	}
+	shown := d
+	if len(shown) > maxReportedDifferences {
+		shown = shown[:maxReportedDifferences]
+	}
+	lines := make([]string, 0, len(shown)+1)
+	for _, diff := range shown {
+		lines = append(lines, "  "+diff.String())
+	}
+	if len(d) > len(shown) {
+		lines = append(lines, fmt.Sprintf("  ... and %d more", len(d)-len(shown)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffValues compares expected and actual the way reflect.DeepEqual does and reports where they differ.
+// Paths are relative to the values, so a mismatch at the top level has an empty path.
+func DiffValues(expected, actual any) Diff {
+	d := &differ{visited: map[visitPair]bool{}}
+	d.compare("", reflect.ValueOf(expected), reflect.ValueOf(actual))
+	return d.diffs
+}
+
+// DiffArgs compares expected arguments with the arguments of a call, labelling each as args[i]
+func DiffArgs(expected, actual []any) Diff {
+	d := &differ{visited: map[visitPair]bool{}}
+	if len(expected) != len(actual) {
+		d.add("args", fmt.Sprintf("%d args", len(expected)), fmt.Sprintf("%d args", len(actual)))
+	}
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		d.compare(fmt.Sprintf("args[%d]", i), reflect.ValueOf(expected[i]), reflect.ValueOf(actual[i]))
+	}
+	return d.diffs
+}
+
+// DiffArgs reports where the call's arguments differ from expected
+func (m *MethodCall) DiffArgs(expected ...any) Diff {
+	return DiffArgs(expected, m.Args)
+}
+
+// visitPair marks a pair of references already being compared, so cycles terminate
+type visitPair struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type differ struct {
+	diffs   Diff
+	visited map[visitPair]bool
+}
+
+func (d *differ) add(path, expected, actual string) {
+	d.diffs = append(d.diffs, Difference{Path: path, Expected: expected, Actual: actual})
+}
+
+func (d *differ) mismatch(path string, expected, actual reflect.Value) {
+	d.add(path, formatValue(expected), formatValue(actual))
+}
+
+// compare walks expected and actual together. Neither may have been obtained through an unexported
+// field; struct fields are read through addressable copies instead.
+func (d *differ) compare(path string, expected, actual reflect.Value) {
+	if !expected.IsValid() || !actual.IsValid() {
+		if expected.IsValid() != actual.IsValid() {
+			d.mismatch(path, expected, actual)
+		}
+		return
+	}
+	if expected.Type() != actual.Type() {
+		d.add(path, formatTyped(expected), formatTyped(actual))
+		return
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				d.mismatch(path, expected, actual)
+			}
+			return
+		}
+		if expected.Kind() != reflect.Slice && expected.Pointer() == actual.Pointer() {
+			return
+		}
+		key := visitPair{a: expected.Pointer(), b: actual.Pointer(), typ: expected.Type()}
+		if d.visited[key] {
+			return
+		}
+		d.visited[key] = true
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if expected.Kind() == reflect.Interface && (expected.IsNil() || actual.IsNil()) {
+			if expected.IsNil() != actual.IsNil() {
+				d.mismatch(path, expected, actual)
+			}
+			return
+		}
+		d.compare(path, expected.Elem(), actual.Elem())
+
+	case reflect.Slice, reflect.Array:
+		n := expected.Len()
+		if actual.Len() > n {
+			n = actual.Len()
+		}
+		for i := 0; i < n; i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= actual.Len():
+				d.add(elemPath, formatValue(expected.Index(i)), "<missing>")
+			case i >= expected.Len():
+				d.add(elemPath, "<missing>", formatValue(actual.Index(i)))
+			default:
+				d.compare(elemPath, expected.Index(i), actual.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		d.compareMaps(path, expected, actual)
+
+	case reflect.Struct:
+		e, a := addressable(expected), addressable(actual)
+		for i := 0; i < e.NumField(); i++ {
+			d.compare(path+"."+e.Type().Field(i).Name, settable(e.Field(i)), settable(a.Field(i)))
+		}
+
+	case reflect.Func:
+		// as with reflect.DeepEqual, funcs are only equal when both are nil
+		if !expected.IsNil() || !actual.IsNil() {
+			d.mismatch(path, expected, actual)
+		}
+
+	case reflect.Chan, reflect.UnsafePointer:
+		if expected.Pointer() != actual.Pointer() {
+			d.mismatch(path, expected, actual)
+		}
+
+	default:
+		if !expected.Equal(actual) {
+			d.mismatch(path, expected, actual)
+		}
+	}
+}
+
+func (d *differ) compareMaps(path string, expected, actual reflect.Value) {
+	type entry struct {
+		label string
+		key   reflect.Value
+	}
+	var entries []entry
+	for _, k := range expected.MapKeys() {
+		entries = append(entries, entry{label: formatValue(k), key: k})
+	}
+	for _, k := range actual.MapKeys() {
+		if !expected.MapIndex(k).IsValid() {
+			entries = append(entries, entry{label: formatValue(k), key: k})
+		}
+	}
+	// map iteration order is random, so sort for stable output
+	sort.Slice(entries, func(i, j int) bool { return entries[i].label < entries[j].label })
+
+	for _, e := range entries {
+		keyPath := fmt.Sprintf("%s[%s]", path, e.label)
+		ev, av := expected.MapIndex(e.key), actual.MapIndex(e.key)
+		switch {
+		case !av.IsValid():
+			d.add(keyPath, formatValue(ev), "<missing>")
+		case !ev.IsValid():
+			d.add(keyPath, "<missing>", formatValue(av))
+		default:
+			d.compare(keyPath, ev, av)
+		}
+	}
+}
+
+// addressable returns an addressable copy of a struct so its unexported fields can be read
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	if v.Kind() == reflect.Func {
+		if v.IsNil() {
+			return "nil func"
+		}
+		return "non-nil func"
+	}
+	return fmt.Sprintf("%#v", v.Interface())
+}
+
+func formatTyped(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%s (%s)", formatValue(v), v.Type())
+}
+
+// formatArgs renders args as a call argument list, e.g. ("a", 2)
+func formatArgs(args []any) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%#v", arg)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// describeArgMismatch explains how each recorded call differs from the expected args
+func describeArgMismatch(expected []any, calls []MethodCall) string {
+	if len(calls) == 0 {
+		return "recorded calls:\n  (none)"
+	}
+	lines := []string{"recorded calls:"}
+	for i, call := range calls {
+		lines = append(lines, fmt.Sprintf("  call %d %s:", i+1, formatArgs(call.Args)))
+		diff := call.DiffArgs(expected...)
+		lines = append(lines, "  "+strings.ReplaceAll(diff.String(), "\n", "\n  "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// settable returns an addressable field with the read-only flag of unexported fields removed
+func settable(f reflect.Value) reflect.Value {
+	if f.CanSet() {
+		return f
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
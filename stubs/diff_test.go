@@ -0,0 +1,116 @@
+package stubs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type diffCargo struct {
+	Items  []string
+	Weight map[string]int
+	owner  string
+	next   *diffCargo
+}
+
+func TestDiffValuesReportsPaths(t *testing.T) {
+	expected := diffCargo{Items: []string{"a", "b"}, Weight: map[string]int{"a": 1, "b": 2}, owner: "jo"}
+	actual := diffCargo{Items: []string{"a", "c", "d"}, Weight: map[string]int{"a": 1, "c": 3}, owner: "al"}
+
+	var got []string
+	for _, d := range DiffValues(expected, actual) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`.Items[1]: expected "b", got "c"`,
+		`.Items[2]: expected <missing>, got "d"`,
+		`.Weight["b"]: expected 2, got <missing>`,
+		`.Weight["c"]: expected <missing>, got 3`,
+		`.owner: expected "jo", got "al"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDiffValuesAgreesWithDeepEqual(t *testing.T) {
+	a := &diffCargo{owner: "x"}
+	a.next = a
+	b := &diffCargo{owner: "x"}
+	b.next = b
+
+	tests := []struct {
+		name     string
+		expected any
+		actual   any
+	}{
+		{name: "equal cycles", expected: a, actual: b},
+		{name: "nil and empty slice", expected: []int(nil), actual: []int{}},
+		{name: "different types", expected: int64(1), actual: 1},
+		{name: "nil interface", expected: nil, actual: 0},
+		{name: "equal maps", expected: map[string][]int{"k": {1}}, actual: map[string][]int{"k": {1}}},
+		{name: "funcs", expected: func() {}, actual: func() {}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffValues(tt.expected, tt.actual)
+			if diff.Equal() != reflect.DeepEqual(tt.expected, tt.actual) {
+				t.Fatalf("DiffValues disagrees with reflect.DeepEqual:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffArgsLabelsArguments(t *testing.T) {
+	call := MethodCall{Args: []any{50, "mph"}}
+	diff := call.DiffArgs(50, "kph")
+	if len(diff) != 1 || diff[0].Path != "args[1]" {
+		t.Fatalf("expected one difference at args[1], got:\n%s", diff)
+	}
+	if diff := call.DiffArgs(50); diff[0].Path != "args" {
+		t.Fatalf("expected an argument count difference, got:\n%s", diff)
+	}
+}
+
+func TestMapsEqual(t *testing.T) {
+	a := reflect.ValueOf(map[string][]int{"k": {1, 2}})
+	if !MapsEqual(a, reflect.ValueOf(map[string][]int{"k": {1, 2}})) {
+		t.Fatal("expected equal maps")
+	}
+	if MapsEqual(a, reflect.ValueOf(map[string][]int{"k": {1, 3}})) {
+		t.Fatal("expected maps with different values to differ")
+	}
+	if MapsEqual(a, reflect.ValueOf(map[string][]int{"j": {1, 2}})) {
+		t.Fatal("expected maps with different keys to differ")
+	}
+}
+
+func TestWaitForSpyCallArgsEqualReportsDiff(t *testing.T) {
+	var m MethodConfig[func([]string)]
+	m.EnableSpy()
+	m.RecordCall([]string{"clothes", "toys"})
+
+	ft := &fakeT{}
+	WaitForSpyCallArgsEqual(ft, &m, 20*time.Millisecond, []string{"clothes", "tools"})
+	if !ft.failed || !strings.Contains(ft.msg, `args[0][1]: expected "tools", got "toys"`) {
+		t.Fatalf("expected a structured diff in the failure, got %q", ft.msg)
+	}
+}
+
+func TestWaitForCallWithArgs(t *testing.T) {
+	var m MethodConfig[func(int)]
+	m.EnableSpy()
+	go func() {
+		m.RecordCall(1)
+		m.RecordCall(2)
+	}()
+	call := WaitForCallWithArgs(t, &m, time.Second, 2)
+	AssertArgs(t, call, 2)
+
+	ft := &fakeT{}
+	WaitForCallWithArgs(ft, &m, 10*time.Millisecond, 3)
+	if !strings.Contains(ft.msg, "call 1 (1):") || !strings.Contains(ft.msg, "args[0]: expected 3, got 2") {
+		t.Fatalf("expected every recorded call to be diffed, got %q", ft.msg)
+	}
+}
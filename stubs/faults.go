@@ -0,0 +1,131 @@
+package stubs
+
+import (
+	"math/rand"
+	"time"
+)
+
+// FaultPolicy describes faults injected into calls to a mocked method.
+// Rolls are drawn from a generator seeded with Seed, so a failing run can be reproduced by reusing its seed.
+type FaultPolicy struct {
+	Seed int64
+
+	// ErrorRate is the fraction of calls (0 to 1) that return Err in the method's error output
+	ErrorRate float64
+	Err       error
+
+	// PanicRate is the fraction of calls (0 to 1) that panic with PanicValue
+	PanicRate  float64
+	PanicValue any
+
+	// Each call is delayed by a random duration in [MinLatency, MaxLatency]
+	MinLatency time.Duration
+	MaxLatency time.Duration
+}
+
+// Fault is the outcome of rolling a FaultPolicy for a single call
+type Fault struct {
+	Delay      time.Duration
+	Panic      bool
+	PanicValue any
+	Err        error
+}
+
+type faultInjector struct {
+	policy FaultPolicy
+	rng    *rand.Rand
+}
+
+// roll draws the same number of values on every call so outcomes depend only on the seed and call index
+func (f *faultInjector) roll() Fault {
+	p := f.policy
+	latencyRoll := f.rng.Int63()
+	panicRoll := f.rng.Float64()
+	errRoll := f.rng.Float64()
+
+	var fault Fault
+	if p.MaxLatency > p.MinLatency {
+		fault.Delay = p.MinLatency + time.Duration(latencyRoll%int64(p.MaxLatency-p.MinLatency+1))
+	} else {
+		fault.Delay = p.MinLatency
+	}
+	if panicRoll < p.PanicRate {
+		fault.Panic = true
+		fault.PanicValue = p.PanicValue
+		return fault
+	}
+	if errRoll < p.ErrorRate {
+		fault.Err = p.Err
+	}
+	return fault
+}
+
+// panicResponse marks a queued or fallback response that panics instead of returning
+type panicResponse struct {
+	value any
+}
+
+// SetFaults applies a seeded fault policy to every call of the method
+func (m *MethodConfig[T]) SetFaults(policy FaultPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.faults = &faultInjector{policy: policy, rng: rand.New(rand.NewSource(policy.Seed))}
+}
+
+// ClearFaults removes any fault policy
+func (m *MethodConfig[T]) ClearFaults() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.faults = nil
+}
+
+// NextFault rolls the fault policy for the next call. It returns the zero Fault if no policy is set.
+func (m *MethodConfig[T]) NextFault() Fault {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.nextFault()
+}
+
+// nextFault rolls the fault policy. Callers must hold m.mu.
+func (m *MethodConfig[T]) nextFault() Fault {
+	if m.faults == nil {
+		return Fault{}
+	}
+	return m.faults.roll()
+}
+
+// ApplyFault rolls the fault policy, sleeps for any injected latency and panics if a panic is injected.
+// It returns the injected error, or nil if the call should proceed normally.
+func (m *MethodConfig[T]) ApplyFault() error {
+	m.mu.Lock()
+	fault := m.nextFault()
+	clock := m.getClock()
+	m.mu.Unlock()
+
+	if fault.Delay > 0 {
+		clock.Sleep(fault.Delay)
+	}
+	if fault.Panic {
+		panic(fault.PanicValue)
+	}
+	return fault.Err
+}
+
+// SetPanicResponse makes the Fallback panic with v
+func (m *MethodConfig[T]) SetPanicResponse(v any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.fallback = panicResponse{value: v}
+}
+
+// EnqueuePanic enqueues a response that panics with v
+func (m *MethodConfig[T]) EnqueuePanic(v any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.countQueued(1)
+	m.queue = append(m.queue, QueuedItem[T]{panics: true, panicValue: v})
+}
@@ -0,0 +1,63 @@
+package stubs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFaultPolicySeedIsDeterministic(t *testing.T) {
+	policy := FaultPolicy{
+		Seed:       42,
+		ErrorRate:  0.3,
+		Err:        errors.New("boom"),
+		PanicRate:  0.1,
+		PanicValue: "panic",
+		MinLatency: time.Millisecond,
+		MaxLatency: 5 * time.Millisecond,
+	}
+
+	var a, b MethodConfig[func() error]
+	a.SetFaults(policy)
+	b.SetFaults(policy)
+
+	var errs, panics int
+	for i := 0; i < 200; i++ {
+		fa, fb := a.NextFault(), b.NextFault()
+		if fa != fb {
+			t.Fatalf("call %d: faults differ for the same seed: %+v vs %+v", i, fa, fb)
+		}
+		if fa.Delay < policy.MinLatency || fa.Delay > policy.MaxLatency {
+			t.Fatalf("call %d: delay %s outside [%s, %s]", i, fa.Delay, policy.MinLatency, policy.MaxLatency)
+		}
+		if fa.Panic {
+			panics++
+		} else if fa.Err != nil {
+			errs++
+		}
+	}
+	if errs == 0 || panics == 0 {
+		t.Fatalf("expected some errors and panics, got %d errors and %d panics", errs, panics)
+	}
+}
+
+func TestPanicResponses(t *testing.T) {
+	var m MethodConfig[func() int]
+	m.EnqueueWithDelay(func() int { return 1 }, 0)
+	m.EnqueuePanic("queued")
+	m.SetPanicResponse("fallback")
+
+	if got := m.NextResponse(nil)(); got != 1 {
+		t.Fatalf("expected first queued response, got %d", got)
+	}
+	for _, want := range []string{"queued", "fallback"} {
+		func() {
+			defer func() {
+				if r := recover(); r != want {
+					t.Fatalf("expected panic %q, got %v", want, r)
+				}
+			}()
+			m.NextResponse(nil)
+		}()
+	}
+}
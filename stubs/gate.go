@@ -0,0 +1,236 @@
+package stubs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Gate holds calls to a mocked method mid-call until the test lets them through, so tests can line up
+// exact interleavings of concurrent calls without sleeping. Calls enter the gate after the before hooks
+// run and before a response is chosen.
+type Gate struct {
+	mu      sync.Mutex
+	entered int
+	waiting int
+	permits int
+	open    bool
+	err     error
+	t       TB
+	clock   Clock
+	leaks   *leakRegistry
+
+	// changed is closed and replaced on every change
+	changed chan struct{}
+}
+
+// NewGate returns a closed gate
+func NewGate() *Gate {
+	return &Gate{changed: make(chan struct{})}
+}
+
+// Enter blocks until the call is let through. It returns the error given to Fail, if any.
+func (g *Gate) Enter() error {
+	g.mu.Lock()
+	g.entered++
+	g.waiting++
+	g.notify()
+	var untrack func()
+	defer func() {
+		if untrack != nil {
+			untrack()
+		}
+	}()
+	for {
+		if g.open {
+			g.waiting--
+			err := g.err
+			g.mu.Unlock()
+			return err
+		}
+		if g.permits > 0 {
+			g.permits--
+			g.waiting--
+			g.notify()
+			g.mu.Unlock()
+			return nil
+		}
+		ch := g.changed
+		g.mu.Unlock()
+		if untrack == nil {
+			untrack = g.leaks.track("held at a gate")
+		}
+		<-ch
+		g.mu.Lock()
+	}
+}
+
+// Entered returns the number of calls that have reached the gate, including those let through
+func (g *Gate) Entered() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.entered
+}
+
+// Waiting returns the number of calls currently held at the gate
+func (g *Gate) Waiting() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.waiting
+}
+
+// WaitUntilEntered blocks until at least n calls have reached the gate
+func (g *Gate) WaitUntilEntered(n int) {
+	g.waitUntilEntered(n, nil)
+}
+
+// EnterOrReport is Enter for calls that cannot return an error. If the gate was failed, the error is reported
+// on the test of the method the gate was set on and false is returned. With no test to report on, it panics
+// with the error instead, so the failure is never lost.
+func (g *Gate) EnterOrReport() bool {
+	err := g.Enter()
+	if err == nil {
+		return true
+	}
+	g.mu.Lock()
+	t := g.t
+	g.mu.Unlock()
+	if t == nil {
+		panic(fmt.Sprintf("call failed at the gate: %v (no test to report it on, see MethodConfig.ReportTo)", err))
+	}
+	t.Errorf("call failed at the gate: %v", err)
+	return false
+}
+
+// WaitUntilEnteredWithin is WaitUntilEntered that fails t if n calls have not reached the gate within timeout.
+// The timeout is measured on the clock of the method the gate was set on, or real time.
+func (g *Gate) WaitUntilEnteredWithin(t TB, n int, timeout time.Duration) {
+	t.Helper()
+	g.mu.Lock()
+	clock := g.clock
+	g.mu.Unlock()
+	if clock == nil {
+		clock = RealClock()
+	}
+	deadline := clock.NewTimer(timeout)
+	defer deadline.Stop()
+	if !g.waitUntilEntered(n, deadline.C()) {
+		t.Fatalf("timeout waiting for %d calls to enter the gate, %d entered", n, g.Entered())
+	}
+}
+
+func (g *Gate) waitUntilEntered(n int, timeout <-chan time.Time) bool {
+	for {
+		g.mu.Lock()
+		if g.entered >= n {
+			g.mu.Unlock()
+			return true
+		}
+		ch := g.changed
+		g.mu.Unlock()
+
+		select {
+		case <-ch:
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+// Release opens the gate, letting every held call and every later call through
+func (g *Gate) Release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.open = true
+	g.notify()
+}
+
+// ReleaseOne lets a single call through. If none is held, the next call to enter passes straight through.
+func (g *Gate) ReleaseOne() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.permits++
+	g.notify()
+}
+
+// Fail opens the gate so every held call and every later call fails with err. Methods with an error
+// output return err; methods without one report err on their test, see EnterOrReport, and return their
+// zero values.
+func (g *Gate) Fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.open = true
+	g.err = err
+	g.notify()
+}
+
+// notify wakes everything waiting on the gate. Callers must hold g.mu.
+func (g *Gate) notify() {
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// SetGate holds every later call to the method at g until the test releases it. g measures
+// WaitUntilEnteredWithin timeouts on the method's clock, calls held at it are reported to the
+// test passed to TrackLeaks, and calls failed by it to the test passed to ReportTo.
+func (m *MethodConfig[T]) SetGate(g *Gate) {
+	m.mu.Lock()
+	old := m.gate
+	m.version++
+	m.gate = g
+	clock := m.getClock()
+	leaks := m.leaks
+	t := m.t
+	m.mu.Unlock()
+	if g != nil {
+		g.mu.Lock()
+		g.clock = clock
+		g.leaks = leaks
+		g.t = t
+		g.mu.Unlock()
+	}
+	releaseReplacedGate(old, g)
+}
+
+// ReportTo makes calls without an error output report failures of gates set from now on to t.
+// Like the clock, it is kept by Reset and Restore.
+func (m *MethodConfig[T]) ReportTo(t TB) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.t = t
+}
+
+// ClearGate removes the gate, releasing any calls held at it
+func (m *MethodConfig[T]) ClearGate() {
+	m.SetGate(nil)
+}
+
+// PassGate enters the method's gate, if it has one, and returns the error it was failed with
+func (m *MethodConfig[T]) PassGate() error {
+	m.mu.Lock()
+	g := m.gate
+	m.mu.Unlock()
+	if g == nil {
+		return nil
+	}
+	return g.Enter()
+}
+
+// PassGateOrReport is PassGate for methods without an error output. If the gate was failed, the error is
+// reported on the test passed to ReportTo and false is returned, so the call returns its zero values.
+func (m *MethodConfig[T]) PassGateOrReport() bool {
+	m.mu.Lock()
+	g := m.gate
+	m.mu.Unlock()
+	if g == nil {
+		return true
+	}
+	return g.EnterOrReport()
+}
+
+// releaseReplacedGate releases old when it is replaced, so no call is left held at a gate the test can no longer reach
+func releaseReplacedGate(old, replacement *Gate) {
+	if old != nil && old != replacement {
+		old.Release()
+	}
+}
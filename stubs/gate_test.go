@@ -0,0 +1,141 @@
+package stubs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGateReleaseOneLetsCallsThroughInTurn(t *testing.T) {
+	g := NewGate()
+	done := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() { done <- g.Enter() }()
+	}
+	g.WaitUntilEnteredWithin(t, 3, time.Second)
+
+	g.ReleaseOne()
+	WaitForResult(t, done, time.Second)
+	select {
+	case <-done:
+		t.Fatal("expected ReleaseOne to let a single call through")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if g.Waiting() != 2 {
+		t.Fatalf("expected 2 calls still held, got %d", g.Waiting())
+	}
+
+	g.Release()
+	WaitForResult(t, done, time.Second)
+	WaitForResult(t, done, time.Second)
+	if err := g.Enter(); err != nil {
+		t.Fatalf("expected an open gate to let later calls through, got %v", err)
+	}
+}
+
+func TestGateReleaseOneBeforeEnter(t *testing.T) {
+	g := NewGate()
+	g.ReleaseOne()
+	if err := g.Enter(); err != nil {
+		t.Fatalf("expected a stored permit to let the call through, got %v", err)
+	}
+	if g.Entered() != 1 || g.Waiting() != 0 {
+		t.Fatalf("unexpected counts entered=%d waiting=%d", g.Entered(), g.Waiting())
+	}
+}
+
+func TestGateFail(t *testing.T) {
+	g := NewGate()
+	errBoom := errors.New("boom")
+	done := make(chan error, 1)
+	go func() { done <- g.Enter() }()
+	g.WaitUntilEntered(1)
+	g.Fail(errBoom)
+	if err := WaitForResult(t, done, time.Second); !errors.Is(err, errBoom) {
+		t.Fatalf("expected the held call to fail with %v, got %v", errBoom, err)
+	}
+	if err := g.Enter(); !errors.Is(err, errBoom) {
+		t.Fatalf("expected later calls to fail too, got %v", err)
+	}
+}
+
+func TestGateFailReportsWithoutErrorOutput(t *testing.T) {
+	var m MethodConfig[func(int)]
+	ft := &fakeT{}
+	m.ReportTo(ft)
+	g := NewGate()
+	m.SetGate(g)
+	g.Fail(errors.New("boom"))
+
+	if m.PassGateOrReport() {
+		t.Fatal("expected the call to be stopped at the failed gate")
+	}
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], "call failed at the gate: boom") {
+		t.Fatalf("expected the failure to be reported on the test, got %q", ft.errors)
+	}
+}
+
+func TestGateFailWithoutTestPanics(t *testing.T) {
+	var m MethodConfig[func(int)]
+	g := NewGate()
+	m.SetGate(g)
+	g.Fail(errors.New("boom"))
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "call failed at the gate: boom") {
+			t.Fatalf("expected a panic naming the gate failure, got %v", r)
+		}
+	}()
+	m.PassGateOrReport()
+}
+
+func TestGateWaitUntilEnteredWithinUsesMethodClock(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var m MethodConfig[func()]
+	m.SetClock(clock)
+	g := NewGate()
+	m.SetGate(g)
+
+	ft := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.WaitUntilEnteredWithin(ft, 1, time.Minute)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	WaitForResult(t, done, time.Second)
+	if !ft.failed {
+		t.Fatal("expected the wait to time out once the fake clock passed the timeout")
+	}
+	if clock.Waiters() != 0 {
+		t.Fatalf("expected the timer to be removed, %d waiters left", clock.Waiters())
+	}
+}
+
+func TestGateWaitUntilEnteredWithinTimesOut(t *testing.T) {
+	ft := &fakeT{}
+	NewGate().WaitUntilEnteredWithin(ft, 1, 10*time.Millisecond)
+	if !ft.failed || !strings.Contains(ft.msg, "timeout waiting for 1 calls to enter the gate, 0 entered") {
+		t.Fatalf("expected a timeout failure, got %q", ft.msg)
+	}
+}
+
+func TestResetReleasesGatedCalls(t *testing.T) {
+	var m MethodConfig[func()]
+	g := NewGate()
+	m.SetGate(g)
+	done := make(chan error, 1)
+	go func() { done <- m.PassGate() }()
+	g.WaitUntilEnteredWithin(t, 1, time.Second)
+
+	m.Reset()
+	if err := WaitForResult(t, done, time.Second); err != nil {
+		t.Fatalf("expected reset to release the call, got %v", err)
+	}
+	if err := m.PassGate(); err != nil || g.Entered() != 1 {
+		t.Fatalf("expected no gate after reset, got err=%v entered=%d", err, g.Entered())
+	}
+}
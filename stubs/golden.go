@@ -0,0 +1,261 @@
+package stubs
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// GoldenMode decides whether a Golden records calls to the real implementation or replays a recording
+type GoldenMode string
+
+const (
+	// GoldenOff leaves mocks untouched
+	GoldenOff GoldenMode = ""
+	// GoldenRecord calls through to the real implementation and writes every call to the golden file
+	GoldenRecord GoldenMode = "record"
+	// GoldenReplay answers calls from the golden file without touching the real implementation
+	GoldenReplay GoldenMode = "replay"
+	// GoldenAuto replays if the golden file exists and records otherwise
+	GoldenAuto GoldenMode = "auto"
+)
+
+// GoldenEnv is the environment variable read by GoldenModeFromFlags when the flag is not set
+const GoldenEnv = "STUBS_GOLDEN"
+
+// GoldenFlag is the flag read by GoldenModeFromFlags. The package does not define it, so it is only
+// read from test binaries that define it themselves, e.g. in TestMain.
+const GoldenFlag = "stubs.golden"
+
+// GoldenModeFromFlags returns the mode given by -stubs.golden if the test binary defines it,
+// or else the STUBS_GOLDEN environment variable
+func GoldenModeFromFlags() GoldenMode {
+	if f := flag.Lookup(GoldenFlag); f != nil && f.Value.String() != "" {
+		return GoldenMode(f.Value.String())
+	}
+	return GoldenMode(os.Getenv(GoldenEnv))
+}
+
+// GoldenCall is one recorded call. Args and results are keyed by their YAML param names.
+type GoldenCall struct {
+	Method  string `yaml:"method"`
+	Args    any    `yaml:"args,omitempty"`
+	Results any    `yaml:"results,omitempty"`
+}
+
+type goldenFile struct {
+	Calls []GoldenCall `yaml:"calls"`
+}
+
+// Golden records calls to a golden file, or replays them, for any number of methods and mocks.
+// Recordings are written when the test finishes. Replay answers each method's calls in recorded order,
+// reporting mismatches with Errorf so they are safe to raise from the goroutine making the call.
+type Golden struct {
+	t    TB
+	path string
+	mode GoldenMode
+
+	mu       sync.Mutex
+	recorded []GoldenCall
+	replay   map[string][]GoldenCall
+	made     map[string]int
+}
+
+// NewGolden returns a Golden for path. GoldenAuto is resolved to record or replay straight away.
+func NewGolden(t TB, path string, mode GoldenMode) *Golden {
+	t.Helper()
+	g := &Golden{t: t, path: path, mode: mode, made: map[string]int{}}
+
+	if mode == GoldenAuto {
+		g.mode = GoldenRecord
+		if _, err := os.Stat(path); err == nil {
+			g.mode = GoldenReplay
+		}
+	}
+
+	switch g.mode {
+	case GoldenOff:
+	case GoldenRecord:
+		t.Cleanup(g.write)
+	case GoldenReplay:
+		if err := g.load(); err != nil {
+			t.Fatalf("failed to load golden file: %v", err)
+			return g
+		}
+		t.Cleanup(g.checkAllReplayed)
+	default:
+		t.Fatalf("unknown golden mode %q, expected %s, %s or %s", mode, GoldenRecord, GoldenReplay, GoldenAuto)
+	}
+	return g
+}
+
+// Mode returns the mode in use, with GoldenAuto resolved
+func (g *Golden) Mode() GoldenMode {
+	return g.mode
+}
+
+func (g *Golden) load() error {
+	data, err := os.ReadFile(g.path)
+	if err != nil {
+		return err
+	}
+	var f goldenFile
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return fmt.Errorf("%s: %w", g.path, err)
+	}
+	g.replay = map[string][]GoldenCall{}
+	for _, c := range f.Calls {
+		g.replay[c.Method] = append(g.replay[c.Method], c)
+	}
+	return nil
+}
+
+func (g *Golden) write() {
+	g.mu.Lock()
+	data, err := yaml.Marshal(goldenFile{Calls: g.recorded})
+	g.mu.Unlock()
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(g.path), os.ModePerm)
+	}
+	if err == nil {
+		err = os.WriteFile(g.path, data, 0o644)
+	}
+	if err != nil {
+		g.t.Errorf("failed to write golden file %s: %v", g.path, err)
+	}
+}
+
+func (g *Golden) checkAllReplayed() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var missing []string
+	for method, calls := range g.replay {
+		if n := len(calls) - g.made[method]; n > 0 {
+			missing = append(missing, fmt.Sprintf("%s (%d)", method, n))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		g.t.Errorf("golden file %s has calls that were not made: %s", g.path, strings.Join(missing, ", "))
+	}
+}
+
+func (g *Golden) record(c GoldenCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.recorded = append(g.recorded, c)
+}
+
+// next returns the next recorded call to method and its position among that method's calls
+func (g *Golden) next(method string) (GoldenCall, int, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	i := g.made[method]
+	g.made[method]++
+	if i >= len(g.replay[method]) {
+		return GoldenCall{}, i, false
+	}
+	return g.replay[method][i], i, true
+}
+
+// UseGolden records this method's calls to g or replays them from it, depending on g's mode.
+// Recording stops when g's test finishes or the method is Reset.
+// Replay enables the mock; calls whose arguments drift from the recording fail the test with a diff.
+// inputs and outputs are the YAML param names.
+func (m *MethodConfig[T]) UseGolden(g *Golden, name string, inputs, outputs []string) {
+	switch g.mode {
+	case GoldenRecord:
+		m.setObserver(func(args, results []any) {
+			g.record(GoldenCall{Method: name, Args: encodeParams(args, inputs), Results: encodeParams(results, outputs)})
+		})
+		g.t.Cleanup(func() { m.setObserver(nil) })
+	case GoldenReplay:
+		m.SetResponseFunc(m.replayFunc(g, name, inputs, outputs))
+		m.Enable()
+	}
+}
+
+func (m *MethodConfig[T]) replayFunc(g *Golden, name string, inputs, outputs []string) T {
+	fnType := reflect.TypeOf((*T)(nil)).Elem()
+	in := make([]reflect.Type, fnType.NumIn())
+	for i := range in {
+		in[i] = fnType.In(i)
+	}
+	out := make([]reflect.Type, fnType.NumOut())
+	for i := range out {
+		out[i] = fnType.Out(i)
+	}
+	zero := func() []reflect.Value {
+		values := make([]reflect.Value, len(out))
+		for i, t := range out {
+			values[i] = reflect.Zero(t)
+		}
+		return values
+	}
+
+	fn := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		actual := make([]any, len(args))
+		for i, a := range args {
+			actual[i] = a.Interface()
+		}
+
+		call, i, ok := g.next(name)
+		if !ok {
+			g.t.Errorf("golden file %s: unexpected call %d to %s%s, only %d were recorded", g.path, i+1, name, formatArgs(actual), i)
+			return zero()
+		}
+
+		path := fmt.Sprintf("%s call %d", name, i+1)
+		expectedArgs, err := decodeParams(path+" args", call.Args, in, inputs, true)
+		if err != nil {
+			g.t.Errorf("golden file %s: %v", g.path, err)
+			return zero()
+		}
+		expected := make([]any, len(expectedArgs))
+		for i, v := range expectedArgs {
+			expected[i] = v.Interface()
+		}
+		if diff := DiffArgs(expected, actual); !diff.Equal() {
+			g.t.Errorf("golden file %s: args of %s drifted from the recording\n%s\nre-record with %s=record", g.path, path, diff, GoldenEnv)
+		}
+
+		results, err := decodeParams(path+" results", call.Results, out, outputs, true)
+		if err != nil {
+			g.t.Errorf("golden file %s: %v", g.path, err)
+			return zero()
+		}
+		return results
+	})
+	return fn.Interface().(T)
+}
+
+// encodeParams converts values into a YAML map keyed by param name, or a list if any param is unnamed.
+// Errors are stored as their message.
+func encodeParams(values []any, names []string) any {
+	if len(values) == 0 {
+		return nil
+	}
+	encoded := make([]any, len(values))
+	for i, v := range values {
+		if err, ok := v.(error); ok {
+			encoded[i] = err.Error()
+			continue
+		}
+		encoded[i] = v
+	}
+	if len(nonEmpty(names)) != len(values) {
+		return encoded
+	}
+	m := make(yaml.MapSlice, len(values))
+	for i, v := range encoded {
+		m[i] = yaml.MapItem{Key: names[i], Value: v}
+	}
+	return m
+}
@@ -0,0 +1,177 @@
+package stubs
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// goldenLoadCargo wires a MethodConfig the way a generated mock does, calling real when not enabled
+func goldenLoadCargo(m *MethodConfig[func([]string) (int, error)], real func([]string) (int, error)) func([]string) (int, error) {
+	return func(items []string) (int, error) {
+		id := m.RecordCall(items)
+		var n int
+		var err error
+		if m.IsEnabled() {
+			n, err = m.NextResponse(real)(items)
+		} else {
+			n, err = real(items)
+		}
+		m.RecordResults(id, n, err)
+		return n, err
+	}
+}
+
+func TestGoldenRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cargo.golden.yaml")
+	realCalls := 0
+	real := func(items []string) (int, error) {
+		realCalls++
+		if len(items) > 2 {
+			return 0, errors.New("cargo hold full")
+		}
+		return len(items), nil
+	}
+
+	rt := &fakeT{}
+	var rec MethodConfig[func([]string) (int, error)]
+	rec.UseGolden(NewGolden(rt, path, GoldenRecord), "LoadCargo", []string{"items"}, []string{"loaded", "err"})
+	load := goldenLoadCargo(&rec, real)
+	load([]string{"a", "b"})
+	load([]string{"a", "b", "c"})
+	rt.finish()
+	if len(rt.errors) > 0 || rt.failed {
+		t.Fatalf("recording failed: %v %s", rt.errors, rt.msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected a golden file: %v", err)
+	}
+	if !strings.Contains(string(data), "loaded: 2") || !strings.Contains(string(data), "err: cargo hold full") {
+		t.Fatalf("expected results keyed by param name, got:\n%s", data)
+	}
+
+	pt := &fakeT{}
+	var rep MethodConfig[func([]string) (int, error)]
+	g := NewGolden(pt, path, GoldenAuto)
+	if g.Mode() != GoldenReplay {
+		t.Fatalf("expected auto to replay an existing file, got %q", g.Mode())
+	}
+	rep.UseGolden(g, "LoadCargo", []string{"items"}, []string{"loaded", "err"})
+	load = goldenLoadCargo(&rep, real)
+	if n, err := load([]string{"a", "b"}); n != 2 || err != nil {
+		t.Fatalf("expected replayed (2, nil), got (%d, %v)", n, err)
+	}
+	if _, err := load([]string{"a", "b", "c"}); err == nil || err.Error() != "cargo hold full" {
+		t.Fatalf("expected replayed error, got %v", err)
+	}
+	pt.finish()
+	if len(pt.errors) > 0 {
+		t.Fatalf("unexpected replay errors: %v", pt.errors)
+	}
+	if realCalls != 2 {
+		t.Fatalf("expected replay not to call the real implementation, got %d real calls", realCalls)
+	}
+}
+
+func TestGoldenReplayReportsDrift(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cargo.golden.yaml")
+	golden := "calls:\n- method: LoadCargo\n  args: {items: [a, b]}\n  results: {loaded: 2}\n- method: LoadCargo\n  args: {items: [c]}\n  results: {loaded: 1}\n"
+	if err := os.WriteFile(path, []byte(golden), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ft := &fakeT{}
+	var m MethodConfig[func([]string) (int, error)]
+	m.UseGolden(NewGolden(ft, path, GoldenReplay), "LoadCargo", []string{"items"}, []string{"loaded", "err"})
+	n, _ := m.NextResponse(nil)([]string{"a", "x"})
+	if n != 2 {
+		t.Fatalf("expected the recorded result despite drift, got %d", n)
+	}
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], `args[0][1]: expected "b", got "x"`) {
+		t.Fatalf("expected a drift diff, got %v", ft.errors)
+	}
+
+	ft.finish()
+	if len(ft.errors) != 2 || !strings.Contains(ft.errors[1], "calls that were not made: LoadCargo (1)") {
+		t.Fatalf("expected unmade calls to be reported, got %v", ft.errors)
+	}
+}
+
+func TestGoldenReplayUnexpectedCall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.golden.yaml")
+	if err := os.WriteFile(path, []byte("calls: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ft := &fakeT{}
+	var m MethodConfig[func(int) int]
+	m.UseGolden(NewGolden(ft, path, GoldenReplay), "Accelerate", []string{"speed"}, []string{"newSpeed"})
+	if got := m.NextResponse(nil)(5); got != 0 {
+		t.Fatalf("expected zero for an unrecorded call, got %d", got)
+	}
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], "unexpected call 1 to Accelerate(5)") {
+		t.Fatalf("expected an unexpected call error, got %v", ft.errors)
+	}
+}
+
+func TestNewGoldenRejectsUnknownMode(t *testing.T) {
+	ft := &fakeT{}
+	NewGolden(ft, filepath.Join(t.TempDir(), "x.yaml"), "rewind")
+	if !ft.failed || !strings.Contains(ft.msg, `unknown golden mode "rewind"`) {
+		t.Fatalf("expected an unknown mode failure, got %q", ft.msg)
+	}
+}
+
+func TestGoldenRecordingStopsWithTestAndReset(t *testing.T) {
+	dir := t.TempDir()
+	real := func(items []string) (int, error) { return len(items), nil }
+	var m MethodConfig[func([]string) (int, error)]
+	load := goldenLoadCargo(&m, real)
+
+	first := &fakeT{}
+	g1 := NewGolden(first, filepath.Join(dir, "first.golden.yaml"), GoldenRecord)
+	m.UseGolden(g1, "LoadCargo", []string{"items"}, []string{"loaded", "err"})
+	load([]string{"a"})
+	first.finish()
+
+	// a later test sharing the mock does not write into the finished test's golden
+	load([]string{"b"})
+	if n := len(g1.recorded); n != 1 {
+		t.Fatalf("expected recording to stop when the test finished, got %d calls", n)
+	}
+
+	second := &fakeT{}
+	g2 := NewGolden(second, filepath.Join(dir, "second.golden.yaml"), GoldenRecord)
+	m.UseGolden(g2, "LoadCargo", []string{"items"}, []string{"loaded", "err"})
+	m.Reset()
+	load([]string{"c"})
+	if n := len(g2.recorded); n != 0 {
+		t.Fatalf("expected Reset to stop recording, got %d calls", n)
+	}
+}
+
+func TestGoldenModeFromFlagsReadsDefinedFlag(t *testing.T) {
+	t.Setenv(GoldenEnv, "replay")
+	if mode := GoldenModeFromFlags(); mode != GoldenReplay {
+		t.Fatalf("expected the environment variable without the flag defined, got %q", mode)
+	}
+
+	// the package must not define the flag itself, or test binaries defining it would panic
+	const usage = "defined by the test"
+	f := flag.Lookup(GoldenFlag)
+	if f == nil {
+		flag.String(GoldenFlag, "", usage)
+		f = flag.Lookup(GoldenFlag)
+	}
+	if f.Usage != usage {
+		t.Fatal("expected the stubs package not to define the golden flag")
+	}
+	f.Value.Set("record")
+	defer f.Value.Set("")
+	if mode := GoldenModeFromFlags(); mode != GoldenRecord {
+		t.Fatalf("expected a defined flag to take precedence, got %q", mode)
+	}
+}
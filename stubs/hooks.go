@@ -0,0 +1,130 @@
+package stubs
+
+import (
+	"sort"
+	"sync"
+)
+
+// Hooks holds funcs run before and after each call to a mocked method, whether the response comes
+// from the real implementation, the queue or the fallback. A is the method's args and R its result.
+// Hooks run in the order they were added, on the goroutine making the call. The zero value is ready to use.
+type Hooks[A, R any] struct {
+	mu     sync.RWMutex
+	next   uint64
+	before map[uint64]func(A)
+	after  map[uint64]func(A, R)
+}
+
+// Before adds fn to run when the method is called, before its response is chosen.
+// Args are passed as given by the caller, so fn can fill pointer and slice arguments.
+// The returned func removes the hook.
+func (h *Hooks[A, R]) Before(fn func(A)) (remove func()) {
+	id := h.addBefore(fn)
+	return func() { h.remove(id) }
+}
+
+// After adds fn to run once the method has returned its result. It is not run if the call panics.
+// The returned func removes the hook.
+func (h *Hooks[A, R]) After(fn func(A, R)) (remove func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.after == nil {
+		h.after = make(map[uint64]func(A, R))
+	}
+	h.next++
+	id := h.next
+	h.after[id] = fn
+	return func() { h.remove(id) }
+}
+
+// RunBefore runs every before hook with args
+func (h *Hooks[A, R]) RunBefore(args A) {
+	for _, fn := range sortedHooks(h, func() map[uint64]func(A) { return h.before }) {
+		fn(args)
+	}
+}
+
+// RunAfter runs every after hook with args and result
+func (h *Hooks[A, R]) RunAfter(args A, result R) {
+	for _, fn := range sortedHooks(h, func() map[uint64]func(A, R) { return h.after }) {
+		fn(args, result)
+	}
+}
+
+// Mark returns a marker for the hooks added so far, for use with RemoveAfter
+func (h *Hooks[A, R]) Mark() uint64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.next
+}
+
+// RemoveAfter removes every hook added after mark was taken
+func (h *Hooks[A, R]) RemoveAfter(mark uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id := range h.before {
+		if id > mark {
+			delete(h.before, id)
+		}
+	}
+	for id := range h.after {
+		if id > mark {
+			delete(h.after, id)
+		}
+	}
+}
+
+// RemoveAll removes every hook
+func (h *Hooks[A, R]) RemoveAll() {
+	h.RemoveAfter(0)
+}
+
+func (h *Hooks[A, R]) addBefore(fn func(A)) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.before == nil {
+		h.before = make(map[uint64]func(A))
+	}
+	h.next++
+	h.before[h.next] = fn
+	return h.next
+}
+
+func (h *Hooks[A, R]) remove(id uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.before, id)
+	delete(h.after, id)
+}
+
+// sortedHooks copies fns in the order they were added, so hooks can be added or removed while they run
+func sortedHooks[A, R, F any](h *Hooks[A, R], pick func() map[uint64]F) []F {
+	h.mu.RLock()
+	fns := pick()
+	ids := make([]uint64, 0, len(fns))
+	for id := range fns {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	out := make([]F, len(ids))
+	for i, id := range ids {
+		out[i] = fns[id]
+	}
+	h.mu.RUnlock()
+	return out
+}
+
+// Notify returns a channel that is closed the next time the method is called, before it responds.
+// The hook removes itself once it has fired.
+func (h *Hooks[A, R]) Notify() <-chan struct{} {
+	ch := make(chan struct{})
+	var once sync.Once
+	var id uint64
+	id = h.addBefore(func(A) {
+		once.Do(func() {
+			close(ch)
+			h.remove(id)
+		})
+	})
+	return ch
+}
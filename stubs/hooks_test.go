@@ -0,0 +1,79 @@
+package stubs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHooksRunInOrderAndRemove(t *testing.T) {
+	var h Hooks[string, int]
+	var got []string
+	h.Before(func(a string) { got = append(got, "before1 "+a) })
+	remove := h.Before(func(a string) { got = append(got, "before2 "+a) })
+	h.After(func(a string, r int) { got = append(got, "after "+a) })
+
+	h.RunBefore("x")
+	h.RunAfter("x", 1)
+	remove()
+	h.RunBefore("y")
+
+	want := []string{"before1 x", "before2 x", "after x", "before1 y"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected hook runs %v, want %v", got, want)
+	}
+}
+
+func TestHooksRemoveAfterMark(t *testing.T) {
+	var h Hooks[int, struct{}]
+	calls := 0
+	h.Before(func(int) { calls++ })
+	mark := h.Mark()
+	h.Before(func(int) { calls += 10 })
+	h.After(func(int, struct{}) { calls += 100 })
+
+	h.RemoveAfter(mark)
+	h.RunBefore(0)
+	h.RunAfter(0, struct{}{})
+	if calls != 1 {
+		t.Fatalf("expected only the hook added before the mark to run, got %d", calls)
+	}
+
+	h.RemoveAll()
+	h.RunBefore(0)
+	if calls != 1 {
+		t.Fatalf("expected no hooks after RemoveAll, got %d", calls)
+	}
+}
+
+func TestHooksCanBeChangedWhileRunning(t *testing.T) {
+	var h Hooks[int, struct{}]
+	var remove func()
+	remove = h.Before(func(int) {
+		remove()
+		h.Before(func(int) {})
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.RunBefore(1)
+	}()
+	WaitForResult(t, done, time.Second)
+}
+
+func TestHooksNotifyFiresOnce(t *testing.T) {
+	var h Hooks[int, struct{}]
+	ch := h.Notify()
+	select {
+	case <-ch:
+		t.Fatal("expected the channel to stay open until a call")
+	default:
+	}
+
+	h.RunBefore(1)
+	WaitForResult(t, ch, time.Second)
+	h.RunBefore(2)
+	if h.Mark() != 1 || len(h.before) != 0 {
+		t.Fatalf("expected the notify hook to remove itself, %d hooks left", len(h.before))
+	}
+}
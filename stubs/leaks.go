@@ -0,0 +1,206 @@
+package stubs
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// leakGrace is how long VerifyNoLeaks gives tracked goroutines to finish once the test is done
+const leakGrace = 200 * time.Millisecond
+
+// leakEntry is a goroutine that would leak if it were still running when a test finished
+type leakEntry struct {
+	id        uint64
+	goroutine uint64
+	what      string
+}
+
+// leakRegistry tracks goroutines started by mocks and goroutines blocked in a gate or on a subscription
+// for a single test. A nil registry tracks nothing.
+type leakRegistry struct {
+	mu      sync.Mutex
+	next    uint64
+	entries map[uint64]leakEntry
+	// checks counts the VerifyNoLeaks calls for the test that have not run yet. Guarded by testLeaks.
+	checks int
+}
+
+// testLeaks holds the registry of every test checked by VerifyNoLeaks, until the check runs
+var testLeaks = struct {
+	sync.Mutex
+	m map[TB]*leakRegistry
+}{m: map[TB]*leakRegistry{}}
+
+// leaksFor returns the registry of t, or nil if VerifyNoLeaks was not called for t
+func leaksFor(t TB) *leakRegistry {
+	testLeaks.Lock()
+	defer testLeaks.Unlock()
+	return testLeaks.m[t]
+}
+
+// track registers the calling goroutine until the returned func is called
+func (r *leakRegistry) track(what string) (done func()) {
+	if r == nil {
+		return func() {}
+	}
+	r.mu.Lock()
+	r.next++
+	id := r.next
+	r.entries[id] = leakEntry{id: id, goroutine: goroutineID(), what: what}
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.entries, id)
+	}
+}
+
+// running returns the entries that are still running, oldest first
+func (r *leakRegistry) running() []leakEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]uint64, 0, len(r.entries))
+	for id := range r.entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	out := make([]leakEntry, len(ids))
+	for i, id := range ids {
+		out[i] = r.entries[id]
+	}
+	return out
+}
+
+// forget stops tracking entries, so a leak is only reported once
+func (r *leakRegistry) forget(entries []leakEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range entries {
+		delete(r.entries, e.id)
+	}
+}
+
+// Go runs fn on a new goroutine that VerifyNoLeaks(t) reports if it is still running when t finishes
+func Go(t TB, name string, fn func()) {
+	r := leaksFor(t)
+	go func() {
+		defer r.track("started by " + name)()
+		fn()
+	}()
+}
+
+// TrackLeaks makes VerifyNoLeaks(t) report calls held at the method's gates when t finishes.
+// It has no effect unless VerifyNoLeaks was called for t. Like the clock, it is kept by Reset and Restore.
+func (m *MethodConfig[T]) TrackLeaks(t TB) {
+	r := leaksFor(t)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leaks = r
+}
+
+// TrackLeaks makes VerifyNoLeaks(t) report publishers blocked on subscriptions made from now on when t finishes.
+// It has no effect unless VerifyNoLeaks was called for t.
+func (b *Broadcaster[E]) TrackLeaks(t TB) {
+	r := leaksFor(t)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.leaks = r
+}
+
+// VerifyNoLeaks fails t if, when it finishes, any goroutine started for t by Go is still running, or any
+// call to a method or broadcaster passed t through TrackLeaks is still held at a gate or blocked sending
+// to a subscription. Each leak is reported with its stack. Tracked goroutines get a short grace period
+// to finish. Leaks are tracked per test, so tests running in parallel do not see each other's.
+// Calling it again for t adds another check, run before cleanups registered earlier, and each leak is
+// reported once.
+func VerifyNoLeaks(t TB) {
+	t.Helper()
+	testLeaks.Lock()
+	r, ok := testLeaks.m[t]
+	if !ok {
+		r = &leakRegistry{entries: map[uint64]leakEntry{}}
+		testLeaks.m[t] = r
+	}
+	r.checks++
+	testLeaks.Unlock()
+
+	t.Cleanup(func() {
+		defer func() {
+			testLeaks.Lock()
+			defer testLeaks.Unlock()
+			if r.checks--; r.checks == 0 {
+				delete(testLeaks.m, t)
+			}
+		}()
+
+		deadline := time.Now().Add(leakGrace)
+		remaining := r.running()
+		for len(remaining) > 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+			remaining = r.running()
+		}
+		if len(remaining) == 0 {
+			return
+		}
+		r.forget(remaining)
+
+		stacks := goroutineStacks()
+		var b strings.Builder
+		for _, e := range remaining {
+			fmt.Fprintf(&b, "\n\ngoroutine %d %s", e.goroutine, e.what)
+			if stack, ok := stacks[e.goroutine]; ok {
+				b.WriteString(":\n")
+				b.WriteString(stack)
+			}
+		}
+		t.Errorf("found %d leaked goroutines%s", len(remaining), b.String())
+	})
+}
+
+// goroutineID returns the id of the calling goroutine, as printed in stack traces
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	id, _ := parseGoroutineHeader(buf[:n])
+	return id
+}
+
+// goroutineStacks returns the stack of every goroutine keyed by its id
+func goroutineStacks() map[uint64]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := map[uint64]string{}
+	for _, g := range bytes.Split(buf, []byte("\n\n")) {
+		if id, ok := parseGoroutineHeader(g); ok {
+			stacks[id] = string(g)
+		}
+	}
+	return stacks
+}
+
+// parseGoroutineHeader reads the id from a stack starting "goroutine 7 [running]:"
+func parseGoroutineHeader(stack []byte) (uint64, bool) {
+	rest, ok := bytes.CutPrefix(stack, []byte("goroutine "))
+	if !ok {
+		return 0, false
+	}
+	end := bytes.IndexByte(rest, ' ')
+	if end < 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(string(rest[:end]), 10, 64)
+	return id, err == nil
+}
@@ -0,0 +1,136 @@
+package stubs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// holdAtGate makes a call to a method tracked for t that is held at a gate until the returned func is called
+func holdAtGate(t *testing.T, owner TB) (release func()) {
+	var m MethodConfig[func()]
+	m.TrackLeaks(owner)
+	g := NewGate()
+	m.SetGate(g)
+	go m.PassGate()
+	g.WaitUntilEnteredWithin(t, 1, time.Second)
+	return g.Release
+}
+
+func TestVerifyNoLeaksReportsHeldGateCalls(t *testing.T) {
+	ft := &fakeT{}
+	VerifyNoLeaks(ft)
+
+	release := holdAtGate(t, ft)
+	ft.finish()
+	release()
+
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], "found 1 leaked goroutines") ||
+		!strings.Contains(ft.errors[0], "held at a gate") || !strings.Contains(ft.errors[0], "(*Gate).Enter") {
+		t.Fatalf("expected the held call to be reported with its stack, got %v", ft.errors)
+	}
+}
+
+func TestVerifyNoLeaksReportsBlockedSenders(t *testing.T) {
+	ft := &fakeT{}
+	VerifyNoLeaks(ft)
+
+	var b Broadcaster[int]
+	b.TrackLeaks(ft)
+	sub := b.Subscribe(SubscribeOptions{Overflow: OverflowBlock})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.Publish(1)
+	}()
+	for len(leaksFor(ft).running()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ft.finish()
+	sub.Unsubscribe()
+	WaitForResult(t, done, time.Second)
+
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], "blocked sending to a subscription") {
+		t.Fatalf("expected the blocked publisher to be reported, got %v", ft.errors)
+	}
+}
+
+func TestVerifyNoLeaksAllowsFinishedGoroutines(t *testing.T) {
+	ft := &fakeT{}
+	VerifyNoLeaks(ft)
+
+	release := make(chan struct{})
+	Go(ft, "test", func() { <-release })
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	ft.finish()
+	if len(ft.errors) != 0 {
+		t.Fatalf("expected a goroutine finishing within the grace period to pass, got %v", ft.errors)
+	}
+}
+
+func TestVerifyNoLeaksIgnoresOtherTests(t *testing.T) {
+	first, second := &fakeT{}, &fakeT{}
+	VerifyNoLeaks(first)
+	VerifyNoLeaks(second)
+
+	release := holdAtGate(t, second)
+	first.finish()
+	if len(first.errors) != 0 {
+		t.Fatalf("expected a leak of another test not to be reported, got %v", first.errors)
+	}
+	second.finish()
+	release()
+	if len(second.errors) != 1 {
+		t.Fatalf("expected the leak to be reported on its own test, got %v", second.errors)
+	}
+}
+
+func TestVerifyNoLeaksReportsEachLeakOnce(t *testing.T) {
+	ft := &fakeT{}
+	VerifyNoLeaks(ft)
+	VerifyNoLeaks(ft)
+
+	release := holdAtGate(t, ft)
+	ft.finish()
+	release()
+	if len(ft.errors) != 1 {
+		t.Fatalf("expected the leak to be reported once, got %v", ft.errors)
+	}
+	if leaksFor(ft) != nil {
+		t.Fatal("expected the registry to be dropped once every check ran")
+	}
+}
+
+func TestCaptureCallsStopsWhenTestFinishes(t *testing.T) {
+	ft := &fakeT{}
+	VerifyNoLeaks(ft)
+
+	var m MethodConfig[func()]
+	m.EnableSpy()
+	ch := CaptureCalls(ft, &m, "Honk spy call", time.Hour)
+	ft.finish()
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the channel to be closed without calls")
+	}
+	if len(ft.errors) != 0 {
+		t.Fatalf("expected the capture to stop quietly, got %v", ft.errors)
+	}
+}
+
+func TestCaptureCallsTimeout(t *testing.T) {
+	ft := &fakeT{}
+	var m MethodConfig[func()]
+	m.EnableSpy()
+	ch := CaptureCalls(ft, &m, "Honk spy call", 10*time.Millisecond)
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the channel to be closed without calls")
+	}
+	if len(ft.errors) != 0 {
+		t.Fatalf("expected the timeout to be reported when the test finishes, got %v", ft.errors)
+	}
+	ft.finish()
+	if len(ft.errors) != 1 || ft.errors[0] != "timeout waiting for Honk spy call" {
+		t.Fatalf("expected a timeout error, got %v", ft.errors)
+	}
+}
@@ -0,0 +1,457 @@
+package stubs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// RecordEnv names the environment variable that makes new<Interface>MockForTest record every call
+// and print the recording when the test fails. Its value picks the format: timeline (also 1 or true),
+// mermaid, json or all.
+const RecordEnv = "STUBS_RECORD"
+
+// timelineWidth is the number of columns the span bars of a timeline are drawn across
+const timelineWidth = 30
+
+// RecordedCall is a call captured by a Recorder
+type RecordedCall struct {
+	// Seq numbers the calls in the order they started, from 1
+	Seq    uint64
+	Mock   string
+	Method string
+	Args   []any
+	// Results holds the values the call returned, or nil if it panicked or is still running
+	Results []any
+	Start   time.Time
+	// End is when the call returned or panicked. It is only meaningful once the call has finished.
+	End time.Time
+	// Goroutine is the id of the goroutine that made the call, as printed in stack traces
+	Goroutine uint64
+	// Returned reports whether the call returned normally
+	Returned bool
+
+	// started and ended order the starts and ends of all calls, whatever clock the mocks use
+	started, ended uint64
+}
+
+// Name returns the call in "Mock.Method" form
+func (c RecordedCall) Name() string {
+	return c.Mock + "." + c.Method
+}
+
+// Finished reports whether the call has returned or panicked
+func (c RecordedCall) Finished() bool {
+	return c.ended != 0
+}
+
+// Duration returns how long the call took, or 0 if it is still running
+func (c RecordedCall) Duration() time.Duration {
+	if !c.Finished() {
+		return 0
+	}
+	return c.End.Sub(c.Start)
+}
+
+// outcome describes how the call ended
+func (c RecordedCall) outcome() string {
+	switch {
+	case c.Returned && len(c.Results) == 0:
+		return "returned"
+	case c.Returned:
+		return strings.TrimSuffix(strings.TrimPrefix(formatArgs(c.Results), "("), ")")
+	case c.Finished():
+		return "panicked"
+	default:
+		return "still running"
+	}
+}
+
+func (c RecordedCall) String() string {
+	return fmt.Sprintf("#%d %s%s -> %s", c.Seq, c.Name(), formatArgs(c.Args), c.outcome())
+}
+
+// Recorder captures every call across the mocks attached to it, with arguments, results, timings and
+// goroutines, and renders them as a timeline, a Mermaid sequence diagram or JSON. Attach it to a
+// generated mock with attachRecorder.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []RecordedCall
+	// gen is incremented by Reset so calls started before it are not written into later entries
+	gen uint64
+	// tick counts call starts and ends
+	tick uint64
+}
+
+// NewRecorder returns an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// start records the beginning of a call
+func (r *Recorder) start(mock, method string, args []any, at time.Time) recording {
+	g := goroutineID()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tick++
+	r.calls = append(r.calls, RecordedCall{
+		Seq:       uint64(len(r.calls) + 1),
+		Mock:      mock,
+		Method:    method,
+		Args:      args,
+		Start:     at,
+		Goroutine: g,
+		started:   r.tick,
+	})
+	return recording{rec: r, index: len(r.calls) - 1, gen: r.gen}
+}
+
+// entry returns the recorded call, or nil if the recorder has been reset since it started. Callers must hold r.mu.
+func (c recording) entry() *RecordedCall {
+	if c.gen != c.rec.gen || c.index >= len(c.rec.calls) {
+		return nil
+	}
+	return &c.rec.calls[c.index]
+}
+
+// returned stores what the call returned
+func (c recording) returned(results []any) {
+	c.rec.mu.Lock()
+	defer c.rec.mu.Unlock()
+	if e := c.entry(); e != nil {
+		e.Results = results
+		e.Returned = true
+	}
+}
+
+// finish records the end of the call
+func (c recording) finish(at time.Time) {
+	c.rec.mu.Lock()
+	defer c.rec.mu.Unlock()
+	if e := c.entry(); e != nil {
+		c.rec.tick++
+		e.End = at
+		e.ended = c.rec.tick
+	}
+}
+
+// Calls returns every call recorded so far in the order they started
+func (r *Recorder) Calls() []RecordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedCall(nil), r.calls...)
+}
+
+// Reset clears the recorded calls. Calls still running when Reset is called are not recorded when they end.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+	r.gen++
+}
+
+// Timeline renders the calls in start order with their offsets from the first call, durations and
+// goroutines, and a bar showing when each was in flight, e.g.
+//
+//	#1  +0s     2ms  g7  |====              |  Vehicle.LoadCargo([]string{"a"}) -> 1, <nil>
+//	#2  +1ms    3ms  g8  |  ======          |  Vehicle.LoadCargo([]string{"b"}) -> 1, <nil>
+func (r *Recorder) Timeline() string {
+	calls := r.Calls()
+	if len(calls) == 0 {
+		return "  (no calls recorded)"
+	}
+	origin, span := timelineBounds(calls)
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, c := range calls {
+		duration := "running"
+		if c.Finished() {
+			duration = c.Duration().String()
+		}
+		fmt.Fprintf(tw, "  #%d\t+%s\t%s\tg%d\t|%s|\t%s%s -> %s\n",
+			c.Seq, c.Start.Sub(origin), duration, c.Goroutine, timelineBar(c, origin, span), c.Name(), formatArgs(c.Args), c.outcome())
+	}
+	tw.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// timelineBounds returns the start of the first call and the time until the last call ended.
+// Calls still running are drawn to the end of the timeline.
+func timelineBounds(calls []RecordedCall) (origin time.Time, span time.Duration) {
+	origin = calls[0].Start
+	end := origin
+	for _, c := range calls {
+		if c.Start.Before(origin) {
+			origin = c.Start
+		}
+		if c.Start.After(end) {
+			end = c.Start
+		}
+		if c.Finished() && c.End.After(end) {
+			end = c.End
+		}
+	}
+	return origin, end.Sub(origin)
+}
+
+func timelineBar(c RecordedCall, origin time.Time, span time.Duration) string {
+	col := func(t time.Time) int {
+		if span <= 0 {
+			return 0
+		}
+		return int(int64(t.Sub(origin)) * (timelineWidth - 1) / int64(span))
+	}
+	from, to := col(c.Start), timelineWidth-1
+	if c.Finished() {
+		to = col(c.End)
+	}
+	bar := []byte(strings.Repeat(" ", timelineWidth))
+	for i := from; i <= to; i++ {
+		bar[i] = '='
+	}
+	if !c.Finished() {
+		bar[timelineWidth-1] = '>'
+	}
+	return string(bar)
+}
+
+// Mermaid renders the calls as a Mermaid sequence diagram, with a participant for each calling
+// goroutine and each mock. Calls and returns appear in the order they happened, so overlapping
+// calls show up interleaved.
+func (r *Recorder) Mermaid() string {
+	calls := r.Calls()
+
+	type event struct {
+		tick  uint64
+		start bool
+		call  RecordedCall
+	}
+	events := make([]event, 0, 2*len(calls))
+	var goroutines []uint64
+	var mocks []string
+	seenG := map[uint64]bool{}
+	seenM := map[string]bool{}
+	for _, c := range calls {
+		events = append(events, event{tick: c.started, start: true, call: c})
+		if c.Finished() {
+			events = append(events, event{tick: c.ended, call: c})
+		}
+		if !seenG[c.Goroutine] {
+			seenG[c.Goroutine] = true
+			goroutines = append(goroutines, c.Goroutine)
+		}
+		if !seenM[c.Mock] {
+			seenM[c.Mock] = true
+			mocks = append(mocks, c.Mock)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].tick < events[j].tick })
+
+	var b strings.Builder
+	b.WriteString("sequenceDiagram\n")
+	for _, g := range goroutines {
+		fmt.Fprintf(&b, "    participant g%d as goroutine %d\n", g, g)
+	}
+	for _, m := range mocks {
+		fmt.Fprintf(&b, "    participant %s\n", mermaidID(m))
+	}
+	for _, e := range events {
+		c := e.call
+		caller, mock := fmt.Sprintf("g%d", c.Goroutine), mermaidID(c.Mock)
+		switch {
+		case e.start:
+			fmt.Fprintf(&b, "    %s->>+%s: #%d %s%s\n", caller, mock, c.Seq, c.Method, mermaidText(formatArgs(c.Args)))
+		case c.Returned:
+			fmt.Fprintf(&b, "    %s-->>-%s: #%d %s [%s]\n", mock, caller, c.Seq, mermaidText(c.outcome()), c.Duration())
+		default:
+			fmt.Fprintf(&b, "    %s--x-%s: #%d panicked [%s]\n", mock, caller, c.Seq, c.Duration())
+		}
+	}
+	for _, c := range calls {
+		if !c.Finished() {
+			fmt.Fprintf(&b, "    Note over %s: #%d %s still running\n", mermaidID(c.Mock), c.Seq, c.Method)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// mermaidID makes a mock name safe to use as a participant id
+func mermaidID(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// mermaidText escapes characters that end or break a Mermaid message
+func mermaidText(s string) string {
+	return strings.NewReplacer(";", "#59;", "#", "#35;", "\n", " ").Replace(s)
+}
+
+// recordedCallJSON is the JSON form of a RecordedCall. Values that cannot be encoded as JSON are written as Go syntax strings.
+type recordedCallJSON struct {
+	Seq        uint64            `json:"seq"`
+	Mock       string            `json:"mock"`
+	Method     string            `json:"method"`
+	Args       []json.RawMessage `json:"args"`
+	Results    []json.RawMessage `json:"results,omitempty"`
+	Start      time.Time         `json:"start"`
+	End        *time.Time        `json:"end,omitempty"`
+	DurationNS int64             `json:"durationNs"`
+	Goroutine  uint64            `json:"goroutine"`
+	Returned   bool              `json:"returned"`
+	Panicked   bool              `json:"panicked"`
+}
+
+// WriteJSON writes the calls as an indented JSON array
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	calls := r.Calls()
+	out := make([]recordedCallJSON, len(calls))
+	for i, c := range calls {
+		out[i] = recordedCallJSON{
+			Seq:        c.Seq,
+			Mock:       c.Mock,
+			Method:     c.Method,
+			Args:       jsonValues(c.Args),
+			Results:    jsonValues(c.Results),
+			Start:      c.Start,
+			DurationNS: int64(c.Duration()),
+			Goroutine:  c.Goroutine,
+			Returned:   c.Returned,
+			Panicked:   c.Finished() && !c.Returned,
+		}
+		if c.Finished() {
+			end := c.End
+			out[i].End = &end
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func jsonValues(values []any) []json.RawMessage {
+	if values == nil {
+		return nil
+	}
+	out := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		if err, ok := v.(error); ok && err != nil {
+			v = err.Error()
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			data, _ = json.Marshal(fmt.Sprintf("%#v", v))
+		}
+		out[i] = data
+	}
+	return out
+}
+
+// Render returns the recording in the given format: timeline, mermaid, json or all
+func (r *Recorder) Render(format string) string {
+	switch format {
+	case "mermaid":
+		return r.Mermaid()
+	case "json":
+		var b strings.Builder
+		r.WriteJSON(&b)
+		return strings.TrimSuffix(b.String(), "\n")
+	case "all":
+		return r.Timeline() + "\n\n" + r.Mermaid() + "\n\n" + r.Render("json")
+	default:
+		return r.Timeline()
+	}
+}
+
+// failureReporter is the part of *testing.T a Recorder needs to report on failure
+type failureReporter interface {
+	Failed() bool
+	Logf(format string, args ...any)
+}
+
+// DumpOnFailure prints the recording in the given format when t finishes, if t failed. Tests that
+// do not expose Failed and Logf, as *testing.T does, always have it printed to stderr.
+func (r *Recorder) DumpOnFailure(t TB, format string) {
+	t.Helper()
+	t.Cleanup(func() {
+		t.Helper()
+		out := "recorded mock calls:\n" + r.Render(format)
+		if f, ok := t.(failureReporter); ok {
+			if f.Failed() {
+				f.Logf("%s", out)
+			}
+			return
+		}
+		if f, ok := t.(interface{ Failed() bool }); ok && !f.Failed() {
+			return
+		}
+		fmt.Fprintln(os.Stderr, out)
+	})
+}
+
+// RecordingEnabled reports whether STUBS_RECORD is set
+func RecordingEnabled() bool {
+	return os.Getenv(RecordEnv) != ""
+}
+
+// recordFormat returns the format named by STUBS_RECORD
+func recordFormat() string {
+	switch v := os.Getenv(RecordEnv); v {
+	case "mermaid", "json", "all":
+		return v
+	default:
+		return "timeline"
+	}
+}
+
+var testRecorders = struct {
+	sync.Mutex
+	m map[TB]*Recorder
+}{m: map[TB]*Recorder{}}
+
+// RecorderFor returns the Recorder shared by every mock recording for t, creating it on first use.
+// It is printed in the format named by STUBS_RECORD if t fails.
+func RecorderFor(t TB) *Recorder {
+	t.Helper()
+	testRecorders.Lock()
+	defer testRecorders.Unlock()
+	if r, ok := testRecorders.m[t]; ok {
+		return r
+	}
+	r := NewRecorder()
+	testRecorders.m[t] = r
+	t.Cleanup(func() {
+		testRecorders.Lock()
+		defer testRecorders.Unlock()
+		delete(testRecorders.m, t)
+	})
+	r.DumpOnFailure(t, recordFormat())
+	return r
+}
+
+// AttachRecorder records every call to the method in rec under mockName.methodName
+func (m *MethodConfig[T]) AttachRecorder(rec *Recorder, mockName, methodName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.recorder = rec
+	m.mockName = mockName
+	m.name = methodName
+}
+
+// recording ties a call in flight to its entry in a Recorder
+type recording struct {
+	rec   *Recorder
+	index int
+	gen   uint64
+}
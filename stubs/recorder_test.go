@@ -0,0 +1,211 @@
+package stubs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordedMethods returns two methods attached to rec that use clock
+func recordedMethods(rec *Recorder, clock Clock) (load *MethodConfig[func([]string) (int, error)], honk *MethodConfig[func()]) {
+	load, honk = &MethodConfig[func([]string) (int, error)]{}, &MethodConfig[func()]{}
+	load.SetClock(clock)
+	honk.SetClock(clock)
+	load.AttachRecorder(rec, "Vehicle", "LoadCargo")
+	honk.AttachRecorder(rec, "Horn", "Honk")
+	return load, honk
+}
+
+func TestRecorderCapturesInterleavedCalls(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := NewRecorder()
+	load, honk := recordedMethods(rec, clock)
+
+	first := load.RecordCall([]string{"a"})
+	clock.Advance(time.Millisecond)
+	second := honk.RecordCall()
+	clock.Advance(time.Millisecond)
+	load.RecordResults(first, 1, nil)
+	load.EndCall(first)
+	honk.EndCall(second) // no results: the call panicked
+	third := load.RecordCall([]string{"b", "c"})
+
+	calls := rec.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	if c := calls[0]; c.Name() != "Vehicle.LoadCargo" || !c.Returned || c.Duration() != 2*time.Millisecond || c.Results[0] != 1 {
+		t.Fatalf("unexpected first call %+v", c)
+	}
+	if c := calls[1]; c.Returned || !c.Finished() || c.String() != "#2 Horn.Honk() -> panicked" {
+		t.Fatalf("expected the second call to have panicked, got %s", c)
+	}
+	if c := calls[2]; c.Finished() || c.String() != `#3 Vehicle.LoadCargo([]string{"b", "c"}) -> still running` {
+		t.Fatalf("expected the third call to be running, got %s", c)
+	}
+	if calls[0].Goroutine == 0 || calls[0].Goroutine != calls[1].Goroutine {
+		t.Fatalf("expected the calling goroutine to be recorded, got %d and %d", calls[0].Goroutine, calls[1].Goroutine)
+	}
+	load.EndCall(third)
+}
+
+func TestRecorderTimeline(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := NewRecorder()
+	load, _ := recordedMethods(rec, clock)
+
+	first := load.RecordCall([]string{"a"})
+	clock.Advance(time.Millisecond)
+	second := load.RecordCall([]string{"b"})
+	clock.Advance(time.Millisecond)
+	load.RecordResults(first, 1, nil)
+	load.EndCall(first)
+	load.RecordResults(second, 0, errors.New("full"))
+	clock.Advance(2 * time.Millisecond)
+	load.EndCall(second)
+
+	lines := strings.Split(rec.Timeline(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a line per call, got:\n%s", rec.Timeline())
+	}
+	for i, want := range []string{
+		"|===============               |  Vehicle.LoadCargo([]string{\"a\"}) -> 1, <nil>",
+		"|       =======================|  Vehicle.LoadCargo([]string{\"b\"}) -> 0, &errors.errorString{s:\"full\"}",
+	} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("expected line %d to contain %q, got %q", i, want, lines[i])
+		}
+	}
+	if !strings.Contains(lines[1], "+1ms") || !strings.Contains(lines[1], "3ms") {
+		t.Errorf("expected the offset and duration of the second call, got %q", lines[1])
+	}
+}
+
+func TestRecorderMermaid(t *testing.T) {
+	rec := NewRecorder()
+	load, honk := recordedMethods(rec, NewFakeClock(time.Time{}))
+
+	first := load.RecordCall([]string{"a;b"})
+	second := honk.RecordCall()
+	load.RecordResults(first, 1, nil)
+	load.EndCall(first)
+	honk.RecordResults(second)
+	honk.EndCall(second)
+	load.RecordCall([]string{"c"})
+
+	got := rec.Mermaid()
+	g := rec.Calls()[0].Goroutine
+	var want []string
+	for _, line := range []string{
+		"sequenceDiagram",
+		"    participant g%[1]d as goroutine %[1]d",
+		"    participant Vehicle",
+		"    participant Horn",
+		`    g%[1]d->>+Vehicle: #1 LoadCargo([]string{"a#59;b"})`,
+		"    g%[1]d->>+Horn: #2 Honk()",
+		"    Vehicle-->>-g%[1]d: #1 1, <nil> [0s]",
+		"    Horn-->>-g%[1]d: #2 returned [0s]",
+		`    g%[1]d->>+Vehicle: #3 LoadCargo([]string{"c"})`,
+		"    Note over Vehicle: #3 LoadCargo still running",
+	} {
+		want = append(want, strings.ReplaceAll(line, "%[1]d", strconv.FormatUint(g, 10)))
+	}
+	if got != strings.Join(want, "\n") {
+		t.Fatalf("unexpected diagram:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestRecorderJSON(t *testing.T) {
+	rec := NewRecorder()
+	load, _ := recordedMethods(rec, NewFakeClock(time.Time{}))
+	id := load.RecordCall([]string{"a"})
+	load.RecordResults(id, 0, errors.New("full"))
+	load.EndCall(id)
+	ch := &MethodConfig[func(chan int)]{}
+	ch.AttachRecorder(rec, "Vehicle", "Listen")
+	ch.RecordCall(make(chan int))
+
+	var b strings.Builder
+	if err := rec.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("invalid JSON %v:\n%s", err, b.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(got))
+	}
+	if r := got[0]["results"].([]any); r[1] != "full" || got[0]["returned"] != true {
+		t.Fatalf("expected errors to be written as their message, got %v", got[0])
+	}
+	if a := got[1]["args"].([]any); !strings.HasPrefix(a[0].(string), "(chan int)") || got[1]["end"] != nil {
+		t.Fatalf("expected a value JSON cannot encode as Go syntax and no end, got %v", got[1])
+	}
+}
+
+func TestRecorderResetDropsCallsInFlight(t *testing.T) {
+	rec := NewRecorder()
+	load, _ := recordedMethods(rec, nil)
+	id := load.RecordCall([]string{"a"})
+	rec.Reset()
+	next := load.RecordCall([]string{"b"})
+	load.RecordResults(id, 1, nil)
+	load.EndCall(id)
+	if c := rec.Calls()[0]; c.Finished() || c.Args[0].([]string)[0] != "b" {
+		t.Fatalf("expected the call started before Reset not to touch later entries, got %s", c)
+	}
+	load.EndCall(next)
+}
+
+func TestRecorderDetachedByReset(t *testing.T) {
+	rec := NewRecorder()
+	load, _ := recordedMethods(rec, nil)
+	load.Reset()
+	load.RecordCall([]string{"a"})
+	if len(rec.Calls()) != 0 {
+		t.Fatal("expected Reset to detach the recorder")
+	}
+}
+
+// failingT is a fakeT that reports failures and collects logs, like *testing.T
+type failingT struct {
+	fakeT
+	logs []string
+}
+
+func (f *failingT) Failed() bool { return f.failed || len(f.errors) > 0 }
+
+func (f *failingT) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func TestRecorderFor(t *testing.T) {
+	t.Setenv(RecordEnv, "mermaid")
+	ft := &failingT{}
+	rec := RecorderFor(ft)
+	if RecorderFor(ft) != rec {
+		t.Fatal("expected the recorder to be shared for the same test")
+	}
+	load, _ := recordedMethods(rec, nil)
+	load.RecordCall([]string{"a"})
+	ft.Errorf("boom")
+	ft.finish()
+	if len(ft.logs) != 1 || !strings.Contains(ft.logs[0], "recorded mock calls:\nsequenceDiagram") {
+		t.Fatalf("expected the diagram to be logged on failure, got %q", ft.logs)
+	}
+
+	passing := &failingT{}
+	RecorderFor(passing)
+	passing.finish()
+	if len(passing.logs) != 0 {
+		t.Fatalf("expected nothing logged for a passing test, got %q", passing.logs)
+	}
+	if RecorderFor(ft) == rec {
+		t.Fatal("expected a new recorder once the test finished")
+	}
+}
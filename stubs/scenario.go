@@ -0,0 +1,381 @@
+package stubs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Scenario describes mock behaviour per method, so responses can be kept in YAML or JSON files
+// instead of Go. For example:
+//
+//	methods:
+//	  LoadCargo:
+//	    responses:            # queued, used in order
+//	      - returns: [3, null]
+//	        delay: 50ms
+//	      - error: "cargo hold full"
+//	    when:                 # once the queue is empty, the first rule whose args match answers
+//	      - args: {items: [piano]}
+//	        panic: "too heavy"
+//	    default:              # answers calls no rule matched
+//	      returns: {loaded: 0}
+//
+// Values are decoded into the method's param types. returns and args take a list in param order or
+// a map keyed by YAML param name; args that are left out match anything. A method with a single
+// output also takes the value on its own. Errors are given as messages.
+type Scenario struct {
+	Methods map[string]ScenarioMethod `yaml:"methods"`
+}
+
+// ScenarioMethod is the behaviour of a single method in a Scenario
+type ScenarioMethod struct {
+	Responses []ScenarioResponse `yaml:"responses"`
+	When      []ScenarioResponse `yaml:"when"`
+	Default   *ScenarioResponse  `yaml:"default"`
+}
+
+// ScenarioResponse is one response of a ScenarioMethod
+type ScenarioResponse struct {
+	// Args restricts a when rule to calls with these arguments
+	Args any `yaml:"args"`
+	// Returns holds the output values. Outputs left out are zero.
+	Returns any `yaml:"returns"`
+	// Error is returned in the method's error output
+	Error string `yaml:"error"`
+	// Panic makes the call panic with this value
+	Panic any `yaml:"panic"`
+	// Delay is a duration such as 50ms that the call sleeps for on the mock's clock
+	Delay string `yaml:"delay"`
+}
+
+// LoadScenario reads a Scenario from a YAML or JSON file
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	sc, err := ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return sc, nil
+}
+
+// ParseScenario decodes a Scenario from YAML or JSON
+func ParseScenario(data []byte) (*Scenario, error) {
+	var sc Scenario
+	if err := yaml.UnmarshalStrict(data, &sc); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	return &sc, nil
+}
+
+// Apply configures each named method with its apply func, usually the generated mock's methods.
+// It fails if the scenario names a method that apply does not have.
+func (s *Scenario) Apply(apply map[string]func(ScenarioMethod) error) error {
+	names := make([]string, 0, len(s.Methods))
+	for name := range s.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		fn, ok := apply[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: no such method", name))
+			continue
+		}
+		if err := fn(s.Methods[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyScenario enables the mock and replaces its queue and fallback with the scenario's responses.
+// A fallback set earlier is cleared even when the scenario has no when rules or default.
+// inputs and outputs are the YAML param names, used to decode values given as maps.
+func (m *MethodConfig[T]) ApplyScenario(name string, sm ScenarioMethod, inputs, outputs []string) error {
+	fnType := reflect.TypeOf((*T)(nil)).Elem()
+	if fnType.Kind() != reflect.Func {
+		return fmt.Errorf("%s: scenarios need a func response type, got %s", name, fnType)
+	}
+	d := scenarioDecoder{fnType: fnType, inputs: inputs, outputs: outputs}
+
+	var errs []error
+	queue := make([]scenarioResponse, len(sm.Responses))
+	for i, r := range sm.Responses {
+		path := fmt.Sprintf("%s.responses[%d]", name, i)
+		if r.Args != nil {
+			errs = append(errs, fmt.Errorf("%s.args: args are only used by when rules", path))
+		}
+		var err error
+		if queue[i], err = d.response(path, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	rules := make([]scenarioResponse, len(sm.When))
+	for i, r := range sm.When {
+		var err error
+		if rules[i], err = d.response(fmt.Sprintf("%s.when[%d]", name, i), r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	var def *scenarioResponse
+	if sm.Default != nil {
+		path := name + ".default"
+		if sm.Default.Args != nil {
+			errs = append(errs, fmt.Errorf("%s.args: args are only used by when rules", path))
+		}
+		r, err := d.response(path, *sm.Default)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		def = &r
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	m.ResetQueue()
+	for _, r := range queue {
+		m.EnqueueWithDelay(m.scenarioFunc(fnType, []scenarioResponse{r}, nil, false), r.delay)
+	}
+	if len(rules) > 0 || def != nil {
+		m.SetResponseFunc(m.scenarioFunc(fnType, rules, def, true))
+	} else {
+		m.clearFallback()
+	}
+	m.Enable()
+	return nil
+}
+
+// scenarioFunc builds a response from the first response whose args match, then def, then zero values.
+// Delays are applied by the queue unless sleep is set.
+func (m *MethodConfig[T]) scenarioFunc(fnType reflect.Type, rs []scenarioResponse, def *scenarioResponse, sleep bool) T {
+	respond := func(r scenarioResponse) []reflect.Value {
+		if sleep && r.delay > 0 {
+			m.Clock().Sleep(r.delay)
+		}
+		if r.panics {
+			panic(r.panicValue)
+		}
+		return append([]reflect.Value(nil), r.outs...)
+	}
+	fn := reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		for _, r := range rs {
+			if r.matches(in) {
+				return respond(r)
+			}
+		}
+		if def != nil {
+			return respond(*def)
+		}
+		outs := make([]reflect.Value, fnType.NumOut())
+		for i := range outs {
+			outs[i] = reflect.Zero(fnType.Out(i))
+		}
+		return outs
+	})
+	return fn.Interface().(T)
+}
+
+// scenarioResponse is a ScenarioResponse decoded into the method's param types
+type scenarioResponse struct {
+	// args holds an invalid Value for arguments that match anything
+	args       []reflect.Value
+	outs       []reflect.Value
+	panics     bool
+	panicValue any
+	delay      time.Duration
+}
+
+func (r scenarioResponse) matches(in []reflect.Value) bool {
+	for i, want := range r.args {
+		if want.IsValid() && !reflect.DeepEqual(want.Interface(), in[i].Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+type scenarioDecoder struct {
+	fnType  reflect.Type
+	inputs  []string
+	outputs []string
+}
+
+func (d scenarioDecoder) response(path string, r ScenarioResponse) (scenarioResponse, error) {
+	var out scenarioResponse
+	var err error
+
+	in := make([]reflect.Type, d.fnType.NumIn())
+	for i := range in {
+		in[i] = d.fnType.In(i)
+	}
+	if r.Args != nil {
+		if out.args, err = decodeParams(path+".args", r.Args, in, d.inputs, false); err != nil {
+			return out, err
+		}
+	}
+
+	outTypes := make([]reflect.Type, d.fnType.NumOut())
+	for i := range outTypes {
+		outTypes[i] = d.fnType.Out(i)
+	}
+	if out.outs, err = decodeParams(path+".returns", r.Returns, outTypes, d.outputs, true); err != nil {
+		return out, err
+	}
+
+	if r.Error != "" {
+		idx := -1
+		for i, t := range outTypes {
+			if t == errorType {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			return out, fmt.Errorf("%s.error: method has no error output", path)
+		}
+		out.outs[idx] = errorValue(r.Error)
+	}
+
+	if r.Panic != nil {
+		out.panics = true
+		out.panicValue = r.Panic
+	}
+
+	if r.Delay != "" {
+		if out.delay, err = time.ParseDuration(r.Delay); err != nil {
+			return out, fmt.Errorf("%s.delay: %w", path, err)
+		}
+	}
+	return out, nil
+}
+
+// decodeParams decodes raw, a list in param order or a map keyed by param name, into values of types.
+// Params left out are zero when zeroMissing is set and invalid otherwise.
+func decodeParams(path string, raw any, types []reflect.Type, names []string, zeroMissing bool) ([]reflect.Value, error) {
+	values := make([]reflect.Value, len(types))
+	set := func(i int, label string, v any) error {
+		decoded, err := decodeValue(v, types[i])
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", path, label, err)
+		}
+		values[i] = decoded
+		return nil
+	}
+
+	// a map for a single param is only keyed by name if that is its one key, otherwise it is the value
+	if m, ok := raw.(map[any]any); ok && len(types) == 1 {
+		if _, byName := m[paramLabel(names, 0)]; !byName || len(m) != 1 {
+			raw = []any{raw}
+		}
+	}
+
+	switch raw := raw.(type) {
+	case nil:
+	case map[any]any:
+		for key, v := range raw {
+			name := fmt.Sprint(key)
+			i := indexOf(names, name)
+			if i < 0 {
+				return nil, fmt.Errorf("%s.%s: no such param, expected one of %s", path, name, strings.Join(nonEmpty(names), ", "))
+			}
+			if err := set(i, name, v); err != nil {
+				return nil, err
+			}
+		}
+	case []any:
+		if len(raw) != len(types) {
+			return nil, fmt.Errorf("%s: expected %d values, got %d", path, len(types), len(raw))
+		}
+		for i, v := range raw {
+			if err := set(i, paramLabel(names, i), v); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if len(types) != 1 {
+			return nil, fmt.Errorf("%s: expected a list or map of %d values, got %v", path, len(types), raw)
+		}
+		if err := set(0, paramLabel(names, 0), raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if zeroMissing {
+		for i, v := range values {
+			if !v.IsValid() {
+				values[i] = reflect.Zero(types[i])
+			}
+		}
+	}
+	return values, nil
+}
+
+// decodeValue converts a decoded YAML value into t by round-tripping it through YAML.
+// Strings are accepted for error values.
+func decodeValue(v any, t reflect.Type) (reflect.Value, error) {
+	if t == errorType {
+		switch v := v.(type) {
+		case nil:
+			return reflect.Zero(t), nil
+		case string:
+			return errorValue(v), nil
+		default:
+			return reflect.Value{}, fmt.Errorf("cannot decode %v as error, expected a message", v)
+		}
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	ptr := reflect.New(t)
+	if err := yaml.UnmarshalStrict(data, ptr.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot decode %v as %s: %w", v, t, err)
+	}
+	return ptr.Elem(), nil
+}
+
+// errorValue returns an error with msg as a Value of the error interface type
+func errorValue(msg string) reflect.Value {
+	v := reflect.New(errorType).Elem()
+	v.Set(reflect.ValueOf(errors.New(msg)))
+	return v
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n != "" && n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func nonEmpty(names []string) []string {
+	var out []string
+	for _, n := range names {
+		if n != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// paramLabel names a param for error messages, falling back to its position
+func paramLabel(names []string, i int) string {
+	if i < len(names) && names[i] != "" {
+		return names[i]
+	}
+	return fmt.Sprintf("[%d]", i)
+}
@@ -0,0 +1,152 @@
+package stubs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type scenarioStatus struct {
+	Speed  int    `yaml:"speed"`
+	Status string `yaml:"status"`
+}
+
+func mustParseScenario(t *testing.T, src string) *Scenario {
+	t.Helper()
+	sc, err := ParseScenario([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseScenario: %v", err)
+	}
+	return sc
+}
+
+func TestScenarioQueueRulesAndDefault(t *testing.T) {
+	sc := mustParseScenario(t, `
+methods:
+  LoadCargo:
+    responses:
+      - returns: [3, null]
+      - error: cargo hold full
+    when:
+      - args: {items: [piano]}
+        panic: too heavy
+    default:
+      returns: {loaded: 1}
+`)
+	var m MethodConfig[func([]string) (int, error)]
+	err := sc.Apply(map[string]func(ScenarioMethod) error{
+		"LoadCargo": func(sm ScenarioMethod) error {
+			return m.ApplyScenario("LoadCargo", sm, []string{"items"}, []string{"loaded", "err"})
+		},
+	})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !m.IsEnabled() {
+		t.Fatal("expected the scenario to enable the mock")
+	}
+
+	call := func(items ...string) (int, error) { return m.NextResponse(nil)(items) }
+	if n, err := call("a"); n != 3 || err != nil {
+		t.Fatalf("expected first queued response (3, nil), got (%d, %v)", n, err)
+	}
+	if _, err := call("a"); err == nil || err.Error() != "cargo hold full" {
+		t.Fatalf("expected queued error, got %v", err)
+	}
+	if n, err := call("a"); n != 1 || err != nil {
+		t.Fatalf("expected default response (1, nil), got (%d, %v)", n, err)
+	}
+	MustPanic(t, func() { call("piano") })
+}
+
+func TestScenarioClearsEarlierFallback(t *testing.T) {
+	sc := mustParseScenario(t, `
+methods:
+  LoadCargo:
+    responses:
+      - returns: [3, null]
+`)
+	var m MethodConfig[func([]string) (int, error)]
+	m.SetResponseFunc(func([]string) (int, error) { return 99, nil })
+	if err := m.ApplyScenario("LoadCargo", sc.Methods["LoadCargo"], []string{"items"}, []string{"loaded", "err"}); err != nil {
+		t.Fatalf("ApplyScenario: %v", err)
+	}
+
+	call := func() (int, error) { return m.NextResponse(func([]string) (int, error) { return 0, nil })(nil) }
+	if n, _ := call(); n != 3 {
+		t.Fatalf("expected the queued response 3, got %d", n)
+	}
+	if n, _ := call(); n != 0 {
+		t.Fatalf("expected zero values once the queue is empty, not the earlier fallback, got %d", n)
+	}
+}
+
+func TestScenarioDelayUsesClock(t *testing.T) {
+	sc := mustParseScenario(t, `{"methods": {"Status": {"default": {"returns": {"speed": 50, "status": "cruising"}, "delay": "1s"}}}}`)
+	clock := NewFakeClock(time.Unix(0, 0))
+	var m MethodConfig[func() scenarioStatus]
+	m.SetClock(clock)
+	if err := m.ApplyScenario("Status", sc.Methods["Status"], nil, []string{"status"}); err != nil {
+		t.Fatalf("ApplyScenario: %v", err)
+	}
+
+	done := make(chan scenarioStatus, 1)
+	go func() { done <- m.NextResponse(nil)() }()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	got := WaitForResult(t, done, time.Second)
+	if got != (scenarioStatus{Speed: 50, Status: "cruising"}) {
+		t.Fatalf("unexpected struct decoded from JSON scenario: %+v", got)
+	}
+}
+
+func TestScenarioDecodeErrorsNameMethodAndField(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "wrong output type",
+			src:  "methods: {Accelerate: {responses: [{returns: {newSpeed: fast}}]}}",
+			want: "Accelerate.responses[0].returns.newSpeed: cannot decode fast as int",
+		},
+		{
+			name: "unknown param",
+			src:  "methods: {Accelerate: {when: [{args: {speeed: 1}}]}}",
+			want: "Accelerate.when[0].args.speeed: no such param, expected one of speed, unit",
+		},
+		{
+			name: "bad delay",
+			src:  "methods: {Accelerate: {default: {delay: soon}}}",
+			want: "Accelerate.default.delay:",
+		},
+		{
+			name: "unknown method",
+			src:  "methods: {Fly: {default: {}}}",
+			want: "Fly: no such method",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m MethodConfig[func(int, string) (int, error)]
+			err := mustParseScenario(t, tt.src).Apply(map[string]func(ScenarioMethod) error{
+				"Accelerate": func(sm ScenarioMethod) error {
+					return m.ApplyScenario("Accelerate", sm, []string{"speed", "unit"}, []string{"newSpeed", "err"})
+				},
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			if m.IsEnabled() {
+				t.Fatal("expected an invalid scenario to leave the mock untouched")
+			}
+		})
+	}
+}
+
+func TestParseScenarioRejectsUnknownKeys(t *testing.T) {
+	if _, err := ParseScenario([]byte("methods: {LoadCargo: {respones: []}}")); err == nil {
+		t.Fatal("expected a misspelt key to be rejected")
+	}
+}
@@ -0,0 +1,165 @@
+package stubs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SequencedCall is a call recorded by a Sequence, tagged with the mock and method it was made on
+type SequencedCall struct {
+	Seq    uint64
+	Mock   string
+	Method string
+	Call   MethodCall
+}
+
+// Name returns the call in "Mock.Method" form
+func (c SequencedCall) Name() string {
+	return c.Mock + "." + c.Method
+}
+
+// matches reports whether the call satisfies a step of the form "Method" or "Mock.Method"
+func (c SequencedCall) matches(step string) bool {
+	if mock, method, ok := strings.Cut(step, "."); ok {
+		return c.Mock == mock && c.Method == method
+	}
+	return c.Method == step
+}
+
+func (c SequencedCall) String() string {
+	args := make([]string, len(c.Call.Args))
+	for i, arg := range c.Call.Args {
+		args[i] = fmt.Sprintf("%#v", arg)
+	}
+	return fmt.Sprintf("#%d %s(%s)", c.Seq, c.Name(), strings.Join(args, ", "))
+}
+
+// Sequence records calls across one or more mocks and gives each a global sequence number.
+// Attach it to a generated mock with attachSequence.
+type Sequence struct {
+	mu    sync.Mutex
+	next  uint64
+	calls []SequencedCall
+}
+
+// NewSequence returns an empty Sequence
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// record appends a call and returns its sequence number
+func (s *Sequence) record(mock, method string, call MethodCall) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	call.Seq = s.next
+	s.calls = append(s.calls, SequencedCall{Seq: s.next, Mock: mock, Method: method, Call: call})
+	return s.next
+}
+
+// Calls returns every call recorded so far in sequence order
+func (s *Sequence) Calls() []SequencedCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SequencedCall(nil), s.calls...)
+}
+
+// Reset clears recorded calls and restarts numbering
+func (s *Sequence) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = 0
+	s.calls = nil
+}
+
+// String renders the recorded calls one per line
+func (s *Sequence) String() string {
+	calls := s.Calls()
+	if len(calls) == 0 {
+		return "  (no calls recorded)"
+	}
+	lines := make([]string, len(calls))
+	for i, c := range calls {
+		lines[i] = "  " + c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// first returns the earliest call matching step
+func first(calls []SequencedCall, step string) (SequencedCall, bool) {
+	for _, c := range calls {
+		if c.matches(step) {
+			return c, true
+		}
+	}
+	return SequencedCall{}, false
+}
+
+// InOrder asserts that the steps were called in the given order. Other calls may be interleaved.
+// A step is either "Method" (any attached mock) or "Mock.Method".
+func InOrder(t TB, seq *Sequence, steps ...string) {
+	t.Helper()
+	calls := seq.Calls()
+	pos := 0
+	for i, step := range steps {
+		found := false
+		for ; pos < len(calls); pos++ {
+			if calls[pos].matches(step) {
+				found = true
+				pos++
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if _, ok := first(calls, step); !ok {
+			t.Fatalf("expected call order %s\n%q was never called\nrecorded sequence:\n%s", strings.Join(steps, " -> "), step, seq)
+			return
+		}
+		t.Fatalf("expected call order %s\n%q was not called after %q\nrecorded sequence:\n%s", strings.Join(steps, " -> "), step, steps[i-1], seq)
+		return
+	}
+}
+
+// PartialOrder asserts that every step in a group was first called before any step in the next group.
+// Order within a group is not checked, e.g.
+//
+//	stubs.PartialOrder(t, seq, []string{"DriveSelf"}, []string{"ParkSelf", "LockDoors"})
+func PartialOrder(t TB, seq *Sequence, groups ...[]string) {
+	t.Helper()
+	calls := seq.Calls()
+
+	firsts := make([][]SequencedCall, len(groups))
+	for g, group := range groups {
+		for _, step := range group {
+			c, ok := first(calls, step)
+			if !ok {
+				t.Fatalf("expected partial order %s\n%q was never called\nrecorded sequence:\n%s", formatGroups(groups), step, seq)
+				return
+			}
+			firsts[g] = append(firsts[g], c)
+		}
+	}
+
+	for g := 1; g < len(groups); g++ {
+		for bi, before := range firsts[g-1] {
+			for ai, after := range firsts[g] {
+				if before.Seq > after.Seq {
+					t.Fatalf("expected partial order %s\n%q (#%d) was called before %q (#%d)\nrecorded sequence:\n%s",
+						formatGroups(groups), groups[g][ai], after.Seq, groups[g-1][bi], before.Seq, seq)
+					return
+				}
+			}
+		}
+	}
+}
+
+func formatGroups(groups [][]string) string {
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		parts[i] = "{" + strings.Join(g, ", ") + "}"
+	}
+	return strings.Join(parts, " -> ")
+}
@@ -0,0 +1,107 @@
+package stubs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeT records failures instead of stopping the test. Errorf does not mark it failed.
+type fakeT struct {
+	failed   bool
+	msg      string
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+// finish runs the cleanups as the testing package does when a test ends
+func (f *fakeT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func (f *fakeT) Fatal(args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprint(args...)
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func newRecordedSequence(calls ...string) *Sequence {
+	seq := NewSequence()
+	configs := map[string]*MethodConfig[func()]{}
+	for _, name := range calls {
+		mock, method, _ := strings.Cut(name, ".")
+		if configs[name] == nil {
+			configs[name] = &MethodConfig[func()]{}
+			configs[name].AttachSequence(seq, mock, method)
+		}
+		configs[name].RecordCall()
+	}
+	return seq
+}
+
+func TestInOrder(t *testing.T) {
+	seq := newRecordedSequence("Car.Start", "Car.Honk", "Robo.Start", "Car.Stop")
+
+	tests := []struct {
+		name    string
+		steps   []string
+		wantErr string
+	}{
+		{name: "subsequence", steps: []string{"Car.Start", "Car.Stop"}},
+		{name: "method only", steps: []string{"Start", "Honk", "Stop"}},
+		{name: "repeated method", steps: []string{"Start", "Start"}},
+		{name: "out of order", steps: []string{"Stop", "Honk"}, wantErr: `"Honk" was not called after "Stop"`},
+		{name: "never called", steps: []string{"Start", "Park"}, wantErr: `"Park" was never called`},
+		{name: "wrong mock", steps: []string{"Robo.Honk"}, wantErr: `"Robo.Honk" was never called`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			InOrder(ft, seq, tt.steps...)
+			if tt.wantErr == "" {
+				if ft.failed {
+					t.Fatalf("unexpected failure: %s", ft.msg)
+				}
+				return
+			}
+			if !strings.Contains(ft.msg, tt.wantErr) {
+				t.Fatalf("expected failure containing %q, got %q", tt.wantErr, ft.msg)
+			}
+			if !strings.Contains(ft.msg, "#4 Car.Stop()") {
+				t.Fatalf("expected recorded sequence in failure, got %q", ft.msg)
+			}
+		})
+	}
+}
+
+func TestPartialOrder(t *testing.T) {
+	seq := newRecordedSequence("Car.Drive", "Car.Lock", "Car.Park", "Car.Close")
+
+	ft := &fakeT{}
+	PartialOrder(ft, seq, []string{"Drive"}, []string{"Park", "Lock"}, []string{"Close"})
+	if ft.failed {
+		t.Fatalf("unexpected failure: %s", ft.msg)
+	}
+
+	ft = &fakeT{}
+	PartialOrder(ft, seq, []string{"Drive", "Park"}, []string{"Lock"})
+	if !strings.Contains(ft.msg, `"Lock" (#2) was called before "Park" (#3)`) {
+		t.Fatalf("unexpected failure message: %q", ft.msg)
+	}
+}
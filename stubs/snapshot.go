@@ -0,0 +1,81 @@
+package stubs
+
+// MethodSnapshot is a point-in-time copy of a MethodConfig's configuration and spy calls
+type MethodSnapshot[T any] struct {
+	enabled    bool
+	spyEnabled bool
+	queue      []QueuedItem[T]
+	fallback   interface{}
+	spyCalls   []MethodCall
+	faults     *faultInjector
+	gate       *Gate
+	noClone    bool
+	sequence   *Sequence
+	recorder   *Recorder
+	mockName   string
+	name       string
+	version    uint64
+}
+
+// Snapshot captures the current configuration and spy calls
+func (m *MethodConfig[T]) Snapshot() MethodSnapshot[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return MethodSnapshot[T]{
+		enabled:    m.enabled,
+		spyEnabled: m.spyEnabled,
+		queue:      append([]QueuedItem[T](nil), m.queue...),
+		fallback:   m.fallback,
+		spyCalls:   append([]MethodCall(nil), m.spyCalls...),
+		faults:     m.faults,
+		gate:       m.gate,
+		noClone:    m.noClone,
+		sequence:   m.sequence,
+		recorder:   m.recorder,
+		mockName:   m.mockName,
+		name:       m.name,
+		version:    m.version,
+	}
+}
+
+// Restore returns the method to the state captured by s. The clock is left unchanged.
+// Calls held at a gate set since s was taken are released.
+func (m *MethodConfig[T]) Restore(s MethodSnapshot[T]) {
+	m.mu.Lock()
+	old := m.gate
+	defer func() { releaseReplacedGate(old, s.gate) }()
+	defer m.mu.Unlock()
+	m.version++
+	m.countEnable(s.enabled)
+	m.countDiscarded(len(m.queue))
+	m.countQueued(len(s.queue))
+	m.enabled = s.enabled
+	m.spyEnabled = s.spyEnabled
+	m.queue = append([]QueuedItem[T](nil), s.queue...)
+	m.fallback = s.fallback
+	m.spyCalls = append([]MethodCall(nil), s.spyCalls...)
+	m.faults = s.faults
+	m.gate = s.gate
+	m.noClone = s.noClone
+	m.sequence = s.sequence
+	m.recorder = s.recorder
+	m.mockName = s.mockName
+	m.name = s.name
+}
+
+// Reset disables the mock and spy, re-enables argument cloning and clears the queue, fallback, spy calls, faults, gate,
+// sequence and recorder, and stops recording to a golden file.
+// Concurrency stats are cleared too. The clock is left unchanged.
+func (m *MethodConfig[T]) Reset() {
+	m.Restore(MethodSnapshot[T]{})
+	m.setObserver(nil)
+	m.ResetConcurrencyStats()
+}
+
+// ModifiedSince reports whether the configuration has changed since s was taken.
+// Recording calls and consuming queued responses do not count as changes.
+func (m *MethodConfig[T]) ModifiedSince(s MethodSnapshot[T]) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.version != s.version
+}
@@ -0,0 +1,67 @@
+package stubs
+
+import (
+	"testing"
+)
+
+func TestMethodConfigSnapshotRestore(t *testing.T) {
+	var m MethodConfig[func() int]
+	m.Enable()
+	m.EnableSpy()
+	m.SetResponseFunc(func() int { return 1 })
+	m.EnqueueWithDelay(func() int { return 2 }, 0)
+	m.RecordCall()
+
+	snap := m.Snapshot()
+	if m.ModifiedSince(snap) {
+		t.Fatal("expected no modification straight after snapshot")
+	}
+
+	// recording and consuming are not configuration changes
+	m.RecordCall()
+	if got := m.NextResponse(nil)(); got != 2 {
+		t.Fatalf("expected queued response 2, got %d", got)
+	}
+	if m.ModifiedSince(snap) {
+		t.Fatal("recording calls should not count as a modification")
+	}
+
+	m.Disable()
+	m.SetResponseFunc(func() int { return 3 })
+	if !m.ModifiedSince(snap) {
+		t.Fatal("expected modification after reconfiguring")
+	}
+
+	m.Restore(snap)
+	if !m.IsEnabled() || m.PeekQueueLength() != 1 || m.CallCount() != 1 {
+		t.Fatalf("restore did not bring back snapshot state: enabled=%v queue=%d calls=%d", m.IsEnabled(), m.PeekQueueLength(), m.CallCount())
+	}
+	m.ResetQueue()
+	if got := m.NextResponse(nil)(); got != 1 {
+		t.Fatalf("expected restored fallback 1, got %d", got)
+	}
+
+	m.Reset()
+	if m.IsEnabled() || m.IsSpyEnabled() || m.CallCount() != 0 {
+		t.Fatal("reset did not clear state")
+	}
+	if got := m.NextResponse(func() int { return 4 })(); got != 4 {
+		t.Fatalf("expected default response after reset, got %d", got)
+	}
+}
+
+func TestBroadcasterUnsubscribeAfter(t *testing.T) {
+	var b Broadcaster[int]
+	kept := b.Subscribe(SubscribeOptions{Buffer: 1})
+	mark := b.Mark()
+	dropped := b.Subscribe(SubscribeOptions{Buffer: 1})
+
+	b.UnsubscribeAfter(mark)
+	if _, ok := <-dropped.C; ok {
+		t.Fatal("expected later subscription to be closed")
+	}
+	b.Publish(1)
+	if got := <-kept.C; got != 1 {
+		t.Fatalf("expected earlier subscription to keep receiving, got %d", got)
+	}
+}
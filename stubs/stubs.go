@@ -1,7 +1,9 @@
 package stubs
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
@@ -9,28 +11,237 @@ import (
 type MethodCall struct {
 	Timestamp time.Time
 	Args      []any
+	// Seq is the global sequence number of the call, or 0 if no Sequence is attached
+	Seq uint64
+	// Results holds the values the call returned. It is nil while the call is running or if it panicked.
+	Results []any
+	// Returned reports whether the call has returned
+	Returned bool
+
+	id uint64
 }
 
+// MethodConfig holds the mock and spy state of a single method. All methods are safe for concurrent use,
+// and no lock is held while a response function runs.
 type MethodConfig[T any] struct {
-	Enabled    bool
-	SpyEnabled bool
 	mu         sync.Mutex
+	enabled    bool
+	spyEnabled bool
 	queue      []QueuedItem[T]
-	Fallback   interface{}
+	fallback   interface{}
 
 	spyCalls []MethodCall
+	// lastID numbers calls so results can be matched to them. It is never reset.
+	lastID uint64
+	// noClone records arguments and results by reference instead of deep copying them
+	noClone bool
+
+	// observer sees the arguments and results of each call, keyed in pending until it returns
+	observer func(args, results []any)
+	pending  map[uint64][]any
+
+	sequence *Sequence
+	recorder *Recorder
+	mockName string
+	name     string
+	// recordings holds the Recorder entries of calls in flight, until EndCall
+	recordings map[uint64]recording
+
+	faults *faultInjector
+	gate   *Gate
+	clock  Clock
+	// leaks tracks calls held at gates for the test passed to TrackLeaks, or is nil
+	leaks *leakRegistry
+	// t is the test passed to ReportTo, or nil
+	t TB
+
+	// concurrency counts in-flight calls. It is kept across Restore, since calls in flight still end.
+	concurrency concurrencyTracker
+
+	// usage counts calls and configuration for the coverage report, or is nil if untracked
+	usage             *methodUsage
+	probe             *usageProbe
+	calledSinceEnable bool
+
+	// changed is closed and replaced each time a spy call or its results are recorded
+	changed chan struct{}
+
+	// version is incremented on every configuration change
+	version uint64
 }
 
-func (m *MethodConfig[T]) RecordCall(args ...any) {
-	if !m.SpyEnabled {
-		return
+// Enable turns the mock on so calls are answered from the queue or fallback
+func (m *MethodConfig[T]) Enable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.countEnable(true)
+	m.enabled = true
+}
+
+// Disable turns the mock off so calls go to the real implementation
+func (m *MethodConfig[T]) Disable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.countEnable(false)
+	m.enabled = false
+}
+
+// IsEnabled reports whether the mock is on
+func (m *MethodConfig[T]) IsEnabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.enabled
+}
+
+// EnableSpy starts recording calls
+func (m *MethodConfig[T]) EnableSpy() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.spyEnabled = true
+}
+
+// DisableSpy stops recording calls. Calls already recorded are kept.
+func (m *MethodConfig[T]) DisableSpy() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.spyEnabled = false
+}
+
+// IsSpyEnabled reports whether calls are being recorded
+func (m *MethodConfig[T]) IsSpyEnabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.spyEnabled
+}
+
+// SetClock sets the Clock used for call timestamps and delays. A nil clock means real time.
+func (m *MethodConfig[T]) SetClock(c Clock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = c
+}
+
+// Clock returns the Clock used by the method, defaulting to real time
+func (m *MethodConfig[T]) Clock() Clock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getClock()
+}
+
+// getClock returns the configured Clock, defaulting to real time. Callers must hold m.mu.
+func (m *MethodConfig[T]) getClock() Clock {
+	if m.clock == nil {
+		return RealClock()
 	}
+	return m.clock
+}
+
+// AttachSequence records every call to the method in seq under mockName.methodName, whether or not the spy is enabled
+func (m *MethodConfig[T]) AttachSequence(seq *Sequence, mockName, methodName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.sequence = seq
+	m.mockName = mockName
+	m.name = methodName
+}
+
+// RecordCall sequences the call and stores it if the spy is enabled. Arguments are deep copied with Clone
+// unless cloning is disabled. It returns an id for RecordResults.
+func (m *MethodConfig[T]) RecordCall(args ...any) uint64 {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.spyCalls = append(m.spyCalls, MethodCall{
-		Timestamp: time.Now(),
+	m.lastID++
+	if !m.noClone && (m.spyEnabled || m.sequence != nil || m.observer != nil || m.recorder != nil) {
+		args = cloneArgs(args)
+	}
+	call := MethodCall{
+		Timestamp: m.getClock().Now(),
 		Args:      args,
-	})
+		id:        m.lastID,
+	}
+	m.concurrency.start(call.id, args)
+	m.countCall()
+	if m.observer != nil {
+		if m.pending == nil {
+			m.pending = make(map[uint64][]any)
+		}
+		m.pending[call.id] = args
+	}
+	if m.sequence != nil {
+		call.Seq = m.sequence.record(m.mockName, m.name, call)
+	}
+	if m.recorder != nil {
+		if m.recordings == nil {
+			m.recordings = make(map[uint64]recording)
+		}
+		m.recordings[call.id] = m.recorder.start(m.mockName, m.name, args, call.Timestamp)
+	}
+	if !m.spyEnabled {
+		return call.id
+	}
+	m.spyCalls = append(m.spyCalls, call)
+	m.notifyChanged()
+	return call.id
+}
+
+// RecordResults stores the values returned by the call with the given id, as returned by RecordCall.
+// It does nothing for calls that were not spied or observed, or have since been cleared.
+func (m *MethodConfig[T]) RecordResults(id uint64, results ...any) {
+	m.mu.Lock()
+	if !m.noClone && (m.spyEnabled || m.sequence != nil || m.observer != nil || m.recorder != nil) {
+		results = cloneArgs(results)
+	}
+	observer := m.observer
+	args, observed := m.pending[id]
+	delete(m.pending, id)
+	if rec, ok := m.recordings[id]; ok {
+		rec.returned(results)
+	}
+	for i := len(m.spyCalls) - 1; i >= 0; i-- {
+		if m.spyCalls[i].id == id {
+			m.spyCalls[i].Results = results
+			m.spyCalls[i].Returned = true
+			m.notifyChanged()
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if observed && observer != nil {
+		observer(args, results)
+	}
+}
+
+// setObserver calls fn with the arguments and results of every call that returns.
+// It is kept by Restore and cleared by Reset.
+func (m *MethodConfig[T]) setObserver(fn func(args, results []any)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observer = fn
+	m.pending = nil
+}
+
+// notifyChanged wakes anything waiting on Changed. Callers must hold m.mu.
+func (m *MethodConfig[T]) notifyChanged() {
+	if m.changed != nil {
+		close(m.changed)
+		m.changed = nil
+	}
+}
+
+// Changed returns a channel that is closed when the next spy call or result is recorded
+func (m *MethodConfig[T]) Changed() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.changed == nil {
+		m.changed = make(chan struct{})
+	}
+	return m.changed
 }
 
 func (m *MethodConfig[T]) CallCount() int {
@@ -60,11 +271,25 @@ func (m *MethodCall) ArgsEqual(expected ...any) bool {
 type QueuedItem[T any] struct {
 	Fn    T
 	Delay time.Duration
+
+	panics     bool
+	panicValue any
 }
 
 // Set a Fallback function
 func (m *MethodConfig[T]) SetResponseFunc(f T) {
-	m.Fallback = f
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.fallback = f
+}
+
+// clearFallback removes the fallback so calls past the queue get zero values
+func (m *MethodConfig[T]) clearFallback() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version++
+	m.fallback = nil
 }
 
 // Set a static value as Fallback
@@ -76,6 +301,8 @@ func (m *MethodConfig[T]) SetStaticResponse(f T) {
 func (m *MethodConfig[T]) EnqueueWithDelay(f T, d time.Duration) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.version++
+	m.countQueued(1)
 	m.queue = append(m.queue, QueuedItem[T]{Fn: f, Delay: d})
 }
 
@@ -88,6 +315,8 @@ func (m *MethodConfig[T]) EnqueueStaticWithDelay(f T, d time.Duration) {
 func (m *MethodConfig[T]) SetResponseFuncQueue(fns []T) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.version++
+	m.countQueued(len(fns))
 	for _, fn := range fns {
 		m.queue = append(m.queue, QueuedItem[T]{Fn: fn, Delay: 0})
 	}
@@ -102,6 +331,8 @@ func (m *MethodConfig[T]) SetResponseFuncOnce(f T) {
 func (m *MethodConfig[T]) SetResponseFuncTimes(f T, times int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.version++
+	m.countQueued(max(times, 0))
 	for i := 0; i < times; i++ {
 		m.queue = append(m.queue, QueuedItem[T]{Fn: f, Delay: 0})
 	}
@@ -111,6 +342,8 @@ func (m *MethodConfig[T]) SetResponseFuncTimes(f T, times int) {
 func (m *MethodConfig[T]) ResetQueue() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.version++
+	m.countDiscarded(len(m.queue))
 	m.queue = nil
 }
 
@@ -121,28 +354,40 @@ func (m *MethodConfig[T]) PeekQueueLength() int {
 	return len(m.queue)
 }
 
-// Get next response from queue or Fallback
+// Get next response from queue or Fallback. Queued delays are slept without holding the lock.
 func (m *MethodConfig[T]) NextResponse(defaultFunc T) T {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	if len(m.queue) > 0 {
 		item := m.queue[0]
 		m.queue = m.queue[1:]
+		clock := m.getClock()
+		m.mu.Unlock()
+
 		if item.Delay > 0 {
-			time.Sleep(item.Delay)
+			clock.Sleep(item.Delay)
+		}
+		if item.panics {
+			panic(item.panicValue)
 		}
 		return item.Fn
 	}
 
-	if f, ok := m.Fallback.(T); ok {
+	fallback := m.fallback
+	m.mu.Unlock()
+
+	if p, ok := fallback.(panicResponse); ok {
+		panic(p.value)
+	}
+
+	if f, ok := fallback.(T); ok {
 		return f
 	}
 
 	return defaultFunc
 }
 
-// TODO test this and use
+// MapsEqual reports whether two maps have the same keys with deeply equal values.
+// Use DiffValues to find out which keys differ.
 func MapsEqual(a, b reflect.Value) bool {
 	if a.Len() != b.Len() {
 		return false
@@ -151,7 +396,7 @@ func MapsEqual(a, b reflect.Value) bool {
 	for _, key := range a.MapKeys() {
 		av := a.MapIndex(key)
 		bv := b.MapIndex(key)
-		if !bv.IsValid() || !reflect.DeepEqual(av.Interface(), bv.Interface()) {
+		if !bv.IsValid() || !DiffValues(av.Interface(), bv.Interface()).Equal() {
 			return false
 		}
 	}
@@ -159,6 +404,15 @@ func MapsEqual(a, b reflect.Value) bool {
 	return true
 }
 
+// TB is the part of testing.TB the stubs helpers report through. *testing.T, *testing.B and *testing.F
+// implement it; PanicTB, FuncTB and IterationTB adapt it for other uses. Every TB is a TestingT.
+type TB interface {
+	TestingT
+	Errorf(format string, args ...any)
+	Cleanup(func())
+}
+
+// TestingT is the smaller interface taken by helpers that only fail the test straight away
 type TestingT interface {
 	Helper()
 	Fatal(args ...any)
@@ -168,10 +422,18 @@ type TestingT interface {
 // WaitForResult waits for a result on a channel or fails after timeout.
 func WaitForResult[T any](t TestingT, ch <-chan T, timeout time.Duration) T {
 	t.Helper()
+	return WaitForResultWithClock(t, RealClock(), ch, timeout)
+}
+
+// WaitForResultWithClock is WaitForResult with the timeout measured on clock.
+func WaitForResultWithClock[T any](t TestingT, clock Clock, ch <-chan T, timeout time.Duration) T {
+	t.Helper()
+	deadline := clock.NewTimer(timeout)
+	defer deadline.Stop()
 	select {
 	case result := <-ch:
 		return result
-	case <-time.After(timeout):
+	case <-deadline.C():
 		t.Fatalf("timeout waiting for background task")
 		var zero T
 		return zero
@@ -183,64 +445,106 @@ func MustPanic(t TestingT, fn func()) {
 	t.Helper()
 	defer func() {
 		if r := recover(); r == nil {
-			t.Fatal("expected panic, but got none")
+			t.Fatalf("expected panic, but got none")
 		}
 	}()
 	fn()
 }
 
 // WaitForSpyCall blocks until at least one spy call is recorded or times out.
-func WaitForSpyCall(t TestingT, getCalls func() []MethodCall, timeout time.Duration) {
+func WaitForSpyCall(t TestingT, src CallSource, timeout time.Duration) {
 	t.Helper()
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		if len(getCalls()) > 0 {
-			return
-		}
-		time.Sleep(10 * time.Millisecond)
+	WaitForSpyCallWithClock(t, src.Clock(), src, timeout)
+}
+
+// WaitForSpyCallWithClock is WaitForSpyCall with the timeout measured on clock.
+func WaitForSpyCallWithClock(t TestingT, clock Clock, src CallSource, timeout time.Duration) {
+	t.Helper()
+	if _, ok := awaitCalls(clockSource{src, clock}, func(calls []MethodCall) bool { return len(calls) > 0 }, timeout, nil); !ok {
+		t.Fatalf("timeout waiting for spy call")
 	}
-	t.Fatal("timeout waiting for spy call")
 }
 
 // WaitForSpyCallMatching waits until a spy call matching the condition is recorded or times out.
-func WaitForSpyCallMatching(t TestingT, getCalls func() []MethodCall, match func(MethodCall) bool, timeout time.Duration) {
+func WaitForSpyCallMatching(t TestingT, src CallSource, match func(MethodCall) bool, timeout time.Duration) {
+	t.Helper()
+	WaitForSpyCallMatchingWithClock(t, src.Clock(), src, match, timeout)
+}
+
+// WaitForSpyCallMatchingWithClock is WaitForSpyCallMatching with the timeout measured on clock.
+func WaitForSpyCallMatchingWithClock(t TestingT, clock Clock, src CallSource, match func(MethodCall) bool, timeout time.Duration) {
 	t.Helper()
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		for _, call := range getCalls() {
+	_, matched := awaitCalls(clockSource{src, clock}, func(calls []MethodCall) bool {
+		for _, call := range calls {
 			if match(call) {
-				return
+				return true
 			}
 		}
-
-		time.Sleep(10 * time.Millisecond)
+		return false
+	}, timeout, nil)
+	if !matched {
+		t.Fatalf("timeout waiting for matching spy call")
 	}
-	t.Fatal("timeout waiting for matching spy call")
 }
 
 // WaitForSpyCallArgsEqual waits until a spy call with matching args is recorded or times out.
-func WaitForSpyCallArgsEqual(t TestingT, getCalls func() []MethodCall, timeout time.Duration, expectedArgs ...any) {
-	WaitForSpyCallMatching(t, getCalls, func(call MethodCall) bool {
-		return call.ArgsEqual(expectedArgs...)
-	}, timeout)
+func WaitForSpyCallArgsEqual(t TestingT, src CallSource, timeout time.Duration, expectedArgs ...any) {
+	t.Helper()
+	WaitForSpyCallArgsEqualWithClock(t, src.Clock(), src, timeout, expectedArgs...)
 }
 
-func WaitForMultipleSpyCalls(t TestingT, getCalls func() []MethodCall, timeout time.Duration, expectedArgsList ...[]any) {
+// WaitForSpyCallArgsEqualWithClock is WaitForSpyCallArgsEqual with the timeout measured on clock.
+func WaitForSpyCallArgsEqualWithClock(t TestingT, clock Clock, src CallSource, timeout time.Duration, expectedArgs ...any) {
 	t.Helper()
-	done := make(chan struct{}, len(expectedArgsList))
+	calls, matched := awaitCalls(clockSource{src, clock}, func(calls []MethodCall) bool {
+		for _, call := range calls {
+			if call.ArgsEqual(expectedArgs...) {
+				return true
+			}
+		}
+		return false
+	}, timeout, nil)
+	if !matched {
+		t.Fatalf("timeout waiting for spy call with args %s\n%s", formatArgs(expectedArgs), describeArgMismatch(expectedArgs, calls))
+	}
+}
 
-	for _, args := range expectedArgsList {
-		go func(args []any) {
-			WaitForSpyCallArgsEqual(t, getCalls, timeout, args...)
-			done <- struct{}{}
-		}(args)
+// WaitForMultipleSpyCalls waits until a spy call matching each set of expected args is recorded or times out.
+func WaitForMultipleSpyCalls(t TestingT, src CallSource, timeout time.Duration, expectedArgsList ...[]any) {
+	t.Helper()
+	WaitForMultipleSpyCallsWithClock(t, src.Clock(), src, timeout, expectedArgsList...)
+}
+
+// WaitForMultipleSpyCallsWithClock is WaitForMultipleSpyCalls with the timeout measured on clock.
+func WaitForMultipleSpyCallsWithClock(t TestingT, clock Clock, src CallSource, timeout time.Duration, expectedArgsList ...[]any) {
+	t.Helper()
+	calls, allMatched := awaitCalls(clockSource{src, clock}, func(calls []MethodCall) bool {
+		return len(missingArgs(calls, expectedArgsList)) == 0
+	}, timeout, nil)
+	if !allMatched {
+		missing := missingArgs(calls, expectedArgsList)
+		details := make([]string, len(missing))
+		for i, args := range missing {
+			details[i] = fmt.Sprintf("args %s:\n%s", formatArgs(args), describeArgMismatch(args, calls))
+		}
+		t.Fatalf("timeout waiting for all spy calls, no call matched args %v\n%s", missing, strings.Join(details, "\n"))
 	}
+}
 
-	for i := 0; i < len(expectedArgsList); i++ {
-		select {
-		case <-done:
-		case <-time.After(timeout):
-			t.Fatalf("timeout waiting for all spy calls")
+// missingArgs returns the expected args that no call matches
+func missingArgs(calls []MethodCall, expectedArgsList [][]any) [][]any {
+	var missing [][]any
+	for _, args := range expectedArgsList {
+		found := false
+		for _, call := range calls {
+			if call.ArgsEqual(args...) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, args)
 		}
 	}
+	return missing
 }
@@ -0,0 +1,188 @@
+package stubs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CallSource is a spy call history that signals when new calls are recorded. *MethodConfig implements it.
+type CallSource interface {
+	Calls() []MethodCall
+	Changed() <-chan struct{}
+	Clock() Clock
+	IsSpyEnabled() bool
+}
+
+// clockSource is a CallSource whose timeouts are measured on clock instead of the source's own
+type clockSource struct {
+	CallSource
+	clock Clock
+}
+
+func (s clockSource) Clock() Clock { return s.clock }
+
+// AwaitCalls blocks until cond holds for the recorded calls or timeout elapses on the source's clock.
+// It wakes on each recorded call rather than polling, and never fails the test itself, so it is safe
+// to use from any goroutine. It returns the calls at the time it stopped and whether cond held.
+func AwaitCalls(src CallSource, cond func([]MethodCall) bool, timeout time.Duration) ([]MethodCall, bool) {
+	return awaitCalls(src, cond, timeout, nil)
+}
+
+// awaitCalls is AwaitCalls that also gives up when stop is closed
+func awaitCalls(src CallSource, cond func([]MethodCall) bool, timeout time.Duration, stop <-chan struct{}) ([]MethodCall, bool) {
+	deadline := src.Clock().NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		// take the signal before reading calls so a call recorded in between is not missed
+		changed := src.Changed()
+		calls := src.Calls()
+		if cond(calls) {
+			return calls, true
+		}
+		select {
+		case <-changed:
+		case <-deadline.C():
+			calls = src.Calls()
+			return calls, cond(calls)
+		case <-stop:
+			return calls, false
+		}
+	}
+}
+
+// CaptureCalls watches src in the background and sends its calls on the returned channel once one is recorded.
+// If none arrives within timeout the channel is closed, and t fails naming what when it finishes. The watch
+// stops quietly when t finishes first, so it never outlives the test.
+func CaptureCalls(t TB, src CallSource, what string, timeout time.Duration) <-chan []MethodCall {
+	ch := make(chan []MethodCall, 1)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	var timedOut bool
+	// cleanups run on the test goroutine, so the timeout is reported there rather than from the watch
+	t.Cleanup(func() {
+		close(stop)
+		<-done
+		if timedOut {
+			t.Errorf("timeout waiting for %s", what)
+		}
+	})
+	Go(t, "capture of "+what, func() {
+		defer close(done)
+		defer close(ch)
+		calls, ok := awaitCalls(src, func(calls []MethodCall) bool { return len(calls) > 0 }, timeout, stop)
+		if ok {
+			ch <- calls
+			return
+		}
+		select {
+		case <-stop:
+		default:
+			timedOut = true
+		}
+	})
+	return ch
+}
+
+// Eventually fails the test if cond does not hold for the recorded calls within timeout
+func Eventually(t TB, src CallSource, cond func([]MethodCall) bool, timeout time.Duration) []MethodCall {
+	t.Helper()
+	calls, ok := AwaitCalls(src, cond, timeout)
+	if !ok {
+		t.Fatalf("condition not met within %s\nrecorded calls:\n%s", timeout, formatCalls(calls))
+	}
+	return calls
+}
+
+// WaitForNCalls waits until at least n calls are recorded and returns them, or fails after timeout
+func WaitForNCalls(t TB, src CallSource, n int, timeout time.Duration) []MethodCall {
+	t.Helper()
+	calls, ok := AwaitCalls(src, func(calls []MethodCall) bool { return len(calls) >= n }, timeout)
+	if !ok {
+		t.Fatalf("expected %d calls within %s, got %d\nrecorded calls:\n%s", n, timeout, len(calls), formatCalls(calls))
+	}
+	return calls
+}
+
+// WaitForCallWithin waits until a call matching match is recorded and returns it, or fails after timeout
+func WaitForCallWithin(t TB, src CallSource, match func(MethodCall) bool, timeout time.Duration) MethodCall {
+	t.Helper()
+	var found MethodCall
+	calls, ok := AwaitCalls(src, func(calls []MethodCall) bool {
+		for _, call := range calls {
+			if match(call) {
+				found = call
+				return true
+			}
+		}
+		return false
+	}, timeout)
+	if !ok {
+		t.Fatalf("no matching call within %s\nrecorded calls:\n%s", timeout, formatCalls(calls))
+	}
+	return found
+}
+
+// WaitForCallWithArgs waits until a call with args deeply equal to expected is recorded and returns it.
+// On timeout it fails with the differences between expected and every recorded call.
+func WaitForCallWithArgs(t TB, src CallSource, timeout time.Duration, expected ...any) MethodCall {
+	t.Helper()
+	var found MethodCall
+	calls, ok := AwaitCalls(src, func(calls []MethodCall) bool {
+		for _, call := range calls {
+			if call.ArgsEqual(expected...) {
+				found = call
+				return true
+			}
+		}
+		return false
+	}, timeout)
+	if !ok {
+		t.Fatalf("no call with args %s within %s\n%s", formatArgs(expected), timeout, describeArgMismatch(expected, calls))
+	}
+	return found
+}
+
+// AssertArgs fails the test with a structured diff if call's args are not deeply equal to expected
+func AssertArgs(t TB, call MethodCall, expected ...any) {
+	t.Helper()
+	if diff := call.DiffArgs(expected...); !diff.Equal() {
+		t.Fatalf("call %s did not match expected args %s\n%s", formatArgs(call.Args), formatArgs(expected), diff)
+	}
+}
+
+// Consistently fails the test if any call is recorded during window, or if src is not recording calls at all
+func Consistently(t TB, src CallSource, window time.Duration) {
+	t.Helper()
+	if !src.IsSpyEnabled() {
+		t.Fatalf("cannot check for calls during %s, the spy is not enabled", window)
+		return
+	}
+	deadline := src.Clock().NewTimer(window)
+	defer deadline.Stop()
+	before := len(src.Calls())
+	for {
+		changed := src.Changed()
+		calls := src.Calls()
+		if len(calls) > before {
+			t.Fatalf("expected no calls for %s, got %d\nunexpected calls:\n%s", window, len(calls)-before, formatCalls(calls[before:]))
+			return
+		}
+		select {
+		case <-changed:
+		case <-deadline.C():
+			return
+		}
+	}
+}
+
+func formatCalls(calls []MethodCall) string {
+	if len(calls) == 0 {
+		return "  (none)"
+	}
+	lines := make([]string, len(calls))
+	for i, call := range calls {
+		lines[i] = fmt.Sprintf("  %d: %#v", i+1, call.Args)
+	}
+	return strings.Join(lines, "\n")
+}
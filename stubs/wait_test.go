@@ -0,0 +1,186 @@
+package stubs
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForNCallsWakesOnRecord(t *testing.T) {
+	var m MethodConfig[func(int)]
+	m.EnableSpy()
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			m.RecordCall(i)
+		}
+	}()
+
+	calls := WaitForNCalls(t, &m, 3, time.Second)
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	call := WaitForCallWithin(t, &m, func(c MethodCall) bool { return c.ArgsEqual(2) }, time.Second)
+	if !call.ArgsEqual(2) {
+		t.Fatalf("expected call with arg 2, got %v", call.Args)
+	}
+}
+
+func TestWaitForNCallsTimesOutOnFakeClock(t *testing.T) {
+	var m MethodConfig[func(int)]
+	clock := NewFakeClock(time.Now())
+	m.SetClock(clock)
+	m.EnableSpy()
+	m.RecordCall(1)
+
+	ft := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		WaitForNCalls(ft, &m, 2, time.Minute)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	WaitForResult(t, done, time.Second)
+	if !strings.Contains(ft.msg, "expected 2 calls within 1m0s, got 1") {
+		t.Fatalf("unexpected failure message %q", ft.msg)
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	var m MethodConfig[func(string)]
+	clock := NewFakeClock(time.Now())
+	m.SetClock(clock)
+	m.EnableSpy()
+	m.RecordCall("before")
+
+	// no call during the window passes
+	ft := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Consistently(ft, &m, time.Second)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	WaitForResult(t, done, time.Second)
+	if ft.failed {
+		t.Fatalf("unexpected failure: %s", ft.msg)
+	}
+
+	// a call during the window fails
+	ft = &fakeT{}
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		Consistently(ft, &m, time.Second)
+	}()
+	clock.BlockUntil(1)
+	m.RecordCall("during")
+	WaitForResult(t, done, time.Second)
+	if !strings.Contains(ft.msg, `"during"`) || strings.Contains(ft.msg, `"before"`) {
+		t.Fatalf("expected only the unexpected call in the failure, got %q", ft.msg)
+	}
+}
+
+func TestWaitForMultipleSpyCallsReportsMissing(t *testing.T) {
+	var m MethodConfig[func(int)]
+	m.EnableSpy()
+	m.RecordCall(1)
+
+	ft := &fakeT{}
+	WaitForMultipleSpyCalls(ft, &m, 20*time.Millisecond, []any{1}, []any{2})
+	if !strings.Contains(ft.msg, "[[2]]") {
+		t.Fatalf("expected missing args in failure, got %q", ft.msg)
+	}
+}
+
+func TestWaitsStopTheirTimers(t *testing.T) {
+	var m MethodConfig[func(int)]
+	clock := NewFakeClock(time.Now())
+	m.SetClock(clock)
+	m.EnableSpy()
+	m.RecordCall(1)
+
+	WaitForNCalls(t, &m, 1, time.Minute)
+	WaitForResultWithClock(t, clock, closedChan(), time.Minute)
+	ft := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Consistently(ft, &m, time.Minute)
+	}()
+	clock.BlockUntil(1)
+	m.RecordCall(2)
+	WaitForResult(t, done, time.Second)
+	if n := clock.Waiters(); n != 0 {
+		t.Fatalf("expected finished waits to leave no waiters, got %d", n)
+	}
+
+	// BlockUntil now only returns once the next sleeper is really waiting
+	slept := make(chan struct{})
+	go func() {
+		m.EnqueueWithDelay(func(int) {}, time.Second)
+		m.NextResponse(nil)
+		close(slept)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	WaitForResult(t, slept, time.Second)
+}
+
+func closedChan() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+// countingSource counts how often a wait reads the calls of its source
+type countingSource struct {
+	CallSource
+	checks atomic.Int32
+}
+
+func (s *countingSource) Calls() []MethodCall {
+	s.checks.Add(1)
+	return s.CallSource.Calls()
+}
+
+func TestWaitForSpyCallWakesOnRecord(t *testing.T) {
+	var m, other MethodConfig[func(int)]
+	clock := NewFakeClock(time.Now())
+	m.EnableSpy()
+	other.EnableSpy()
+
+	src := &countingSource{CallSource: &m}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		WaitForSpyCallArgsEqualWithClock(t, clock, src, time.Minute, 2)
+	}()
+
+	clock.BlockUntil(1)
+	// calls recorded by other methods do not wake the wait
+	other.RecordCall(2)
+	time.Sleep(50 * time.Millisecond)
+	if n := src.checks.Load(); n > 1 {
+		t.Fatalf("expected the wait to block until a call is recorded, checked %d times", n)
+	}
+	m.RecordCall(1)
+	m.RecordCall(2)
+	WaitForResult(t, done, time.Second)
+	if n := clock.Waiters(); n != 0 {
+		t.Fatalf("expected the wait to stop its timer, got %d waiters", n)
+	}
+}
+
+func TestConsistentlyFailsWithoutSpy(t *testing.T) {
+	var m MethodConfig[func()]
+	ft := &fakeT{}
+	Consistently(ft, &m, time.Minute)
+	if !ft.failed || !strings.Contains(ft.msg, "spy is not enabled") {
+		t.Fatalf("expected Consistently to fail when the spy is off, got %q", ft.msg)
+	}
+}